@@ -0,0 +1,43 @@
+package health
+
+import (
+	"encoding/json"
+	"hinsun-backend/adapters/shared/health"
+	"net/http"
+)
+
+// HealthRoutes serves the probes used by the orchestrator and load balancers
+type HealthRoutes struct {
+	readiness *health.Readiness
+}
+
+func NewHealthRoutes(readiness *health.Readiness) *HealthRoutes {
+	return &HealthRoutes{
+		readiness: readiness,
+	}
+}
+
+// Livez reports that the process is up and serving, it never checks dependencies so an
+// outage of the database does not get every instance restarted
+func (hr *HealthRoutes) Livez(w http.ResponseWriter, r *http.Request) {
+	writeReport(w, http.StatusOK, &health.Report{Status: health.StatusUp, Checks: []health.CheckResult{}})
+}
+
+// Readyz reports whether the instance can serve traffic, with the status and latency of each check
+func (hr *HealthRoutes) Readyz(w http.ResponseWriter, r *http.Request) {
+	report := hr.readiness.Check(r.Context())
+
+	statusCode := http.StatusOK
+	if report.Status != health.StatusUp {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	writeReport(w, statusCode, report)
+}
+
+func writeReport(w http.ResponseWriter, statusCode int, report *health.Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(report)
+}
@@ -8,6 +8,7 @@ import (
 	"hinsun-backend/internal/core/failure"
 	"hinsun-backend/internal/domain/applications"
 	"hinsun-backend/internal/domain/usecases"
+	"hinsun-backend/internal/domain/values"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -15,23 +16,23 @@ import (
 )
 
 type AccountHandler struct {
-	app            applications.AccountAppService
-	validator      *validator.Validate
-	authMiddleware *middlewares.AuthMiddleware
-	roleMiddleware *middlewares.RoleMiddleware
+	app                  applications.AccountAppService
+	validator            *validator.Validate
+	authMiddleware       *middlewares.AuthMiddleware
+	permissionMiddleware *middlewares.PermissionMiddleware
 }
 
 func NewAccountHandler(
 	app applications.AccountAppService,
 	validator *validator.Validate,
 	authMiddleware *middlewares.AuthMiddleware,
-	roleMiddleware *middlewares.RoleMiddleware,
+	permissionMiddleware *middlewares.PermissionMiddleware,
 ) *AccountHandler {
 	return &AccountHandler{
-		app:            app,
-		validator:      validator,
-		authMiddleware: authMiddleware,
-		roleMiddleware: roleMiddleware,
+		app:                  app,
+		validator:            validator,
+		authMiddleware:       authMiddleware,
+		permissionMiddleware: permissionMiddleware,
 	}
 }
 
@@ -39,8 +40,8 @@ func (h *AccountHandler) Handler() chi.Router {
 	r := chi.NewRouter()
 
 	r.With(h.authMiddleware.RequireAuth).Get("/", h.findAllAccounts)
-	r.With(h.authMiddleware.RequireAuth, h.roleMiddleware.RequireGod).Post("/", h.createAccount)
-	r.With(h.authMiddleware.RequireAuth, h.roleMiddleware.RequireGod).Delete("/", h.deleteMultipleAccounts)
+	r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.AccountManagePermission)).Post("/", h.createAccount)
+	r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.AccountManagePermission)).Delete("/", h.deleteMultipleAccounts)
 
 	r.Get("/search", h.searchAccounts)
 	r.With(h.authMiddleware.RequireAuth).Get("/profile", h.findAccountProfile)
@@ -48,8 +49,12 @@ func (h *AccountHandler) Handler() chi.Router {
 	r.Route("/{id}", func(r chi.Router) {
 		r.Get("/", h.findAccountByID)
 
-		r.With(h.authMiddleware.RequireAuth, h.roleMiddleware.RequireGod).Put("/", h.updateAccount)
-		r.With(h.authMiddleware.RequireAuth, h.roleMiddleware.RequireGod).Delete("/", h.deleteAccount)
+		r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.AccountManagePermission)).Put("/", h.updateAccount)
+		r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.AccountManagePermission)).Patch("/", h.patchAccount)
+		r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.AccountManagePermission)).Delete("/", h.deleteAccount)
+
+		r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.AccountManagePermission)).Get("/roles", h.findAccountRoles)
+		r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.RoleManagePermission)).Put("/roles", h.assignAccountRoles)
 	})
 
 	return r
@@ -132,11 +137,18 @@ func (h *AccountHandler) findAccountByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	https.SetETag(w, account.Version)
 	https.ResponseSuccess(w, http.StatusOK, "Account retrieved successfully", account)
 }
 
 func (h *AccountHandler) updateAccount(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
+	expectedVersion, err := https.ParseIfMatch(r)
+	if err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
 	var params usecases.UpdateAccountParams
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
 		https.BadRequest(w, err)
@@ -148,12 +160,54 @@ func (h *AccountHandler) updateAccount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	updatedAccount, err := h.app.UpdateAccount(r.Context(), id, &params)
+	updatedAccount, err := h.app.UpdateAccount(r.Context(), id, expectedVersion, &params)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.SetETag(w, updatedAccount.Version)
+	https.ResponseSuccess(w, http.StatusOK, "Account updated successfully", updatedAccount)
+}
+
+// patchAccount applies a JSON merge patch on top of the current account, so omitted fields keep their values
+func (h *AccountHandler) patchAccount(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	expectedVersion, err := https.ParseIfMatch(r)
+	if err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	currentAccount, err := h.app.FindAccountByID(r.Context(), id)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	// The patch was computed against this read, so the write must not overwrite a newer version
+	if expectedVersion == nil {
+		expectedVersion = &currentAccount.Version
+	}
+
+	params := usecases.NewUpdateAccountParams(currentAccount)
+	if err := https.DecodeMergePatch(r, params); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(params); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	updatedAccount, err := h.app.UpdateAccount(r.Context(), id, expectedVersion, params)
 	if err != nil {
 		https.RespondWithFailure(w, err)
 		return
 	}
 
+	https.SetETag(w, updatedAccount.Version)
 	https.ResponseSuccess(w, http.StatusOK, "Account updated successfully", updatedAccount)
 }
 
@@ -183,3 +237,36 @@ func (h *AccountHandler) findAccountProfile(w http.ResponseWriter, r *http.Reque
 
 	https.ResponseSuccess(w, http.StatusOK, "Account profile retrieved successfully", account)
 }
+
+func (h *AccountHandler) findAccountRoles(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	accountRoles, err := h.app.FindAccountRoles(r.Context(), id)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Account roles retrieved successfully", accountRoles)
+}
+
+func (h *AccountHandler) assignAccountRoles(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var params usecases.AssignAccountRolesParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(params); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	accountRoles, err := h.app.AssignAccountRoles(r.Context(), id, &params)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Account roles assigned successfully", accountRoles)
+}
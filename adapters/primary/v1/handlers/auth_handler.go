@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"hinsun-backend/adapters/shared/https"
 	"hinsun-backend/adapters/shared/middlewares"
+	"hinsun-backend/internal/core/failure"
 	"hinsun-backend/internal/domain/applications"
 	"hinsun-backend/internal/domain/usecases"
+	"hinsun-backend/internal/domain/values"
+	"hinsun-backend/pkg/jwt"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -13,23 +16,23 @@ import (
 )
 
 type AuthHandler struct {
-	app            applications.AuthAppService
-	validator      *validator.Validate
-	authMiddleware *middlewares.AuthMiddleware
-	roleMiddleware *middlewares.RoleMiddleware
+	app                  applications.AuthAppService
+	validator            *validator.Validate
+	authMiddleware       *middlewares.AuthMiddleware
+	permissionMiddleware *middlewares.PermissionMiddleware
 }
 
 func NewAuthHandler(
 	app applications.AuthAppService,
 	validator *validator.Validate,
 	authMiddleware *middlewares.AuthMiddleware,
-	roleMiddleware *middlewares.RoleMiddleware,
+	permissionMiddleware *middlewares.PermissionMiddleware,
 ) *AuthHandler {
 	return &AuthHandler{
-		app:            app,
-		validator:      validator,
-		authMiddleware: authMiddleware,
-		roleMiddleware: roleMiddleware,
+		app:                  app,
+		validator:            validator,
+		authMiddleware:       authMiddleware,
+		permissionMiddleware: permissionMiddleware,
 	}
 }
 
@@ -40,6 +43,25 @@ func (h *AuthHandler) Handler() chi.Router {
 	r.Post("/refresh", h.refreshTokens)
 	r.With(h.authMiddleware.RequireAuth).Post("/signout", h.signOut)
 
+	r.Route("/2fa", func(r chi.Router) {
+		r.Post("/verify", h.verifyTwoFactor)
+
+		r.With(h.authMiddleware.RequireAuth).Post("/enroll", h.enrollTwoFactor)
+		r.With(h.authMiddleware.RequireAuth).Post("/activate", h.activateTwoFactor)
+		r.With(h.authMiddleware.RequireAuth).Post("/disable", h.disableTwoFactor)
+		r.With(h.authMiddleware.RequireAuth).Post("/recovery-codes", h.regenerateRecoveryCodes)
+	})
+
+	r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.SystemKeysPermission)).Post("/keys/rotate", h.rotateSigningKeys)
+
+	r.Route("/tokens", func(r chi.Router) {
+		r.Use(h.authMiddleware.RequireAuth)
+
+		r.Get("/", h.findAccessTokens)
+		r.Post("/", h.createAccessToken)
+		r.Delete("/{id}", h.revokeAccessToken)
+	})
+
 	return r
 }
 
@@ -61,6 +83,11 @@ func (h *AuthHandler) authEmail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if response.TwoFactorRequired {
+		https.ResponseSuccess(w, http.StatusAccepted, "Two-factor authentication required", response)
+		return
+	}
+
 	https.ResponseSuccess(w, http.StatusOK, "Authentication successful", response)
 }
 
@@ -94,3 +121,197 @@ func (h *AuthHandler) signOut(w http.ResponseWriter, r *http.Request) {
 
 	https.ResponseSuccess(w, http.StatusOK, "Signed out successfully", nil)
 }
+
+// ================================== Two-Factor Authentication Handlers =================================
+
+func (h *AuthHandler) verifyTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var params usecases.VerifyTwoFactorParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(params); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	response, err := h.app.VerifyTwoFactor(r.Context(), &params)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Authentication successful", response)
+}
+
+func (h *AuthHandler) enrollTwoFactor(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.GetClaimsFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	response, err := h.app.EnrollTwoFactor(r.Context(), claims.AccountID)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Two-factor enrollment started", response)
+}
+
+func (h *AuthHandler) activateTwoFactor(w http.ResponseWriter, r *http.Request) {
+	claims, params, ok := h.bindTwoFactorCode(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.app.ActivateTwoFactor(r.Context(), claims.AccountID, params)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Two-factor authentication enabled", response)
+}
+
+func (h *AuthHandler) disableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	claims, params, ok := h.bindTwoFactorCode(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.app.DisableTwoFactor(r.Context(), claims.AccountID, params); err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Two-factor authentication disabled", nil)
+}
+
+func (h *AuthHandler) regenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	claims, params, ok := h.bindTwoFactorCode(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.app.RegenerateRecoveryCodes(r.Context(), claims.AccountID, params)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Recovery codes regenerated", response)
+}
+
+// bindTwoFactorCode extracts the caller's claims and a validated TOTP code from the request
+func (h *AuthHandler) bindTwoFactorCode(w http.ResponseWriter, r *http.Request) (*jwt.Claims, *usecases.TwoFactorCodeParams, bool) {
+	claims, ok := middlewares.GetClaimsFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return nil, nil, false
+	}
+
+	var params usecases.TwoFactorCodeParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		https.BadRequest(w, err)
+		return nil, nil, false
+	}
+
+	if err := h.validator.Struct(params); err != nil {
+		https.ValidationFailed(w, err)
+		return nil, nil, false
+	}
+
+	return claims, &params, true
+}
+
+// ================================== Signing Key Handlers =================================
+
+func (h *AuthHandler) rotateSigningKeys(w http.ResponseWriter, r *http.Request) {
+	response, err := h.app.RotateSigningKeys(r.Context())
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Signing keys rotated successfully", response)
+}
+
+// JWKS serves the public verification keys. The body is the bare JWK set document
+// (RFC 7517) rather than the usual response envelope, so standard JWT libraries can consume it.
+func (h *AuthHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	keySet, err := h.app.FindPublicKeySet(r.Context())
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(keySet)
+}
+
+// ================================== Access Token Handlers =================================
+
+func (h *AuthHandler) findAccessTokens(w http.ResponseWriter, r *http.Request) {
+	actor, ok := middlewares.GetActorFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	tokens, err := h.app.FindAccessTokens(r.Context(), actor)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Access tokens retrieved successfully", tokens)
+}
+
+func (h *AuthHandler) createAccessToken(w http.ResponseWriter, r *http.Request) {
+	actor, ok := middlewares.GetActorFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	var params usecases.CreateAccessTokenParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(params); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	response, err := h.app.CreateAccessToken(r.Context(), actor, &params)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusCreated, "Access token created successfully, store it now as it will not be shown again", response)
+}
+
+func (h *AuthHandler) revokeAccessToken(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	actor, ok := middlewares.GetActorFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	token, err := h.app.RevokeAccessToken(r.Context(), actor, id)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Access token revoked successfully", token)
+}
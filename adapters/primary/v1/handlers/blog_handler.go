@@ -8,6 +8,7 @@ import (
 	"hinsun-backend/internal/core/failure"
 	"hinsun-backend/internal/domain/applications"
 	"hinsun-backend/internal/domain/usecases"
+	"hinsun-backend/internal/domain/values"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -15,23 +16,23 @@ import (
 )
 
 type BlogHandler struct {
-	app            applications.BlogAppService
-	validator      *validator.Validate
-	authMiddleware *middlewares.AuthMiddleware
-	roleMiddleware *middlewares.RoleMiddleware
+	app                  applications.BlogAppService
+	validator            *validator.Validate
+	authMiddleware       *middlewares.AuthMiddleware
+	permissionMiddleware *middlewares.PermissionMiddleware
 }
 
 func NewBlogHandler(
 	app applications.BlogAppService,
 	validator *validator.Validate,
 	authMiddleware *middlewares.AuthMiddleware,
-	roleMiddleware *middlewares.RoleMiddleware,
+	permissionMiddleware *middlewares.PermissionMiddleware,
 ) *BlogHandler {
 	return &BlogHandler{
-		app:            app,
-		validator:      validator,
-		authMiddleware: authMiddleware,
-		roleMiddleware: roleMiddleware,
+		app:                  app,
+		validator:            validator,
+		authMiddleware:       authMiddleware,
+		permissionMiddleware: permissionMiddleware,
 	}
 }
 
@@ -39,13 +40,14 @@ func (h *BlogHandler) Handler() chi.Router {
 	r := chi.NewRouter()
 
 	r.Get("/", h.findBlogs)
-	r.With(h.authMiddleware.RequireAuth, h.roleMiddleware.RequireAdmin).Post("/", h.createBlog)
-	r.With(h.authMiddleware.RequireAuth, h.roleMiddleware.RequireAdmin).Delete("/", h.deleteMultipleBlogs)
+	r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.BlogWritePermission)).Post("/", h.createBlog)
+	r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.BlogManagePermission)).Delete("/", h.deleteMultipleBlogs)
 
 	r.Route("/{id}", func(r chi.Router) {
 		r.Get("/", h.findBlog)
-		r.With(h.authMiddleware.RequireAuth, h.roleMiddleware.RequireAdmin).Delete("/", h.deleteBlog)
-		r.With(h.authMiddleware.RequireAuth, h.roleMiddleware.RequireAdmin).Put("/", h.updateBlog)
+		r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.BlogWritePermission)).Delete("/", h.deleteBlog)
+		r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.BlogWritePermission)).Put("/", h.updateBlog)
+		r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.BlogWritePermission)).Patch("/", h.patchBlog)
 
 		r.Route("/comments", func(r chi.Router) {
 			r.Get("/", h.findBlogComments)
@@ -83,6 +85,8 @@ func (h *BlogHandler) findBlog(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	https.SetETag(w, blog.Version)
+	https.SetLastModified(w, blog.UpdatedAt)
 	https.ResponseSuccess(w, http.StatusOK, "Blog retrieved successfully", blog)
 }
 
@@ -98,7 +102,14 @@ func (h *BlogHandler) createBlog(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	blog, err := h.app.CreateBlog(r.Context(), &params)
+	// Get actor from context (set by AuthMiddleware)
+	actor, ok := middlewares.GetActorFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	blog, err := h.app.CreateBlog(r.Context(), actor, &params)
 	if err != nil {
 		https.RespondWithFailure(w, err)
 		return
@@ -109,6 +120,12 @@ func (h *BlogHandler) createBlog(w http.ResponseWriter, r *http.Request) {
 
 func (h *BlogHandler) updateBlog(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
+	expectedVersion, err := https.ParseIfMatch(r)
+	if err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
 	var params usecases.UpdateBlogParams
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
 		https.BadRequest(w, err)
@@ -120,18 +137,82 @@ func (h *BlogHandler) updateBlog(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	updatedBlog, err := h.app.UpdateBlog(r.Context(), id, &params)
+	// Get actor from context (set by AuthMiddleware)
+	actor, ok := middlewares.GetActorFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	updatedBlog, err := h.app.UpdateBlog(r.Context(), actor, id, expectedVersion, &params)
 	if err != nil {
 		https.RespondWithFailure(w, err)
 		return
 	}
 
+	https.SetETag(w, updatedBlog.Version)
+	https.ResponseSuccess(w, http.StatusOK, "Blog updated successfully", updatedBlog)
+}
+
+// patchBlog applies a JSON merge patch on top of the current blog, so omitted fields keep their values
+func (h *BlogHandler) patchBlog(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	expectedVersion, err := https.ParseIfMatch(r)
+	if err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	currentBlog, err := h.app.FindBlogForUpdate(r.Context(), id)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	// The patch was computed against this read, so the write must not overwrite a newer version
+	if expectedVersion == nil {
+		expectedVersion = &currentBlog.Version
+	}
+
+	params := usecases.NewUpdateBlogParams(currentBlog)
+	if err := https.DecodeMergePatch(r, params); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(params); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	// Get actor from context (set by AuthMiddleware)
+	actor, ok := middlewares.GetActorFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	updatedBlog, err := h.app.UpdateBlog(r.Context(), actor, id, expectedVersion, params)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.SetETag(w, updatedBlog.Version)
 	https.ResponseSuccess(w, http.StatusOK, "Blog updated successfully", updatedBlog)
 }
 
 func (h *BlogHandler) deleteBlog(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
-	deletedResult, err := h.app.DeleteBlog(r.Context(), id)
+
+	// Get actor from context (set by AuthMiddleware)
+	actor, ok := middlewares.GetActorFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	deletedResult, err := h.app.DeleteBlog(r.Context(), actor, id)
 	if err != nil {
 		https.RespondWithFailure(w, err)
 		return
@@ -216,6 +297,12 @@ func (h *BlogHandler) updateCommentOnBlog(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	expectedVersion, err := https.ParseIfMatch(r)
+	if err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
 	var params usecases.UpdateCommentOnBlogParams
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
 		https.BadRequest(w, err)
@@ -227,12 +314,13 @@ func (h *BlogHandler) updateCommentOnBlog(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	updatedComment, err := h.app.UpdateCommentOnBlog(r.Context(), blogId, claims.AccountID, commentId, &params)
+	updatedComment, err := h.app.UpdateCommentOnBlog(r.Context(), blogId, claims.AccountID, commentId, expectedVersion, &params)
 	if err != nil {
 		https.RespondWithFailure(w, err)
 		return
 	}
 
+	https.SetETag(w, updatedComment.Version)
 	https.ResponseSuccess(w, http.StatusOK, "Comment updated successfully", updatedComment)
 }
 
@@ -240,14 +328,14 @@ func (h *BlogHandler) deleteCommentOnBlog(w http.ResponseWriter, r *http.Request
 	blogId := chi.URLParam(r, "id")
 	commentId := chi.URLParam(r, "commentId")
 
-	// Get claims from context (set by AuthMiddleware)
-	claims, ok := middlewares.GetClaimsFromContext(r.Context())
+	// Get actor from context (set by AuthMiddleware)
+	actor, ok := middlewares.GetActorFromContext(r.Context())
 	if !ok {
 		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
 		return
 	}
 
-	deletedResult, err := h.app.DeleteCommentOnBlog(r.Context(), blogId, claims.AccountID, commentId)
+	deletedResult, err := h.app.DeleteCommentOnBlog(r.Context(), blogId, actor, commentId)
 	if err != nil {
 		https.RespondWithFailure(w, err)
 		return
@@ -7,6 +7,7 @@ import (
 	"hinsun-backend/adapters/shared/middlewares"
 	"hinsun-backend/internal/domain/applications"
 	"hinsun-backend/internal/domain/usecases"
+	"hinsun-backend/internal/domain/values"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -14,23 +15,23 @@ import (
 )
 
 type CategoryHandler struct {
-	app            applications.CategoryAppService
-	validator      *validator.Validate
-	authMiddleware *middlewares.AuthMiddleware
-	roleMiddleware *middlewares.RoleMiddleware
+	app                  applications.CategoryAppService
+	validator            *validator.Validate
+	authMiddleware       *middlewares.AuthMiddleware
+	permissionMiddleware *middlewares.PermissionMiddleware
 }
 
 func NewCategoryHandler(
 	app applications.CategoryAppService,
 	validator *validator.Validate,
 	authMiddleware *middlewares.AuthMiddleware,
-	roleMiddleware *middlewares.RoleMiddleware,
+	permissionMiddleware *middlewares.PermissionMiddleware,
 ) *CategoryHandler {
 	return &CategoryHandler{
-		app:            app,
-		validator:      validator,
-		authMiddleware: authMiddleware,
-		roleMiddleware: roleMiddleware,
+		app:                  app,
+		validator:            validator,
+		authMiddleware:       authMiddleware,
+		permissionMiddleware: permissionMiddleware,
 	}
 }
 
@@ -38,13 +39,13 @@ func (h *CategoryHandler) Handler() chi.Router {
 	r := chi.NewRouter()
 
 	r.Get("/", h.findAllCategories)
-	r.With(h.authMiddleware.RequireAuth, h.roleMiddleware.RequireAdmin).Post("/", h.createCategory)
-	r.With(h.authMiddleware.RequireAuth, h.roleMiddleware.RequireAdmin).Delete("/", h.deleteMultipleCategories)
+	r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.CategoryWritePermission)).Post("/", h.createCategory)
+	r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.CategoryWritePermission)).Delete("/", h.deleteMultipleCategories)
 
 	r.Route("/{id}", func(r chi.Router) {
 		r.Get("/", h.findCategoryByID)
-		r.With(h.authMiddleware.RequireAuth, h.roleMiddleware.RequireAdmin).Put("/", h.updateCategory)
-		r.With(h.authMiddleware.RequireAuth, h.roleMiddleware.RequireAdmin).Delete("/", h.deleteCategory)
+		r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.CategoryWritePermission)).Put("/", h.updateCategory)
+		r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.CategoryWritePermission)).Delete("/", h.deleteCategory)
 	})
 
 	return r
@@ -68,6 +69,8 @@ func (h *CategoryHandler) findCategoryByID(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	https.SetETag(w, category.Version)
+	https.SetLastModified(w, category.UpdatedAt)
 	https.ResponseSuccess(w, http.StatusOK, "Category retrieved successfully", category)
 }
 
@@ -94,6 +97,12 @@ func (h *CategoryHandler) createCategory(w http.ResponseWriter, r *http.Request)
 
 func (h *CategoryHandler) updateCategory(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
+	expectedVersion, err := https.ParseIfMatch(r)
+	if err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
 	var params usecases.UpdateCategoryParams
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
 		https.BadRequest(w, err)
@@ -105,12 +114,13 @@ func (h *CategoryHandler) updateCategory(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	updatedCategory, err := h.app.UpdateCategory(r.Context(), id, &params)
+	updatedCategory, err := h.app.UpdateCategory(r.Context(), id, expectedVersion, &params)
 	if err != nil {
 		https.RespondWithFailure(w, err)
 		return
 	}
 
+	https.SetETag(w, updatedCategory.Version)
 	https.ResponseSuccess(w, http.StatusOK, "Category updated successfully", updatedCategory)
 }
 
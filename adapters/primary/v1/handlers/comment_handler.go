@@ -7,6 +7,7 @@ import (
 	"hinsun-backend/adapters/shared/middlewares"
 	"hinsun-backend/internal/domain/applications"
 	"hinsun-backend/internal/domain/usecases"
+	"hinsun-backend/internal/domain/values"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -14,29 +15,29 @@ import (
 )
 
 type CommentHandler struct {
-	app            applications.CommentAppService
-	validator      *validator.Validate
-	authMiddleware *middlewares.AuthMiddleware
-	roleMiddleware *middlewares.RoleMiddleware
+	app                  applications.CommentAppService
+	validator            *validator.Validate
+	authMiddleware       *middlewares.AuthMiddleware
+	permissionMiddleware *middlewares.PermissionMiddleware
 }
 
 func NewCommentHandler(
 	app applications.CommentAppService,
 	validator *validator.Validate,
 	authMiddleware *middlewares.AuthMiddleware,
-	roleMiddleware *middlewares.RoleMiddleware,
+	permissionMiddleware *middlewares.PermissionMiddleware,
 ) *CommentHandler {
 	return &CommentHandler{
-		app:            app,
-		validator:      validator,
-		authMiddleware: authMiddleware,
-		roleMiddleware: roleMiddleware,
+		app:                  app,
+		validator:            validator,
+		authMiddleware:       authMiddleware,
+		permissionMiddleware: permissionMiddleware,
 	}
 }
 
 func (h *CommentHandler) Handler() chi.Router {
 	r := chi.NewRouter()
-	r.Use(h.authMiddleware.RequireAuth, h.roleMiddleware.RequireAdmin)
+	r.Use(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.CommentModeratePermission))
 
 	r.Get("/", h.findComments)
 	r.Post("/", h.createComment)
@@ -69,6 +70,7 @@ func (h *CommentHandler) findComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	https.SetETag(w, comment.Version)
 	https.ResponseSuccess(w, http.StatusOK, "Comment retrieved successfully", comment)
 }
 
@@ -95,6 +97,12 @@ func (h *CommentHandler) createComment(w http.ResponseWriter, r *http.Request) {
 
 func (h *CommentHandler) updateComment(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
+	expectedVersion, err := https.ParseIfMatch(r)
+	if err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
 	var params usecases.UpdateCommentParams
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
 		https.BadRequest(w, err)
@@ -106,12 +114,13 @@ func (h *CommentHandler) updateComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	updatedComment, err := h.app.UpdateComment(r.Context(), id, &params)
+	updatedComment, err := h.app.UpdateComment(r.Context(), id, expectedVersion, &params)
 	if err != nil {
 		https.RespondWithFailure(w, err)
 		return
 	}
 
+	https.SetETag(w, updatedComment.Version)
 	https.ResponseSuccess(w, http.StatusOK, "Comment updated successfully", updatedComment)
 }
 
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/json"
+	"hinsun-backend/adapters/shared/https"
+	"hinsun-backend/adapters/shared/middlewares"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/applications"
+	"hinsun-backend/internal/domain/usecases"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type DeviceHandler struct {
+	app            applications.DeviceAppService
+	validator      *validator.Validate
+	authMiddleware *middlewares.AuthMiddleware
+}
+
+func NewDeviceHandler(
+	app applications.DeviceAppService,
+	validator *validator.Validate,
+	authMiddleware *middlewares.AuthMiddleware,
+) *DeviceHandler {
+	return &DeviceHandler{
+		app:            app,
+		validator:      validator,
+		authMiddleware: authMiddleware,
+	}
+}
+
+func (h *DeviceHandler) Handler() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(h.authMiddleware.RequireAuth)
+
+	r.Get("/", h.findDeviceTokens)
+	r.Post("/", h.registerDeviceToken)
+	r.Delete("/{id}", h.unregisterDeviceToken)
+
+	return r
+}
+
+func (h *DeviceHandler) findDeviceTokens(w http.ResponseWriter, r *http.Request) {
+	actor, ok := middlewares.GetActorFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	tokens, err := h.app.FindDeviceTokens(r.Context(), actor)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Device tokens retrieved successfully", tokens)
+}
+
+func (h *DeviceHandler) registerDeviceToken(w http.ResponseWriter, r *http.Request) {
+	actor, ok := middlewares.GetActorFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	var params usecases.RegisterDeviceTokenParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(params); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	token, err := h.app.RegisterDeviceToken(r.Context(), actor, &params)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusCreated, "Device token registered successfully", token)
+}
+
+func (h *DeviceHandler) unregisterDeviceToken(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	actor, ok := middlewares.GetActorFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	if err := h.app.UnregisterDeviceToken(r.Context(), actor, id); err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Device token unregistered successfully", nil)
+}
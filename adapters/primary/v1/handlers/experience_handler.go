@@ -7,6 +7,7 @@ import (
 	"hinsun-backend/adapters/shared/middlewares"
 	"hinsun-backend/internal/domain/applications"
 	"hinsun-backend/internal/domain/usecases"
+	"hinsun-backend/internal/domain/values"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -14,23 +15,23 @@ import (
 )
 
 type ExperienceHandler struct {
-	app            applications.GlobalAppService
-	validator      *validator.Validate
-	authMiddleware *middlewares.AuthMiddleware
-	roleMiddleware *middlewares.RoleMiddleware
+	app                  applications.GlobalAppService
+	validator            *validator.Validate
+	authMiddleware       *middlewares.AuthMiddleware
+	permissionMiddleware *middlewares.PermissionMiddleware
 }
 
 func NewExperienceHandler(
 	app applications.GlobalAppService,
 	validator *validator.Validate,
 	authMiddleware *middlewares.AuthMiddleware,
-	roleMiddleware *middlewares.RoleMiddleware,
+	permissionMiddleware *middlewares.PermissionMiddleware,
 ) *ExperienceHandler {
 	return &ExperienceHandler{
-		app:            app,
-		validator:      validator,
-		authMiddleware: authMiddleware,
-		roleMiddleware: roleMiddleware,
+		app:                  app,
+		validator:            validator,
+		authMiddleware:       authMiddleware,
+		permissionMiddleware: permissionMiddleware,
 	}
 }
 
@@ -39,14 +40,16 @@ func (h *ExperienceHandler) Handler() chi.Router {
 
 	r.Get("/", h.findAllExperiences)
 
-	r.With(h.authMiddleware.RequireAuth, h.roleMiddleware.RequireAdmin).Post("/", h.createExperience)
-	r.With(h.authMiddleware.RequireAuth, h.roleMiddleware.RequireAdmin).Delete("/", h.deleteMultipleExperiences)
+	r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.ExperienceWritePermission)).Post("/", h.createExperience)
+	r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.ExperienceWritePermission)).Delete("/", h.deleteMultipleExperiences)
+	r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.ExperienceWritePermission)).Put("/order", h.reorderExperiences)
 
 	r.Route("/{id}", func(r chi.Router) {
 		r.Get("/", h.findExperienceByID)
 
-		r.With(h.authMiddleware.RequireAuth, h.roleMiddleware.RequireAdmin).Delete("/", h.deleteExperience)
-		r.With(h.authMiddleware.RequireAuth, h.roleMiddleware.RequireAdmin).Put("/", h.updateExperience)
+		r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.ExperienceWritePermission)).Delete("/", h.deleteExperience)
+		r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.ExperienceWritePermission)).Put("/", h.updateExperience)
+		r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.ExperienceWritePermission)).Patch("/", h.patchExperience)
 	})
 
 	return r
@@ -59,6 +62,13 @@ func (h *ExperienceHandler) findAllExperiences(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	https.VaryLanguage(w)
+	if preferred := https.PreferredLanguages(r); preferred != nil {
+		for i, experience := range experiences {
+			experiences[i], _ = experience.Localized(preferred)
+		}
+	}
+
 	https.ResponseSuccess(w, http.StatusOK, "Experiences retrieved successfully", experiences)
 }
 
@@ -70,6 +80,17 @@ func (h *ExperienceHandler) findExperienceByID(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	https.VaryLanguage(w)
+	if preferred := https.PreferredLanguages(r); preferred != nil {
+		localized, language := experience.Localized(preferred)
+		https.SetLocalizedETag(w, experience.Version, language)
+		https.SetContentLanguage(w, language)
+		experience = localized
+	} else {
+		https.SetETag(w, experience.Version)
+	}
+
+	https.SetLastModified(w, experience.UpdatedAt)
 	https.ResponseSuccess(w, http.StatusOK, "Experience retrieved successfully", experience)
 }
 
@@ -96,6 +117,12 @@ func (h *ExperienceHandler) createExperience(w http.ResponseWriter, r *http.Requ
 
 func (h *ExperienceHandler) updateExperience(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
+	expectedVersion, err := https.ParseIfMatch(r)
+	if err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
 	var params usecases.UpdateExperienceParams
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
 		https.BadRequest(w, err)
@@ -107,15 +134,79 @@ func (h *ExperienceHandler) updateExperience(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	updatedExperience, err := h.app.UpdateExperience(r.Context(), id, &params)
+	updatedExperience, err := h.app.UpdateExperience(r.Context(), id, expectedVersion, &params)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.SetETag(w, updatedExperience.Version)
+	https.ResponseSuccess(w, http.StatusOK, "Experience updated successfully", updatedExperience)
+}
+
+// patchExperience applies a JSON merge patch on top of the current experience, so omitted fields keep their values
+func (h *ExperienceHandler) patchExperience(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	expectedVersion, err := https.ParseIfMatch(r)
+	if err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	currentExperience, err := h.app.FindExperienceForUpdate(r.Context(), id)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	// The patch was computed against this read, so the write must not overwrite a newer version
+	if expectedVersion == nil {
+		expectedVersion = &currentExperience.Version
+	}
+
+	params := usecases.NewUpdateExperienceParams(currentExperience)
+	if err := https.DecodeMergePatch(r, params); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(params); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	updatedExperience, err := h.app.UpdateExperience(r.Context(), id, expectedVersion, params)
 	if err != nil {
 		https.RespondWithFailure(w, err)
 		return
 	}
 
+	https.SetETag(w, updatedExperience.Version)
 	https.ResponseSuccess(w, http.StatusOK, "Experience updated successfully", updatedExperience)
 }
 
+// reorderExperiences renumbers every experience at once, in the order of the listed IDs
+func (h *ExperienceHandler) reorderExperiences(w http.ResponseWriter, r *http.Request) {
+	var params usecases.ReorderExperiencesParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(params); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	experiences, err := h.app.ReorderExperiences(r.Context(), &params)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Experiences reordered successfully", experiences)
+}
+
 func (h *ExperienceHandler) deleteExperience(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	deletedResult, err := h.app.DeleteExperience(r.Context(), id)
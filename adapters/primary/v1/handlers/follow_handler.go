@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/json"
+	"hinsun-backend/adapters/shared/https"
+	"hinsun-backend/adapters/shared/middlewares"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/applications"
+	"hinsun-backend/internal/domain/usecases"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type FollowHandler struct {
+	app            applications.SubscriptionAppService
+	validator      *validator.Validate
+	authMiddleware *middlewares.AuthMiddleware
+}
+
+func NewFollowHandler(
+	app applications.SubscriptionAppService,
+	validator *validator.Validate,
+	authMiddleware *middlewares.AuthMiddleware,
+) *FollowHandler {
+	return &FollowHandler{
+		app:            app,
+		validator:      validator,
+		authMiddleware: authMiddleware,
+	}
+}
+
+func (h *FollowHandler) Handler() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(h.authMiddleware.RequireAuth)
+
+	r.Get("/", h.findFollows)
+	r.Post("/", h.follow)
+	r.Delete("/{id}", h.unfollow)
+
+	return r
+}
+
+func (h *FollowHandler) findFollows(w http.ResponseWriter, r *http.Request) {
+	actor, ok := middlewares.GetActorFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	follows, err := h.app.FindFollows(r.Context(), actor)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Follows retrieved successfully", follows)
+}
+
+func (h *FollowHandler) follow(w http.ResponseWriter, r *http.Request) {
+	actor, ok := middlewares.GetActorFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	var params usecases.FollowParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(params); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	follow, err := h.app.Follow(r.Context(), actor, &params)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusCreated, "Followed successfully", follow)
+}
+
+func (h *FollowHandler) unfollow(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	actor, ok := middlewares.GetActorFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	if err := h.app.Unfollow(r.Context(), actor, id); err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Unfollowed successfully", nil)
+}
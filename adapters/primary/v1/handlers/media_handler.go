@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"hinsun-backend/adapters/shared/https"
+	"hinsun-backend/adapters/shared/middlewares"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/applications"
+	"hinsun-backend/internal/domain/values"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	// mediaFileField is the multipart field holding the uploaded file
+	mediaFileField = "file"
+
+	// multipartOverhead is the room left for boundaries and part headers around the file
+	multipartOverhead = 1 << 20
+
+	// mediaFileCachePolicy applies to stored files, their keys change with their content
+	mediaFileCachePolicy = "public, max-age=31536000, immutable"
+)
+
+type MediaHandler struct {
+	app                  applications.MediaAppService
+	maxUploadSize        int64
+	authMiddleware       *middlewares.AuthMiddleware
+	permissionMiddleware *middlewares.PermissionMiddleware
+}
+
+func NewMediaHandler(
+	app applications.MediaAppService,
+	maxUploadSize int64,
+	authMiddleware *middlewares.AuthMiddleware,
+	permissionMiddleware *middlewares.PermissionMiddleware,
+) *MediaHandler {
+	return &MediaHandler{
+		app:                  app,
+		maxUploadSize:        maxUploadSize,
+		authMiddleware:       authMiddleware,
+		permissionMiddleware: permissionMiddleware,
+	}
+}
+
+func (h *MediaHandler) Handler() chi.Router {
+	r := chi.NewRouter()
+
+	// Public routes, files of the local storage
+	r.Get("/files/*", h.serveFile)
+
+	// Protected routes
+	r.Group(func(r chi.Router) {
+		r.Use(h.authMiddleware.RequireAuth)
+
+		r.Get("/", h.findMedia)
+		r.With(h.permissionMiddleware.RequirePermission(values.MediaWritePermission)).Post("/", h.uploadMedia)
+		r.Delete("/{id}", h.deleteMedia)
+	})
+
+	return r
+}
+
+func (h *MediaHandler) findMedia(w http.ResponseWriter, r *http.Request) {
+	actor, ok := middlewares.GetActorFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	medias, err := h.app.FindMedia(r.Context(), actor)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Media retrieved successfully", medias)
+}
+
+// uploadMedia stores the "file" field of a multipart form, the file type is checked on its
+// content and uploading the same content again returns the existing media
+func (h *MediaHandler) uploadMedia(w http.ResponseWriter, r *http.Request) {
+	actor, ok := middlewares.GetActorFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	// 1. Read the file part, one byte past the limit is enough to reject it
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxUploadSize+multipartOverhead)
+	content, err := h.readFile(r)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			https.RespondWithFailure(w, failure.NewValidationFailure(fmt.Sprintf("file exceeds the maximum size of %d bytes", h.maxUploadSize)))
+			return
+		}
+
+		https.BadRequest(w, err)
+		return
+	}
+
+	// 2. Store it
+	mediaEntity, created, err := h.app.UploadMedia(r.Context(), actor, content)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	if !created {
+		https.ResponseSuccess(w, http.StatusOK, "Media already uploaded", mediaEntity)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusCreated, "Media uploaded successfully", mediaEntity)
+}
+
+func (h *MediaHandler) deleteMedia(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	actor, ok := middlewares.GetActorFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	if err := h.app.DeleteMedia(r.Context(), actor, id); err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Media deleted successfully", nil)
+}
+
+func (h *MediaHandler) serveFile(w http.ResponseWriter, r *http.Request) {
+	key := chi.URLParam(r, "*")
+
+	file, err := h.app.OpenMediaFile(r.Context(), key)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+	defer file.Close()
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", contentType)
+	header.Set("Cache-Control", mediaFileCachePolicy)
+	header.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	io.Copy(w, file)
+}
+
+// readFile returns the content of the file field, reading at most one byte past the limit
+func (h *MediaHandler) readFile(r *http.Request) ([]byte, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, fmt.Errorf("missing %q file field", mediaFileField)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if part.FormName() != mediaFileField {
+			part.Close()
+			continue
+		}
+
+		defer part.Close()
+		return io.ReadAll(io.LimitReader(part, h.maxUploadSize+1))
+	}
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"encoding/json"
+	"hinsun-backend/adapters/shared/https"
+	"hinsun-backend/adapters/shared/middlewares"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/applications"
+	"hinsun-backend/internal/domain/usecases"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type NotificationHandler struct {
+	app            applications.NotificationAppService
+	validator      *validator.Validate
+	authMiddleware *middlewares.AuthMiddleware
+}
+
+func NewNotificationHandler(
+	app applications.NotificationAppService,
+	validator *validator.Validate,
+	authMiddleware *middlewares.AuthMiddleware,
+) *NotificationHandler {
+	return &NotificationHandler{
+		app:            app,
+		validator:      validator,
+		authMiddleware: authMiddleware,
+	}
+}
+
+func (h *NotificationHandler) Handler() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(h.authMiddleware.RequireAuth)
+
+	r.Get("/", h.findNotifications)
+	r.Post("/{id}/read", h.markNotificationRead)
+	r.Get("/preferences", h.findPreference)
+	r.Put("/preferences", h.updatePreference)
+
+	return r
+}
+
+func (h *NotificationHandler) findNotifications(w http.ResponseWriter, r *http.Request) {
+	actor, ok := middlewares.GetActorFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	notifications, err := h.app.FindNotifications(r.Context(), actor)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Notifications retrieved successfully", notifications)
+}
+
+func (h *NotificationHandler) markNotificationRead(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	actor, ok := middlewares.GetActorFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	notification, err := h.app.MarkNotificationRead(r.Context(), actor, id)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Notification marked as read", notification)
+}
+
+func (h *NotificationHandler) findPreference(w http.ResponseWriter, r *http.Request) {
+	actor, ok := middlewares.GetActorFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	preference, err := h.app.FindNotificationPreference(r.Context(), actor)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Notification preferences retrieved successfully", preference)
+}
+
+func (h *NotificationHandler) updatePreference(w http.ResponseWriter, r *http.Request) {
+	actor, ok := middlewares.GetActorFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	var params usecases.UpdateNotificationPreferenceParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(params); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	preference, err := h.app.UpdateNotificationPreference(r.Context(), actor, &params)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Notification preferences updated successfully", preference)
+}
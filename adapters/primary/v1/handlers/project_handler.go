@@ -7,6 +7,7 @@ import (
 	"hinsun-backend/adapters/shared/middlewares"
 	"hinsun-backend/internal/domain/applications"
 	"hinsun-backend/internal/domain/usecases"
+	"hinsun-backend/internal/domain/values"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -14,23 +15,23 @@ import (
 )
 
 type ProjectHandler struct {
-	app            applications.GlobalAppService
-	validator      *validator.Validate
-	authMiddleware *middlewares.AuthMiddleware
-	roleMiddleware *middlewares.RoleMiddleware
+	app                  applications.GlobalAppService
+	validator            *validator.Validate
+	authMiddleware       *middlewares.AuthMiddleware
+	permissionMiddleware *middlewares.PermissionMiddleware
 }
 
 func NewProjectHandler(
 	app applications.GlobalAppService,
 	validator *validator.Validate,
 	authMiddleware *middlewares.AuthMiddleware,
-	roleMiddleware *middlewares.RoleMiddleware,
+	permissionMiddleware *middlewares.PermissionMiddleware,
 ) *ProjectHandler {
 	return &ProjectHandler{
-		app:            app,
-		validator:      validator,
-		authMiddleware: authMiddleware,
-		roleMiddleware: roleMiddleware,
+		app:                  app,
+		validator:            validator,
+		authMiddleware:       authMiddleware,
+		permissionMiddleware: permissionMiddleware,
 	}
 }
 
@@ -38,27 +39,48 @@ func (h *ProjectHandler) Handler() chi.Router {
 	r := chi.NewRouter()
 
 	r.Get("/", h.findAllProjects)
-	r.With(h.authMiddleware.RequireAuth, h.roleMiddleware.RequireAdmin).Post("/", h.createProject)
-	r.With(h.authMiddleware.RequireAuth, h.roleMiddleware.RequireAdmin).Delete("/", h.deleteMultipleProjects)
+	r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.ProjectWritePermission)).Post("/", h.createProject)
+	r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.ProjectWritePermission)).Delete("/", h.deleteMultipleProjects)
+	r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.ProjectWritePermission)).Put("/featured", h.featureProjects)
 
 	r.Route("/{id}", func(r chi.Router) {
 		r.Get("/", h.findProjectByID)
 
-		r.With(h.authMiddleware.RequireAuth, h.roleMiddleware.RequireAdmin).Put("/", h.updateProject)
-		r.With(h.authMiddleware.RequireAuth, h.roleMiddleware.RequireAdmin).Delete("/", h.deleteProject)
+		r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.ProjectWritePermission)).Put("/", h.updateProject)
+		r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.ProjectWritePermission)).Patch("/", h.patchProject)
+		r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.ProjectWritePermission)).Delete("/", h.deleteProject)
+		r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.ProjectWritePermission)).Post("/repository/refresh", h.refreshProjectRepository)
 	})
 
 	return r
 }
 
 func (h *ProjectHandler) findAllProjects(w http.ResponseWriter, r *http.Request) {
-	projects, err := h.app.FindProjects(r.Context())
+	var query usecases.FindProjectsQuery
+	if err := https.BindQuery(r, &query); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(query); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	projects, pageMeta, err := h.app.FindProjects(r.Context(), &query)
 	if err != nil {
 		https.RespondWithFailure(w, err)
 		return
 	}
 
-	https.ResponseSuccess(w, http.StatusOK, "Projects retrieved successfully", projects)
+	https.VaryLanguage(w)
+	if preferred := https.PreferredLanguages(r); preferred != nil {
+		for i, project := range projects {
+			projects[i], _ = project.Localized(preferred)
+		}
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Projects retrieved successfully", projects, pageMeta)
 }
 
 func (h *ProjectHandler) findProjectByID(w http.ResponseWriter, r *http.Request) {
@@ -69,6 +91,17 @@ func (h *ProjectHandler) findProjectByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	https.VaryLanguage(w)
+	if preferred := https.PreferredLanguages(r); preferred != nil {
+		localized, language := project.Localized(preferred)
+		https.SetLocalizedETag(w, project.Version, language)
+		https.SetContentLanguage(w, language)
+		project = localized
+	} else {
+		https.SetETag(w, project.Version)
+	}
+
+	https.SetLastModified(w, project.UpdatedAt)
 	https.ResponseSuccess(w, http.StatusOK, "Project retrieved successfully", project)
 }
 
@@ -95,6 +128,12 @@ func (h *ProjectHandler) createProject(w http.ResponseWriter, r *http.Request) {
 
 func (h *ProjectHandler) updateProject(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
+	expectedVersion, err := https.ParseIfMatch(r)
+	if err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
 	var params usecases.UpdateProjectParams
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
 		https.BadRequest(w, err)
@@ -106,15 +145,104 @@ func (h *ProjectHandler) updateProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	updatedProject, err := h.app.UpdateProject(r.Context(), id, &params)
+	updatedProject, err := h.app.UpdateProject(r.Context(), id, expectedVersion, &params)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.SetETag(w, updatedProject.Version)
+	https.ResponseSuccess(w, http.StatusOK, "Project updated successfully", updatedProject)
+}
+
+// patchProject applies a JSON merge patch on top of the current project, so omitted fields keep their values
+func (h *ProjectHandler) patchProject(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	expectedVersion, err := https.ParseIfMatch(r)
+	if err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	currentProject, err := h.app.FindProjectForUpdate(r.Context(), id)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	// The patch was computed against this read, so the write must not overwrite a newer version
+	if expectedVersion == nil {
+		expectedVersion = &currentProject.Version
+	}
+
+	params := usecases.NewUpdateProjectParams(currentProject)
+	if err := https.DecodeMergePatch(r, params); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(params); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	updatedProject, err := h.app.UpdateProject(r.Context(), id, expectedVersion, params)
 	if err != nil {
 		https.RespondWithFailure(w, err)
 		return
 	}
 
+	https.SetETag(w, updatedProject.Version)
 	https.ResponseSuccess(w, http.StatusOK, "Project updated successfully", updatedProject)
 }
 
+// refreshProjectRepository syncs the repository metadata of the project now, and imports its
+// README into the markdown of the language given in readme
+func (h *ProjectHandler) refreshProjectRepository(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var query usecases.RefreshProjectRepositoryQuery
+	if err := https.BindQuery(r, &query); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(query); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	project, err := h.app.RefreshProjectRepository(r.Context(), id, &query)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.SetETag(w, project.Version)
+	https.ResponseSuccess(w, http.StatusOK, "Project repository refreshed successfully", project)
+}
+
+// featureProjects replaces the featured projects at once, in the order of the listed IDs
+func (h *ProjectHandler) featureProjects(w http.ResponseWriter, r *http.Request) {
+	var params usecases.FeatureProjectsParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(params); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	projects, err := h.app.FeatureProjects(r.Context(), &params)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Featured projects updated successfully", projects)
+}
+
 func (h *ProjectHandler) deleteProject(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	deletedResult, err := h.app.DeleteProject(r.Context(), id)
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"encoding/json"
+	"hinsun-backend/adapters/shared/https"
+	"hinsun-backend/adapters/shared/middlewares"
+	"hinsun-backend/internal/domain/applications"
+	"hinsun-backend/internal/domain/usecases"
+	"hinsun-backend/internal/domain/values"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type RoleHandler struct {
+	app                  applications.RoleAppService
+	validator            *validator.Validate
+	authMiddleware       *middlewares.AuthMiddleware
+	permissionMiddleware *middlewares.PermissionMiddleware
+}
+
+func NewRoleHandler(
+	app applications.RoleAppService,
+	validator *validator.Validate,
+	authMiddleware *middlewares.AuthMiddleware,
+	permissionMiddleware *middlewares.PermissionMiddleware,
+) *RoleHandler {
+	return &RoleHandler{
+		app:                  app,
+		validator:            validator,
+		authMiddleware:       authMiddleware,
+		permissionMiddleware: permissionMiddleware,
+	}
+}
+
+func (h *RoleHandler) Handler() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.RoleManagePermission))
+
+	r.Get("/", h.findRoles)
+	r.Post("/", h.createRole)
+	r.Get("/permissions", h.findPermissions)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", h.findRole)
+		r.Put("/", h.updateRole)
+		r.Delete("/", h.deleteRole)
+	})
+
+	return r
+}
+
+func (h *RoleHandler) findRoles(w http.ResponseWriter, r *http.Request) {
+	roles, err := h.app.FindRoles(r.Context())
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Roles retrieved successfully", roles)
+}
+
+func (h *RoleHandler) findPermissions(w http.ResponseWriter, r *http.Request) {
+	permissions, err := h.app.FindPermissions(r.Context())
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Permissions retrieved successfully", permissions)
+}
+
+func (h *RoleHandler) findRole(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	role, err := h.app.FindRole(r.Context(), id)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Role retrieved successfully", role)
+}
+
+func (h *RoleHandler) createRole(w http.ResponseWriter, r *http.Request) {
+	var params usecases.CreateRoleParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(params); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	role, err := h.app.CreateRole(r.Context(), &params)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusCreated, "Role created successfully", role)
+}
+
+func (h *RoleHandler) updateRole(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var params usecases.UpdateRoleParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(params); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	updatedRole, err := h.app.UpdateRole(r.Context(), id, &params)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Role updated successfully", updatedRole)
+}
+
+func (h *RoleHandler) deleteRole(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	deletedResult, err := h.app.DeleteRole(r.Context(), id)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Role deleted successfully", deletedResult)
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"hinsun-backend/adapters/shared/https"
+	"hinsun-backend/adapters/shared/middlewares"
+	"hinsun-backend/adapters/shared/streaming"
+	"hinsun-backend/internal/core/failure"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	// streamRetry is the reconnection delay suggested to EventSource clients
+	streamRetry = 3 * time.Second
+
+	// streamWriteTimeout bounds each write, the server WriteTimeout would end the stream
+	streamWriteTimeout = 10 * time.Second
+)
+
+type StreamHandler struct {
+	broker            *streaming.Broker
+	heartbeatInterval time.Duration
+	authMiddleware    *middlewares.AuthMiddleware
+}
+
+func NewStreamHandler(
+	broker *streaming.Broker,
+	heartbeatInterval time.Duration,
+	authMiddleware *middlewares.AuthMiddleware,
+) *StreamHandler {
+	return &StreamHandler{
+		broker:            broker,
+		heartbeatInterval: heartbeatInterval,
+		authMiddleware:    authMiddleware,
+	}
+}
+
+func (h *StreamHandler) Handler() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(h.authMiddleware.RequireAuth)
+
+	r.Get("/", h.stream)
+
+	return r
+}
+
+// stream sends the caller's notifications and replies, plus the new comments of blogId when
+// given, as Server-Sent Events
+func (h *StreamHandler) stream(w http.ResponseWriter, r *http.Request) {
+	actor, ok := middlewares.GetActorFromContext(r.Context())
+	if !ok {
+		https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+		return
+	}
+
+	blogID := r.URL.Query().Get("blogId")
+	if blogID != "" {
+		if _, err := uuid.Parse(blogID); err != nil {
+			https.RespondWithFailure(w, failure.NewValidationFailure("invalid blogId").WithCause(err))
+			return
+		}
+	}
+
+	// EventSource sends the header on reconnects, the query parameter serves the first connection
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("lastEventId")
+	}
+
+	// 1. Open the subscription
+	client, backlog, err := h.broker.Subscribe(actor.AccountID, blogID, lastEventID)
+	switch {
+	case errors.Is(err, streaming.ErrTooManyConnections):
+		https.RespondWithFailure(w, failure.NewTooManyRequestsFailure("too many open streams, close one and try again"))
+		return
+	case errors.Is(err, streaming.ErrBrokerClosed):
+		http.Error(w, "server is shutting down", http.StatusServiceUnavailable)
+		return
+	case err != nil:
+		https.RespondWithFailure(w, failure.NewInternalFailure("failed to open stream", err))
+		return
+	}
+	defer h.broker.Unsubscribe(client)
+
+	// 2. Start the stream and replay what the client missed
+	controller := http.NewResponseController(w)
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("X-Accel-Buffering", "no") // keeps nginx from buffering the stream
+	w.WriteHeader(http.StatusOK)
+
+	if err := h.write(w, controller, fmt.Sprintf("retry: %d\n\n", streamRetry.Milliseconds())); err != nil {
+		return
+	}
+
+	for _, event := range backlog {
+		if err := h.writeEvent(w, controller, event); err != nil {
+			return
+		}
+	}
+
+	// 3. Forward events until the client leaves, lags behind or the server shuts down
+	heartbeat := time.NewTicker(h.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, open := <-client.Events():
+			if !open {
+				return
+			}
+
+			if err := h.writeEvent(w, controller, event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := h.write(w, controller, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (h *StreamHandler) writeEvent(w http.ResponseWriter, controller *http.ResponseController, event *streaming.Event) error {
+	return h.write(w, controller, fmt.Sprintf("id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Name, event.Data))
+}
+
+func (h *StreamHandler) write(w http.ResponseWriter, controller *http.ResponseController, message string) error {
+	if err := controller.SetWriteDeadline(time.Now().Add(streamWriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	if _, err := w.Write([]byte(message)); err != nil {
+		return err
+	}
+
+	return controller.Flush()
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/json"
+	"hinsun-backend/adapters/shared/https"
+	"hinsun-backend/internal/domain/applications"
+	"hinsun-backend/internal/domain/usecases"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+// SubscriptionHandler serves the email subscriptions of anonymous readers, the token of the
+// links they receive authenticates confirm and unsubscribe
+type SubscriptionHandler struct {
+	app       applications.SubscriptionAppService
+	validator *validator.Validate
+}
+
+func NewSubscriptionHandler(app applications.SubscriptionAppService, validator *validator.Validate) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		app:       app,
+		validator: validator,
+	}
+}
+
+func (h *SubscriptionHandler) Handler() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/", h.subscribe)
+	r.Post("/confirm", h.confirm)
+	r.Post("/unsubscribe", h.unsubscribe)
+
+	return r
+}
+
+func (h *SubscriptionHandler) subscribe(w http.ResponseWriter, r *http.Request) {
+	var params usecases.SubscribeByEmailParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(params); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	if err := h.app.SubscribeByEmail(r.Context(), &params); err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusAccepted, "Check your inbox to confirm the subscription", nil)
+}
+
+func (h *SubscriptionHandler) confirm(w http.ResponseWriter, r *http.Request) {
+	var params usecases.SubscriptionTokenParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(params); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	subscription, err := h.app.ConfirmEmailSubscription(r.Context(), &params)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Subscription confirmed successfully", subscription)
+}
+
+func (h *SubscriptionHandler) unsubscribe(w http.ResponseWriter, r *http.Request) {
+	var params usecases.SubscriptionTokenParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(params); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	if err := h.app.UnsubscribeEmail(r.Context(), &params); err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Unsubscribed successfully", nil)
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"hinsun-backend/adapters/shared/https"
+	"hinsun-backend/adapters/shared/middlewares"
+	"hinsun-backend/internal/domain/applications"
+	"hinsun-backend/internal/domain/usecases"
+	"hinsun-backend/internal/domain/values"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type TagHandler struct {
+	app                  applications.TagAppService
+	validator            *validator.Validate
+	authMiddleware       *middlewares.AuthMiddleware
+	permissionMiddleware *middlewares.PermissionMiddleware
+}
+
+func NewTagHandler(
+	app applications.TagAppService,
+	validator *validator.Validate,
+	authMiddleware *middlewares.AuthMiddleware,
+	permissionMiddleware *middlewares.PermissionMiddleware,
+) *TagHandler {
+	return &TagHandler{
+		app:                  app,
+		validator:            validator,
+		authMiddleware:       authMiddleware,
+		permissionMiddleware: permissionMiddleware,
+	}
+}
+
+func (h *TagHandler) Handler() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.findAllTags)
+	r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.ProjectWritePermission)).Post("/", h.createTag)
+	r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.ProjectWritePermission)).Delete("/", h.deleteMultipleTags)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", h.findTagByID)
+		r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.ProjectWritePermission)).Put("/", h.updateTag)
+		r.With(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.ProjectWritePermission)).Delete("/", h.deleteTag)
+	})
+
+	return r
+}
+
+// findAllTags lists the vocabulary with the number of projects using each tag, sort=usage gives a tag cloud
+func (h *TagHandler) findAllTags(w http.ResponseWriter, r *http.Request) {
+	var query usecases.FindTagsQuery
+	if err := https.BindQuery(r, &query); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(query); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	tags, err := h.app.FindTags(r.Context(), &query)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Tags retrieved successfully", tags)
+}
+
+func (h *TagHandler) findTagByID(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	tag, err := h.app.FindTag(r.Context(), id)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.SetETag(w, tag.Version)
+	https.SetLastModified(w, tag.UpdatedAt)
+	https.ResponseSuccess(w, http.StatusOK, "Tag retrieved successfully", tag)
+}
+
+func (h *TagHandler) createTag(w http.ResponseWriter, r *http.Request) {
+	var params usecases.CreateTagParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(params); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	tag, err := h.app.CreateTag(r.Context(), &params)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusCreated, "Tag created successfully", tag)
+}
+
+func (h *TagHandler) updateTag(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	expectedVersion, err := https.ParseIfMatch(r)
+	if err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	var params usecases.UpdateTagParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(params); err != nil {
+		https.ValidationFailed(w, err)
+		return
+	}
+
+	updatedTag, err := h.app.UpdateTag(r.Context(), id, expectedVersion, &params)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.SetETag(w, updatedTag.Version)
+	https.ResponseSuccess(w, http.StatusOK, "Tag updated successfully", updatedTag)
+}
+
+func (h *TagHandler) deleteTag(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	deletedResult, err := h.app.DeleteTag(r.Context(), id)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Tag deleted successfully", deletedResult)
+}
+
+func (h *TagHandler) deleteMultipleTags(w http.ResponseWriter, r *http.Request) {
+	var query usecases.DeleteTagsQuery
+	if err := https.BindQuery(r, &query); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	// Validate that at least one ID is provided
+	if len(query.IDs) == 0 {
+		https.BadRequest(w, errors.New("at least one id must be provided in ids parameter"))
+		return
+	}
+
+	deletedResult, err := h.app.DeleteMultipleTags(r.Context(), &query)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Tags deleted successfully", deletedResult)
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"hinsun-backend/adapters/shared/https"
+	"hinsun-backend/adapters/shared/middlewares"
+	"hinsun-backend/internal/domain/applications"
+	"hinsun-backend/internal/domain/usecases"
+	"hinsun-backend/internal/domain/values"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type TrashHandler struct {
+	app                  applications.TrashAppService
+	authMiddleware       *middlewares.AuthMiddleware
+	permissionMiddleware *middlewares.PermissionMiddleware
+}
+
+func NewTrashHandler(
+	app applications.TrashAppService,
+	authMiddleware *middlewares.AuthMiddleware,
+	permissionMiddleware *middlewares.PermissionMiddleware,
+) *TrashHandler {
+	return &TrashHandler{
+		app:                  app,
+		authMiddleware:       authMiddleware,
+		permissionMiddleware: permissionMiddleware,
+	}
+}
+
+func (h *TrashHandler) Handler() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(h.authMiddleware.RequireAuth, h.permissionMiddleware.RequirePermission(values.TrashManagePermission))
+
+	r.Get("/", h.findTrash)
+	r.Post("/{resource}/{id}/restore", h.restoreItem)
+
+	return r
+}
+
+func (h *TrashHandler) findTrash(w http.ResponseWriter, r *http.Request) {
+	var query usecases.FindTrashQuery
+	if err := https.BindQuery(r, &query); err != nil {
+		https.BadRequest(w, err)
+		return
+	}
+
+	items, err := h.app.FindTrash(r.Context(), &query)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Trash retrieved successfully", items)
+}
+
+func (h *TrashHandler) restoreItem(w http.ResponseWriter, r *http.Request) {
+	resource := chi.URLParam(r, "resource")
+	id := chi.URLParam(r, "id")
+	restored, err := h.app.RestoreItem(r.Context(), resource, id)
+	if err != nil {
+		https.RespondWithFailure(w, err)
+		return
+	}
+
+	https.ResponseSuccess(w, http.StatusOK, "Item restored successfully", restored)
+}
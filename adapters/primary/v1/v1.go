@@ -2,17 +2,28 @@ package v1
 
 import (
 	"hinsun-backend/adapters/primary/v1/handlers"
+	"hinsun-backend/adapters/shared/middlewares"
 
 	"github.com/go-chi/chi/v5"
 )
 
 type V1Routes struct {
-	authHandler       *handlers.AuthHandler
-	experienceHandler *handlers.ExperienceHandler
-	blogHandler       *handlers.BlogHandler
-	projectHandler    *handlers.ProjectHandler
-	accountHandler    *handlers.AccountHandler
-	categoryHandler   *handlers.CategoryHandler
+	authHandler         *handlers.AuthHandler
+	experienceHandler   *handlers.ExperienceHandler
+	blogHandler         *handlers.BlogHandler
+	projectHandler      *handlers.ProjectHandler
+	accountHandler      *handlers.AccountHandler
+	categoryHandler     *handlers.CategoryHandler
+	tagHandler          *handlers.TagHandler
+	roleHandler         *handlers.RoleHandler
+	trashHandler        *handlers.TrashHandler
+	deviceHandler       *handlers.DeviceHandler
+	notificationHandler *handlers.NotificationHandler
+	streamHandler       *handlers.StreamHandler
+	followHandler       *handlers.FollowHandler
+	subscriptionHandler *handlers.SubscriptionHandler
+	mediaHandler        *handlers.MediaHandler
+	cachePolicies       middlewares.CachePolicies
 }
 
 func NewV1Routes(
@@ -22,26 +33,58 @@ func NewV1Routes(
 	projectHandler *handlers.ProjectHandler,
 	accountHandler *handlers.AccountHandler,
 	categoryHandler *handlers.CategoryHandler,
+	tagHandler *handlers.TagHandler,
+	roleHandler *handlers.RoleHandler,
+	trashHandler *handlers.TrashHandler,
+	deviceHandler *handlers.DeviceHandler,
+	notificationHandler *handlers.NotificationHandler,
+	streamHandler *handlers.StreamHandler,
+	followHandler *handlers.FollowHandler,
+	subscriptionHandler *handlers.SubscriptionHandler,
+	mediaHandler *handlers.MediaHandler,
+	cachePolicies middlewares.CachePolicies,
 ) *V1Routes {
 	return &V1Routes{
-		authHandler:       authHandler,
-		experienceHandler: experienceHandler,
-		blogHandler:       blogHandler,
-		projectHandler:    projectHandler,
-		accountHandler:    accountHandler,
-		categoryHandler:   categoryHandler,
+		authHandler:         authHandler,
+		experienceHandler:   experienceHandler,
+		blogHandler:         blogHandler,
+		projectHandler:      projectHandler,
+		accountHandler:      accountHandler,
+		categoryHandler:     categoryHandler,
+		tagHandler:          tagHandler,
+		roleHandler:         roleHandler,
+		trashHandler:        trashHandler,
+		deviceHandler:       deviceHandler,
+		notificationHandler: notificationHandler,
+		streamHandler:       streamHandler,
+		followHandler:       followHandler,
+		subscriptionHandler: subscriptionHandler,
+		mediaHandler:        mediaHandler,
+		cachePolicies:       cachePolicies,
 	}
 }
 
 func (vr *V1Routes) RegisterRoutes() chi.Router {
 	r := chi.NewRouter()
 
+	// Nothing is cacheable by default, only the public content groups below opt in
+	r.Use(middlewares.NoStore)
+
 	r.Mount("/auth", vr.authHandler.Handler())
-	r.Mount("/experiences", vr.experienceHandler.Handler())
-	r.Mount("/blogs", vr.blogHandler.Handler())
-	r.Mount("/projects", vr.projectHandler.Handler())
+	r.With(middlewares.PublicCache(vr.cachePolicies.Experiences)).Mount("/experiences", vr.experienceHandler.Handler())
+	r.With(middlewares.PublicCache(vr.cachePolicies.Blogs)).Mount("/blogs", vr.blogHandler.Handler())
+	r.With(middlewares.PublicCache(vr.cachePolicies.Projects)).Mount("/projects", vr.projectHandler.Handler())
 	r.Mount("/accounts", vr.accountHandler.Handler())
-	r.Mount("/categories", vr.categoryHandler.Handler())
+	r.With(middlewares.PublicCache(vr.cachePolicies.Categories)).Mount("/categories", vr.categoryHandler.Handler())
+	r.With(middlewares.PublicCache(vr.cachePolicies.Tags)).Mount("/tags", vr.tagHandler.Handler())
+	r.Mount("/roles", vr.roleHandler.Handler())
+	r.Mount("/trash", vr.trashHandler.Handler())
+	r.Mount("/devices", vr.deviceHandler.Handler())
+	r.Mount("/notifications", vr.notificationHandler.Handler())
+	r.Mount("/stream", vr.streamHandler.Handler())
+	r.Mount("/follows", vr.followHandler.Handler())
+	r.Mount("/subscriptions", vr.subscriptionHandler.Handler())
+	r.Mount("/media", vr.mediaHandler.Handler())
 
 	return r
 }
@@ -0,0 +1,26 @@
+package wellknown
+
+import (
+	"hinsun-backend/adapters/primary/v1/handlers"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// WellKnownRoutes serves the unversioned /.well-known documents
+type WellKnownRoutes struct {
+	authHandler *handlers.AuthHandler
+}
+
+func NewWellKnownRoutes(authHandler *handlers.AuthHandler) *WellKnownRoutes {
+	return &WellKnownRoutes{
+		authHandler: authHandler,
+	}
+}
+
+func (wr *WellKnownRoutes) RegisterRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/jwks.json", wr.authHandler.JWKS)
+
+	return r
+}
@@ -0,0 +1,234 @@
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/project"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultGithubAPIURL is the GitHub REST API, other base URLs point at GitHub Enterprise or a fake
+	DefaultGithubAPIURL = "https://api.github.com"
+
+	githubAPIVersion = "2022-11-28"
+	maxReadmeSize    = 1 << 20
+)
+
+type githubRepository struct {
+	FullName        string    `json:"full_name"`
+	Description     string    `json:"description"`
+	Language        string    `json:"language"`
+	Topics          []string  `json:"topics"`
+	StargazersCount int       `json:"stargazers_count"`
+	ForksCount      int       `json:"forks_count"`
+	OpenIssuesCount int       `json:"open_issues_count"`
+	Archived        bool      `json:"archived"`
+	PushedAt        time.Time `json:"pushed_at"`
+}
+
+type githubMetadataProvider struct {
+	baseURL string
+	token   string
+	client  *http.Client
+
+	// resetAt is when requests may be sent again after GitHub reported the rate limit exhausted,
+	// requests before it fail without reaching GitHub
+	mu      sync.Mutex
+	resetAt time.Time
+}
+
+// NewGithubMetadataProvider reads repositories from the GitHub REST API at baseURL. The token is
+// optional, authenticated requests get a rate limit of 5000 instead of 60 an hour.
+func NewGithubMetadataProvider(baseURL, token string, timeout time.Duration) project.RepositoryMetadataProvider {
+	if baseURL == "" {
+		baseURL = DefaultGithubAPIURL
+	}
+
+	return &githubMetadataProvider{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *githubMetadataProvider) FetchMetadata(ctx context.Context, repositoryURL string) (*project.RepositoryMetadata, error) {
+	owner, name, err := parseGithubURL(repositoryURL)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := p.get(ctx, "/repos/"+owner+"/"+name, "application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var repository githubRepository
+	if err := json.NewDecoder(response.Body).Decode(&repository); err != nil {
+		return nil, failure.NewInternalFailure("failed to decode GitHub repository", err)
+	}
+
+	var pushedAt int64
+	if !repository.PushedAt.IsZero() {
+		pushedAt = repository.PushedAt.Unix()
+	}
+
+	topics := repository.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+
+	return &project.RepositoryMetadata{
+		URL:             repositoryURL,
+		FullName:        repository.FullName,
+		Description:     repository.Description,
+		PrimaryLanguage: repository.Language,
+		Topics:          topics,
+		Stars:           repository.StargazersCount,
+		Forks:           repository.ForksCount,
+		OpenIssues:      repository.OpenIssuesCount,
+		Archived:        repository.Archived,
+		PushedAt:        pushedAt,
+		SyncedAt:        time.Now().Unix(),
+	}, nil
+}
+
+func (p *githubMetadataProvider) FetchReadme(ctx context.Context, repositoryURL string) (string, error) {
+	owner, name, err := parseGithubURL(repositoryURL)
+	if err != nil {
+		return "", err
+	}
+
+	// The raw media type returns the markdown itself instead of base64 in JSON
+	response, err := p.get(ctx, "/repos/"+owner+"/"+name+"/readme", "application/vnd.github.raw+json")
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	readme, err := io.ReadAll(io.LimitReader(response.Body, maxReadmeSize+1))
+	if err != nil {
+		return "", failure.NewInternalFailure("failed to read GitHub README", err)
+	}
+
+	if len(readme) > maxReadmeSize {
+		return "", failure.NewValidationFailure(fmt.Sprintf("repository README exceeds %d bytes", maxReadmeSize))
+	}
+
+	return string(readme), nil
+}
+
+// get sends a GET to the API and returns the successful response, whose body the caller closes
+func (p *githubMetadataProvider) get(ctx context.Context, path, accept string) (*http.Response, error) {
+	if resetAt := p.rateLimitedUntil(); !resetAt.IsZero() {
+		return nil, rateLimitFailure(resetAt)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+path, nil)
+	if err != nil {
+		return nil, failure.NewInternalFailure("failed to build GitHub request", err)
+	}
+
+	request.Header.Set("Accept", accept)
+	request.Header.Set("X-GitHub-Api-Version", githubAPIVersion)
+	request.Header.Set("User-Agent", "hinsun-backend")
+	if p.token != "" {
+		request.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return nil, failure.NewInternalFailure("failed to reach GitHub", err)
+	}
+
+	resetAt := p.recordRateLimit(response)
+	if response.StatusCode == http.StatusOK {
+		return response, nil
+	}
+
+	response.Body.Close()
+	switch {
+	case response.StatusCode == http.StatusNotFound:
+		return nil, failure.NewNotFoundFailure("Repository not found on GitHub")
+	case !resetAt.IsZero():
+		return nil, rateLimitFailure(resetAt)
+	default:
+		return nil, failure.NewInternalFailure("GitHub request failed", fmt.Errorf("GET %s: %s", path, response.Status))
+	}
+}
+
+// recordRateLimit reads the rate limit headers, and returns the time requests may resume when
+// the response says they have to wait: the primary limit is exhausted (X-RateLimit-Remaining
+// is 0 until X-RateLimit-Reset), or a secondary limit asks to retry after some seconds.
+func (p *githubMetadataProvider) recordRateLimit(response *http.Response) time.Time {
+	var resetAt time.Time
+	if response.StatusCode == http.StatusForbidden || response.StatusCode == http.StatusTooManyRequests {
+		if seconds, err := strconv.Atoi(response.Header.Get("Retry-After")); err == nil {
+			resetAt = time.Now().Add(time.Duration(seconds) * time.Second)
+		}
+	}
+
+	if resetAt.IsZero() && response.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(response.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			resetAt = time.Unix(reset, 0)
+		}
+	}
+
+	if resetAt.IsZero() {
+		return resetAt
+	}
+
+	p.mu.Lock()
+	p.resetAt = resetAt
+	p.mu.Unlock()
+
+	// The last request of the window still succeeded, only the next ones wait
+	if response.StatusCode == http.StatusOK {
+		return time.Time{}
+	}
+
+	return resetAt
+}
+
+// rateLimitedUntil returns the pending reset time, or zero when requests may be sent
+func (p *githubMetadataProvider) rateLimitedUntil() time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if time.Now().Before(p.resetAt) {
+		return p.resetAt
+	}
+
+	return time.Time{}
+}
+
+func rateLimitFailure(resetAt time.Time) error {
+	return failure.NewTooManyRequestsFailure(
+		fmt.Sprintf("GitHub rate limit exceeded, retry after %s", resetAt.UTC().Format(time.RFC3339)),
+	)
+}
+
+// parseGithubURL extracts the owner and name of a repository from its web URL, e.g.
+// https://github.com/owner/name, with or without a trailing .git or path below it
+func parseGithubURL(repositoryURL string) (string, string, error) {
+	parsed, err := url.Parse(strings.TrimSpace(repositoryURL))
+	if err != nil || (parsed.Host != "github.com" && parsed.Host != "www.github.com") {
+		return "", "", failure.NewValidationFailure(fmt.Sprintf("'%s' is not a GitHub repository URL", repositoryURL))
+	}
+
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(segments) < 2 || segments[0] == "" || segments[1] == "" {
+		return "", "", failure.NewValidationFailure(fmt.Sprintf("'%s' is not a GitHub repository URL", repositoryURL))
+	}
+
+	return url.PathEscape(segments[0]), url.PathEscape(strings.TrimSuffix(segments[1], ".git")), nil
+}
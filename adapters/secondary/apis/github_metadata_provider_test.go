@@ -0,0 +1,231 @@
+package apis
+
+import (
+	"context"
+	"fmt"
+	"hinsun-backend/internal/core/failure"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const repositoryURL = "https://github.com/owner/name"
+
+// newGithubServer serves handler as the GitHub API and returns a provider pointed at it, along
+// with the number of requests that reached the server
+func newGithubServer(t *testing.T, handler http.HandlerFunc) (*githubMetadataProvider, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	provider := NewGithubMetadataProvider(server.URL+"/", "secret-token", 5*time.Second)
+	return provider.(*githubMetadataProvider), &requests
+}
+
+func TestFetchMetadata(t *testing.T) {
+	provider, _ := newGithubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/name" {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer secret-token" || r.Header.Get("Accept") != "application/vnd.github+json" {
+			t.Errorf("unexpected headers %v", r.Header)
+		}
+
+		fmt.Fprint(w, `{
+			"full_name": "owner/name",
+			"description": "A project",
+			"language": "Go",
+			"topics": ["api", "go"],
+			"stargazers_count": 42,
+			"forks_count": 7,
+			"open_issues_count": 3,
+			"archived": true,
+			"pushed_at": "2026-01-02T03:04:05Z"
+		}`)
+	})
+
+	metadata, err := provider.FetchMetadata(context.Background(), repositoryURL+".git")
+	if err != nil {
+		t.Fatalf("fetch metadata: %v", err)
+	}
+
+	if metadata.FullName != "owner/name" || metadata.PrimaryLanguage != "Go" || metadata.Stars != 42 ||
+		metadata.Forks != 7 || metadata.OpenIssues != 3 || !metadata.Archived || len(metadata.Topics) != 2 {
+		t.Errorf("unexpected metadata %+v", metadata)
+	}
+
+	if want := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC).Unix(); metadata.PushedAt != want {
+		t.Errorf("pushed at = %d, want %d", metadata.PushedAt, want)
+	}
+}
+
+func TestFetchMetadataNotFound(t *testing.T) {
+	provider, _ := newGithubServer(t, http.NotFound)
+
+	if _, err := provider.FetchMetadata(context.Background(), repositoryURL); !failure.Is(err, failure.NotFoundFailure) {
+		t.Errorf("error = %v, want a not found failure", err)
+	}
+
+	if _, err := provider.FetchReadme(context.Background(), repositoryURL); !failure.Is(err, failure.NotFoundFailure) {
+		t.Errorf("readme error = %v, want a not found failure", err)
+	}
+}
+
+func TestFetchMetadataInvalidURL(t *testing.T) {
+	provider, requests := newGithubServer(t, http.NotFound)
+
+	for _, invalid := range []string{"https://gitlab.com/owner/name", "https://github.com/owner", "not a url"} {
+		if _, err := provider.FetchMetadata(context.Background(), invalid); !failure.Is(err, failure.ValidationFailure) {
+			t.Errorf("%s: error = %v, want a validation failure", invalid, err)
+		}
+	}
+
+	if requests.Load() != 0 {
+		t.Errorf("%d requests sent for invalid URLs", requests.Load())
+	}
+}
+
+func TestFetchReadme(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		wantErr failure.FailureCode
+	}{
+		{name: "small", size: 12},
+		{name: "at the cap", size: maxReadmeSize},
+		{name: "over the cap", size: maxReadmeSize + 1, wantErr: failure.ValidationFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, _ := newGithubServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/repos/owner/name/readme" || r.Header.Get("Accept") != "application/vnd.github.raw+json" {
+					t.Errorf("unexpected request %s %v", r.URL.Path, r.Header)
+				}
+
+				fmt.Fprint(w, strings.Repeat("#", tt.size))
+			})
+
+			readme, err := provider.FetchReadme(context.Background(), repositoryURL)
+			if tt.wantErr != "" {
+				if !failure.Is(err, tt.wantErr) {
+					t.Errorf("error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil || len(readme) != tt.size {
+				t.Errorf("readme of %d bytes, %v; want %d bytes", len(readme), err, tt.size)
+			}
+		})
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name    string
+		status  int
+		headers map[string]string
+		// whether the response itself fails, the next request is short-circuited in every case
+		failsNow bool
+		resetAt  time.Time
+	}{
+		{
+			name:    "last request of the window",
+			status:  http.StatusOK,
+			headers: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": strconv.FormatInt(reset.Unix(), 10)},
+			resetAt: reset,
+		},
+		{
+			name:     "primary limit exhausted",
+			status:   http.StatusForbidden,
+			headers:  map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": strconv.FormatInt(reset.Unix(), 10)},
+			failsNow: true,
+			resetAt:  reset,
+		},
+		{
+			name:     "secondary limit on 403",
+			status:   http.StatusForbidden,
+			headers:  map[string]string{"Retry-After": "120", "X-RateLimit-Remaining": "10"},
+			failsNow: true,
+			resetAt:  time.Now().Add(120 * time.Second),
+		},
+		{
+			name:     "secondary limit on 429",
+			status:   http.StatusTooManyRequests,
+			headers:  map[string]string{"Retry-After": "60"},
+			failsNow: true,
+			resetAt:  time.Now().Add(60 * time.Second),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, requests := newGithubServer(t, func(w http.ResponseWriter, r *http.Request) {
+				for name, value := range tt.headers {
+					w.Header().Set(name, value)
+				}
+
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, `{"full_name": "owner/name"}`)
+			})
+
+			_, err := provider.FetchMetadata(context.Background(), repositoryURL)
+			if tt.failsNow != failure.Is(err, failure.TooManyRequestsFailure) {
+				t.Fatalf("first request error = %v, want rate limited %v", err, tt.failsNow)
+			}
+
+			if until := provider.rateLimitedUntil(); until.Sub(tt.resetAt).Abs() > 2*time.Second {
+				t.Errorf("rate limited until %v, want %v", until, tt.resetAt)
+			}
+
+			// Requests before the reset fail without reaching GitHub
+			if _, err := provider.FetchReadme(context.Background(), repositoryURL); !failure.Is(err, failure.TooManyRequestsFailure) {
+				t.Errorf("request before the reset: error = %v, want a too many requests failure", err)
+			}
+
+			if requests.Load() != 1 {
+				t.Errorf("%d requests reached GitHub, want 1", requests.Load())
+			}
+
+			// And go through again once it has passed
+			provider.mu.Lock()
+			provider.resetAt = time.Now().Add(-time.Second)
+			provider.mu.Unlock()
+
+			provider.FetchMetadata(context.Background(), repositoryURL)
+			if requests.Load() != 2 {
+				t.Errorf("%d requests reached GitHub after the reset, want 2", requests.Load())
+			}
+		})
+	}
+}
+
+func TestForbiddenWithoutRateLimit(t *testing.T) {
+	provider, requests := newGithubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "4000")
+		http.Error(w, "Resource not accessible", http.StatusForbidden)
+	})
+
+	_, err := provider.FetchMetadata(context.Background(), repositoryURL)
+	if !failure.Is(err, failure.InternalFailure) {
+		t.Errorf("error = %v, want an internal failure", err)
+	}
+
+	provider.FetchMetadata(context.Background(), repositoryURL)
+	if requests.Load() != 2 {
+		t.Errorf("%d requests reached GitHub, want 2 since no limit was reported", requests.Load())
+	}
+}
@@ -0,0 +1,90 @@
+package caches
+
+import (
+	"context"
+	"errors"
+	"hinsun-backend/internal/core/cache"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKeyPrefix keeps cache keys apart from anything else stored in the same database
+const redisKeyPrefix = "cache:"
+
+// redisScanCount is the number of keys requested per SCAN call during prefix deletes
+const redisScanCount = 500
+
+type redisCache struct {
+	client *redis.Client
+}
+
+// NewRedisCache creates a cache shared by every instance connected to the same Redis
+func NewRedisCache(client *redis.Client) cache.Cache {
+	return &redisCache{client: client}
+}
+
+func (c *redisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, redisKeyPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return value, true, nil
+}
+
+func (c *redisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, redisKeyPrefix+key, value, ttl).Err()
+}
+
+func (c *redisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = redisKeyPrefix + key
+	}
+
+	return c.client.Unlink(ctx, prefixed...).Err()
+}
+
+// DeletePrefix walks the keyspace with SCAN rather than KEYS, so Redis is never blocked
+func (c *redisCache) DeletePrefix(ctx context.Context, prefix string) error {
+	iterator := c.client.Scan(ctx, 0, redisKeyPrefix+prefix+"*", redisScanCount).Iterator()
+
+	batch := make([]string, 0, redisScanCount)
+	for iterator.Next(ctx) {
+		batch = append(batch, iterator.Val())
+		if len(batch) == redisScanCount {
+			if err := c.client.Unlink(ctx, batch...).Err(); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	if err := iterator.Err(); err != nil {
+		return err
+	}
+
+	if len(batch) > 0 {
+		return c.client.Unlink(ctx, batch...).Err()
+	}
+
+	return nil
+}
+
+// Ping lets the readiness probe check the connection
+func (c *redisCache) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
+
+func (c *redisCache) Close() error {
+	return c.client.Close()
+}
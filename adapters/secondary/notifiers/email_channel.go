@@ -0,0 +1,32 @@
+package notifiers
+
+import (
+	"context"
+	"hinsun-backend/internal/domain/notification"
+	"hinsun-backend/pkg/mailer"
+)
+
+type emailChannel struct {
+	mailer mailer.Mailer
+}
+
+// NewEmailChannel emails the notification to the account's address
+func NewEmailChannel(mailer mailer.Mailer) notification.NotificationChannel {
+	return &emailChannel{mailer: mailer}
+}
+
+func (c *emailChannel) Channel() notification.Channel {
+	return notification.ChannelEmail
+}
+
+func (c *emailChannel) Deliver(ctx context.Context, message *notification.Message) error {
+	if message.Recipient.Email == "" {
+		return nil
+	}
+
+	return c.mailer.Send(ctx, &mailer.Message{
+		To:      message.Recipient.Email,
+		Subject: message.Title,
+		Body:    message.Body,
+	})
+}
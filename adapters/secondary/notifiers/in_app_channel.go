@@ -0,0 +1,49 @@
+package notifiers
+
+import (
+	"context"
+	"hinsun-backend/internal/core/events"
+	"hinsun-backend/internal/domain/notification"
+)
+
+type inAppChannel struct {
+	notificationService notification.NotificationService
+	eventBus            events.EventBus
+}
+
+// NewInAppChannel stores the notification in the account's inbox and announces it on the bus,
+// so connected clients learn about it without polling
+func NewInAppChannel(notificationService notification.NotificationService, eventBus events.EventBus) notification.NotificationChannel {
+	return &inAppChannel{
+		notificationService: notificationService,
+		eventBus:            eventBus,
+	}
+}
+
+func (c *inAppChannel) Channel() notification.Channel {
+	return notification.ChannelInApp
+}
+
+func (c *inAppChannel) Deliver(ctx context.Context, message *notification.Message) error {
+	created, err := c.notificationService.CreateNotification(
+		ctx,
+		message.Recipient.AccountID,
+		message.Type,
+		message.Title,
+		message.Body,
+		message.Data,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return c.eventBus.Publish(ctx, notification.NewNotificationCreatedEvent(notification.NotificationCreatedPayload{
+		NotificationID: created.ID.String(),
+		AccountID:      created.AccountID,
+		Type:           created.Type,
+		Title:          created.Title,
+		Content:        created.Content,
+		Data:           created.Data,
+	}))
+}
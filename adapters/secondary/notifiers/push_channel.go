@@ -0,0 +1,95 @@
+package notifiers
+
+import (
+	"context"
+	"fmt"
+	"hinsun-backend/internal/core/log"
+	"hinsun-backend/internal/domain/devicetoken"
+	"hinsun-backend/internal/domain/notification"
+	"hinsun-backend/pkg/firebase"
+
+	"go.uber.org/zap"
+)
+
+type pushChannel struct {
+	deviceTokenService devicetoken.DeviceTokenService
+	fcmService         firebase.FCMService
+}
+
+// NewPushChannel sends the notification to every registered device of the account through FCM,
+// pruning the tokens FCM rejects
+func NewPushChannel(deviceTokenService devicetoken.DeviceTokenService, fcmService firebase.FCMService) notification.NotificationChannel {
+	return &pushChannel{
+		deviceTokenService: deviceTokenService,
+		fcmService:         fcmService,
+	}
+}
+
+func (c *pushChannel) Channel() notification.Channel {
+	return notification.ChannelPush
+}
+
+func (c *pushChannel) Deliver(ctx context.Context, message *notification.Message) error {
+	// 1. Collect the devices of the recipient
+	deviceTokens, err := c.deviceTokenService.FindAccountTokens(ctx, message.Recipient.AccountID)
+	if err != nil {
+		return err
+	}
+
+	if len(deviceTokens) == 0 {
+		return nil
+	}
+
+	tokens := make([]string, 0, len(deviceTokens))
+	for _, deviceToken := range deviceTokens {
+		tokens = append(tokens, deviceToken.Token)
+	}
+
+	data := make(map[string]string, len(message.Data)+1)
+	for key, value := range message.Data {
+		data[key] = value
+	}
+	data["type"] = string(message.Type)
+
+	// 2. Send, a partial result still reports the tokens rejected by the batches that went through
+	result, sendErr := c.fcmService.SendMulticast(ctx, tokens, &firebase.PushNotification{
+		Title: message.Title,
+		Body:  message.Body,
+		Data:  data,
+	})
+
+	// 3. Forget devices that uninstalled the app or whose token expired
+	if result != nil && len(result.InvalidTokens) > 0 {
+		pruned, err := c.deviceTokenService.PruneTokens(ctx, result.InvalidTokens)
+		if err != nil {
+			return err
+		}
+
+		log.FromContext(ctx).Info(fmt.Sprintf("🧹 Pruned %d invalid device tokens", pruned), zap.Int("rejected", len(result.InvalidTokens)))
+	}
+
+	return sendErr
+}
+
+type topicChannel struct {
+	fcmService firebase.FCMService
+}
+
+// NewTopicChannel broadcasts notifications to FCM topics, reaching devices without an account
+func NewTopicChannel(fcmService firebase.FCMService) notification.TopicChannel {
+	return &topicChannel{fcmService: fcmService}
+}
+
+func (c *topicChannel) Broadcast(ctx context.Context, topic string, message *notification.Message) error {
+	data := make(map[string]string, len(message.Data)+1)
+	for key, value := range message.Data {
+		data[key] = value
+	}
+	data["type"] = string(message.Type)
+
+	return c.fcmService.SendToTopic(ctx, topic, &firebase.PushNotification{
+		Title: message.Title,
+		Body:  message.Body,
+		Data:  data,
+	})
+}
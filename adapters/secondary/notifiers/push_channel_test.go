@@ -0,0 +1,146 @@
+package notifiers
+
+import (
+	"context"
+	"errors"
+	"hinsun-backend/internal/core/log"
+	"hinsun-backend/internal/domain/devicetoken"
+	"hinsun-backend/internal/domain/notification"
+	"hinsun-backend/pkg/firebase"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const accountID = "0198c1a0-0000-7000-8000-000000000001"
+
+// fakeDeviceTokenService serves a fixed set of tokens and records the ones pruned
+type fakeDeviceTokenService struct {
+	devicetoken.DeviceTokenService
+	tokens []string
+	pruned []string
+}
+
+func (s *fakeDeviceTokenService) FindAccountTokens(ctx context.Context, accountID string) ([]*devicetoken.DeviceTokenEntity, error) {
+	entities := make([]*devicetoken.DeviceTokenEntity, 0, len(s.tokens))
+	for _, token := range s.tokens {
+		entities = append(entities, &devicetoken.DeviceTokenEntity{
+			ID:        uuid.New(),
+			AccountID: uuid.MustParse(accountID),
+			Token:     token,
+			Platform:  devicetoken.PlatformAndroid,
+		})
+	}
+
+	return entities, nil
+}
+
+func (s *fakeDeviceTokenService) PruneTokens(ctx context.Context, tokens []string) (int, error) {
+	s.pruned = append(s.pruned, tokens...)
+	return len(tokens), nil
+}
+
+// failingFCMService fails the request after the fake handled it, the way a later batch failing
+// leaves the result of the earlier ones
+type failingFCMService struct {
+	*firebase.FakeFCMService
+	err error
+}
+
+func (s *failingFCMService) SendMulticast(ctx context.Context, tokens []string, notification *firebase.PushNotification) (*firebase.MulticastResult, error) {
+	result, _ := s.FakeFCMService.SendMulticast(ctx, tokens, notification)
+	return result, s.err
+}
+
+var errQuotaExceeded = errors.New("quota exceeded")
+
+func TestPushChannelPrunesInvalidTokens(t *testing.T) {
+	log.Logger = zap.NewNop()
+
+	tests := []struct {
+		name        string
+		invalid     []string
+		unavailable []string
+		requestErr  error
+		wantPruned  []string
+		wantSent    []string
+	}{
+		{
+			name:     "all delivered",
+			wantSent: []string{"phone", "tablet", "browser"},
+		},
+		{
+			name:       "unregistered tokens",
+			invalid:    []string{"tablet", "browser"},
+			wantPruned: []string{"tablet", "browser"},
+			wantSent:   []string{"phone"},
+		},
+		{
+			name:        "transient failures",
+			unavailable: []string{"phone", "tablet"},
+			wantSent:    []string{"browser"},
+		},
+		{
+			name:        "unregistered and transient failures",
+			invalid:     []string{"tablet"},
+			unavailable: []string{"phone"},
+			wantPruned:  []string{"tablet"},
+			wantSent:    []string{"browser"},
+		},
+		{
+			name:       "request failed",
+			requestErr: errQuotaExceeded,
+			wantSent:   []string{"phone", "tablet", "browser"},
+		},
+		{
+			name:       "request failed after a partial result",
+			invalid:    []string{"browser"},
+			requestErr: errQuotaExceeded,
+			wantPruned: []string{"browser"},
+			wantSent:   []string{"phone", "tablet"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deviceTokens := &fakeDeviceTokenService{tokens: []string{"phone", "tablet", "browser"}}
+			fake := firebase.NewFakeFCMService()
+			fake.MarkInvalid(tt.invalid...)
+			fake.MarkUnavailable(tt.unavailable...)
+
+			var fcmService firebase.FCMService = fake
+			if tt.requestErr != nil {
+				fcmService = &failingFCMService{FakeFCMService: fake, err: tt.requestErr}
+			}
+
+			err := NewPushChannel(deviceTokens, fcmService).Deliver(context.Background(), &notification.Message{
+				Recipient: notification.Recipient{AccountID: accountID},
+				Type:      notification.EventTypeCommentReply,
+				Title:     "New comment",
+				Body:      "Someone replied",
+				Data:      map[string]string{"commentId": "42"},
+			})
+			if !errors.Is(err, tt.requestErr) {
+				t.Errorf("deliver error = %v, want %v", err, tt.requestErr)
+			}
+
+			if !slices.Equal(deviceTokens.pruned, tt.wantPruned) {
+				t.Errorf("pruned %v, want %v", deviceTokens.pruned, tt.wantPruned)
+			}
+
+			var sent []string
+			for _, push := range fake.Sent() {
+				sent = append(sent, push.Token)
+				if push.Notification.Data["type"] != string(notification.EventTypeCommentReply) || push.Notification.Data["commentId"] != "42" {
+					t.Errorf("unexpected data %v", push.Notification.Data)
+				}
+			}
+
+			if !slices.Equal(sent, tt.wantSent) {
+				t.Errorf("sent to %v, want %v", sent, tt.wantSent)
+			}
+		})
+	}
+}
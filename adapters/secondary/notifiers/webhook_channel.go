@@ -0,0 +1,133 @@
+package notifiers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hinsun-backend/internal/domain/notification"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	// WebhookSignatureHeader carries sha256=<hex HMAC of "<timestamp>.<body>"> keyed by the
+	// account's webhook secret
+	WebhookSignatureHeader = "X-Hinsun-Signature"
+	WebhookTimestampHeader = "X-Hinsun-Timestamp"
+	WebhookEventHeader     = "X-Hinsun-Event"
+)
+
+type webhookPayload struct {
+	AccountID string            `json:"accountId"`
+	Type      string            `json:"type"`
+	Title     string            `json:"title"`
+	Body      string            `json:"body"`
+	Data      map[string]string `json:"data,omitempty"`
+	SentAt    int64             `json:"sentAt"`
+}
+
+type webhookChannel struct {
+	client *http.Client
+}
+
+// NewWebhookChannel posts the notification as signed JSON to the account's webhook URL
+func NewWebhookChannel(timeout time.Duration) notification.NotificationChannel {
+	dialer := &net.Dialer{Timeout: timeout, Control: rejectPrivateAddresses}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &webhookChannel{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			// Redirects could lead the signed body to another host
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (c *webhookChannel) Channel() notification.Channel {
+	return notification.ChannelWebhook
+}
+
+func (c *webhookChannel) Deliver(ctx context.Context, message *notification.Message) error {
+	preference := message.Preference
+	if preference == nil || preference.WebhookURL == "" {
+		return nil
+	}
+
+	sentAt := time.Now().Unix()
+	body, err := json.Marshal(webhookPayload{
+		AccountID: message.Recipient.AccountID,
+		Type:      string(message.Type),
+		Title:     message.Title,
+		Body:      message.Body,
+		Data:      message.Data,
+		SentAt:    sentAt,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, preference.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(sentAt, 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookEventHeader, string(message.Type))
+	request.Header.Set(WebhookTimestampHeader, timestamp)
+	request.Header.Set(WebhookSignatureHeader, "sha256="+signWebhook(preference.WebhookSecret, timestamp, body))
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", response.StatusCode)
+	}
+
+	return nil
+}
+
+// rejectPrivateAddresses checks the resolved address, so webhook URLs cannot reach this host or
+// the internal network whatever their host name resolves to
+func rejectPrivateAddresses(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+		return fmt.Errorf("webhook address %s is not publicly routable", host)
+	}
+
+	return nil
+}
+
+// signWebhook covers the timestamp too, so receivers can reject replayed deliveries
+func signWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
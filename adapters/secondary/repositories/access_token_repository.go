@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"context"
+	"hinsun-backend/adapters/shared/models"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/accesstoken"
+
+	"gorm.io/gorm"
+)
+
+type accessTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewAccessTokenRepository(db *gorm.DB) accesstoken.AccessTokenRepository {
+	return &accessTokenRepository{
+		db: db,
+	}
+}
+
+func (r *accessTokenRepository) Create(ctx context.Context, token *accesstoken.AccessTokenEntity) error {
+	model := models.FromAccessTokenEntity(token)
+	err := gorm.G[models.AccessTokenModel](withTx(ctx, r.db)).Create(ctx, &model)
+	if err != nil {
+		return failure.NewDatabaseFailure("Failed to create access token in database").WithCause(err)
+	}
+
+	return nil
+}
+
+// Update persists usage and revocation, the only mutable parts of a token
+func (r *accessTokenRepository) Update(ctx context.Context, token *accesstoken.AccessTokenEntity) (int, error) {
+	rowsAffected, err := gorm.G[models.AccessTokenModel](withTx(ctx, r.db)).
+		Select("last_used_at", "revoked_at").
+		Where("id = ?", token.ID).
+		Updates(ctx, models.FromAccessTokenEntity(token))
+	if err != nil {
+		return 0, failure.NewDatabaseFailure("Failed to update access token in database").WithCause(err)
+	}
+
+	return rowsAffected, nil
+}
+
+func (r *accessTokenRepository) FindByID(ctx context.Context, id string) (*accesstoken.AccessTokenEntity, error) {
+	tokenModel, err := gorm.G[models.AccessTokenModel](withTx(ctx, r.db)).Where("id = ?", id).First(ctx)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, failure.NewDatabaseFailure("Failed to retrieve access token from database").WithCause(err)
+	}
+
+	return tokenModel.ToEntity(), nil
+}
+
+func (r *accessTokenRepository) FindByLookupID(ctx context.Context, lookupID string) (*accesstoken.AccessTokenEntity, error) {
+	tokenModel, err := gorm.G[models.AccessTokenModel](withTx(ctx, r.db)).Where("lookup_id = ?", lookupID).First(ctx)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, failure.NewDatabaseFailure("Failed to retrieve access token from database").WithCause(err)
+	}
+
+	return tokenModel.ToEntity(), nil
+}
+
+func (r *accessTokenRepository) FindByAccountID(ctx context.Context, accountID string) ([]*accesstoken.AccessTokenEntity, error) {
+	tokenModels, err := gorm.G[models.AccessTokenModel](withTx(ctx, r.db)).Where("account_id = ?", accountID).Order("created_at DESC").Find(ctx)
+	if err != nil {
+		return nil, failure.NewDatabaseFailure("Failed to retrieve access tokens from database").WithCause(err)
+	}
+
+	tokenEntities := make([]*accesstoken.AccessTokenEntity, 0, len(tokenModels))
+	for _, tokenModel := range tokenModels {
+		tokenEntities = append(tokenEntities, tokenModel.ToEntity())
+	}
+
+	return tokenEntities, nil
+}
@@ -10,6 +10,12 @@ import (
 	"gorm.io/gorm"
 )
 
+// accountColumns are written on update, so a profile may clear its bio or an account be deactivated.
+// The two-factor columns are written by UpdateTwoFactor only.
+var accountColumns = []string{
+	"name", "email", "email_verified", "is_active", "password", "avatar", "bio", "updated_at", "version",
+}
+
 type accountRepository struct {
 	db *gorm.DB
 }
@@ -22,7 +28,7 @@ func NewAccountRepository(db *gorm.DB) account.AccountRepository {
 
 func (r *accountRepository) Create(ctx context.Context, account *account.AccountEntity) error {
 	model := models.FromAccountEntity(account)
-	err := gorm.G[models.AccountModel](r.db).Create(ctx, &model)
+	err := gorm.G[models.AccountModel](withTx(ctx, r.db)).Create(ctx, &model)
 	if err != nil {
 		return failure.NewDatabaseFailure("Failed to create account in database").WithCause(err)
 	}
@@ -31,7 +37,7 @@ func (r *accountRepository) Create(ctx context.Context, account *account.Account
 }
 
 func (r *accountRepository) FindByEmail(ctx context.Context, email *values.Email) (*account.AccountEntity, error) {
-	account, err := gorm.G[models.AccountModel](r.db).Where("email = ?", email.Value()).First(ctx)
+	account, err := gorm.G[models.AccountModel](withTx(ctx, r.db)).Where("email = ?", email.Value()).First(ctx)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -49,16 +55,128 @@ func (r *accountRepository) FindByEmail(ctx context.Context, email *values.Email
 }
 
 func (r *accountRepository) Update(ctx context.Context, account *account.AccountEntity) (int, error) {
-	rowsAffected, err := gorm.G[models.AccountModel](r.db).Where("id = ?", account.ID).Updates(ctx, models.FromAccountEntity(account))
+	model := models.FromAccountEntity(account)
+	model.Version = account.Version + 1
+
+	rowsAffected, err := updateVersioned(ctx, withTx(ctx, r.db), account.ID, account.Version, model, accountColumns...)
 	if err != nil {
+		if failure.Is(err, failure.ConflictFailure) {
+			return 0, err
+		}
+
 		return 0, failure.NewDatabaseFailure("Failed to update account in database").WithCause(err)
 	}
 
+	if rowsAffected > 0 {
+		account.Version = model.Version
+	}
+
+	return rowsAffected, nil
+}
+
+// UpdateTwoFactor persists the two-factor columns explicitly, so disabling (zero values) is written too
+func (r *accountRepository) UpdateTwoFactor(ctx context.Context, account *account.AccountEntity) (int, error) {
+	rowsAffected, err := gorm.G[models.AccountModel](withTx(ctx, r.db)).
+		Select("two_factor_enabled", "two_factor_secret", "recovery_codes", "updated_at").
+		Where("id = ?", account.ID).
+		Updates(ctx, models.FromAccountEntity(account))
+	if err != nil {
+		return 0, failure.NewDatabaseFailure("Failed to update two-factor settings in database").WithCause(err)
+	}
+
 	return rowsAffected, nil
 }
 
+func (r *accountRepository) AcceptTwoFactorStep(ctx context.Context, id string, step int64) (int, error) {
+	// The condition makes the check and the write one statement, so of two requests sending the
+	// same code only one changes the row
+	result := withTx(ctx, r.db).WithContext(ctx).Model(&models.AccountModel{}).
+		Where("id = ? AND two_factor_last_step < ?", id, step).
+		UpdateColumn("two_factor_last_step", step)
+	if result.Error != nil {
+		return 0, failure.NewDatabaseFailure("Failed to record two-factor code in database").WithCause(result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+func (r *accountRepository) ReserveTwoFactorAttempt(ctx context.Context, id string, maxAttempts int, now, lockedUntil int64) (int, error) {
+	// A counter left at the limit by an expired lockout starts over; both expressions read the old counter
+	attempts := "CASE WHEN two_factor_failed_attempts >= ? THEN 1 ELSE two_factor_failed_attempts + 1 END"
+	result := withTx(ctx, r.db).WithContext(ctx).Model(&models.AccountModel{}).
+		Where("id = ? AND two_factor_locked_until <= ?", id, now).
+		UpdateColumns(map[string]any{
+			"two_factor_failed_attempts": gorm.Expr(attempts, maxAttempts),
+			"two_factor_locked_until": gorm.Expr(
+				"CASE WHEN ("+attempts+") >= ? THEN ? ELSE two_factor_locked_until END",
+				maxAttempts, maxAttempts, lockedUntil,
+			),
+		})
+	if result.Error != nil {
+		return 0, failure.NewDatabaseFailure("Failed to count two-factor attempt in database").WithCause(result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+func (r *accountRepository) ClearTwoFactorAttempts(ctx context.Context, id string) error {
+	err := withTx(ctx, r.db).WithContext(ctx).Model(&models.AccountModel{}).
+		Where("id = ? AND two_factor_failed_attempts > 0", id).
+		UpdateColumns(map[string]any{"two_factor_failed_attempts": 0, "two_factor_locked_until": 0}).Error
+	if err != nil {
+		return failure.NewDatabaseFailure("Failed to reset two-factor attempts in database").WithCause(err)
+	}
+
+	return nil
+}
+
+func (r *accountRepository) FindWithTwoFactorSecret(ctx context.Context) ([]*account.AccountEntity, error) {
+	accountModels, err := gorm.G[models.AccountModel](withTx(ctx, r.db)).Where("two_factor_secret <> ''").Find(ctx)
+	if err != nil {
+		return nil, failure.NewDatabaseFailure("Failed to retrieve two-factor accounts from database").WithCause(err)
+	}
+
+	accountEntities := make([]*account.AccountEntity, 0, len(accountModels))
+	for _, accountModel := range accountModels {
+		entity, err := accountModel.ToEntity()
+		if err != nil {
+			return nil, err
+		}
+
+		accountEntities = append(accountEntities, entity)
+	}
+
+	return accountEntities, nil
+}
+
+func (r *accountRepository) ReplaceTwoFactorSecret(ctx context.Context, id string, oldSecret, newSecret string) (int, error) {
+	// A secret replaced by a new enrollment meanwhile is left alone
+	result := withTx(ctx, r.db).WithContext(ctx).Model(&models.AccountModel{}).
+		Where("id = ? AND two_factor_secret = ?", id, oldSecret).
+		UpdateColumn("two_factor_secret", newSecret)
+	if result.Error != nil {
+		return 0, failure.NewDatabaseFailure("Failed to update two-factor secret in database").WithCause(result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+func (r *accountRepository) RemoveRecoveryCode(ctx context.Context, id string, hashedCode string) (int, error) {
+	// Of two requests redeeming the same code, the second finds it removed and changes no row
+	result := withTx(ctx, r.db).WithContext(ctx).Model(&models.AccountModel{}).
+		Where("id = ? AND ? = ANY(recovery_codes)", id, hashedCode).
+		Updates(map[string]any{
+			"recovery_codes": gorm.Expr("array_remove(recovery_codes, ?)", hashedCode),
+		})
+	if result.Error != nil {
+		return 0, failure.NewDatabaseFailure("Failed to consume recovery code in database").WithCause(result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
 func (r *accountRepository) Delete(ctx context.Context, id string) (int, error) {
-	rowAffected, err := gorm.G[models.AccountModel](r.db).Where("id = ?", id).Delete(ctx)
+	rowAffected, err := gorm.G[models.AccountModel](withTx(ctx, r.db)).Where("id = ?", id).Delete(ctx)
 	if err != nil {
 		return 0, failure.NewDatabaseFailure("Failed to delete account from database").WithCause(err)
 	}
@@ -67,7 +185,7 @@ func (r *accountRepository) Delete(ctx context.Context, id string) (int, error)
 }
 
 func (r *accountRepository) DeleteMany(ctx context.Context, ids []string) (int, error) {
-	rowAffected, err := gorm.G[models.AccountModel](r.db).Where("id IN ?", ids).Delete(ctx)
+	rowAffected, err := gorm.G[models.AccountModel](withTx(ctx, r.db)).Where("id IN ?", ids).Delete(ctx)
 	if err != nil {
 		return 0, failure.NewDatabaseFailure("Failed to delete accounts from database").WithCause(err)
 	}
@@ -76,7 +194,7 @@ func (r *accountRepository) DeleteMany(ctx context.Context, ids []string) (int,
 }
 
 func (r *accountRepository) FindByID(ctx context.Context, id string) (*account.AccountEntity, error) {
-	accountModel, err := gorm.G[models.AccountModel](r.db).Where("id = ?", id).First(ctx)
+	accountModel, err := gorm.G[models.AccountModel](withTx(ctx, r.db)).Where("id = ?", id).First(ctx)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -94,7 +212,7 @@ func (r *accountRepository) FindByID(ctx context.Context, id string) (*account.A
 }
 
 func (r *accountRepository) FindAll(ctx context.Context) ([]*account.AccountEntity, error) {
-	blogs, err := gorm.G[models.AccountModel](r.db).Find(ctx)
+	blogs, err := gorm.G[models.AccountModel](withTx(ctx, r.db)).Find(ctx)
 	if err != nil {
 		return nil, failure.NewDatabaseFailure("Failed to retrieve blogs from database").WithCause(err)
 	}
@@ -114,7 +232,7 @@ func (r *accountRepository) FindAll(ctx context.Context) ([]*account.AccountEnti
 
 func (r *accountRepository) SearchByNameAndEmail(ctx context.Context, name, email string) ([]*account.AccountEntity, error) {
 	var accountModels []models.AccountModel
-	accountModels, err := gorm.G[models.AccountModel](r.db).Where("email LIKE ?", "%"+email+"%").Where("name LIKE ?", "%"+name+"%").Find(ctx)
+	accountModels, err := gorm.G[models.AccountModel](withTx(ctx, r.db)).Where("email LIKE ?", "%"+email+"%").Where("name LIKE ?", "%"+name+"%").Find(ctx)
 	if err != nil {
 		return nil, failure.NewDatabaseFailure("Failed to search accounts in database").WithCause(err)
 	}
@@ -9,6 +9,12 @@ import (
 	"gorm.io/gorm"
 )
 
+// blogColumns are written on update, so a merge patch may unpublish a blog or reset a counter
+var blogColumns = []string{
+	"slug", "languages", "categories", "names", "descriptions", "is_published", "markdowns",
+	"favorites", "views", "estimated_read_time_seconds", "updated_at", "version",
+}
+
 type blogRepository struct {
 	db *gorm.DB
 }
@@ -22,7 +28,7 @@ func NewBlogRepository(db *gorm.DB) blog.BlogRepository {
 
 func (r *blogRepository) Create(ctx context.Context, blog *blog.BlogEntity) error {
 	model := models.FromBlogEntity(blog)
-	err := gorm.G[models.BlogModel](r.db).Create(ctx, &model)
+	err := gorm.G[models.BlogModel](withTx(ctx, r.db)).Create(ctx, &model)
 	if err != nil {
 		return failure.NewDatabaseFailure("Failed to create blog in database").WithCause(err)
 	}
@@ -31,16 +37,27 @@ func (r *blogRepository) Create(ctx context.Context, blog *blog.BlogEntity) erro
 }
 
 func (r *blogRepository) Update(ctx context.Context, blog *blog.BlogEntity) (int, error) {
-	rowsAffected, err := gorm.G[models.BlogModel](r.db).Where("id = ?", blog.ID).Updates(ctx, models.FromBlogEntity(blog))
+	model := models.FromBlogEntity(blog)
+	model.Version = blog.Version + 1
+
+	rowsAffected, err := updateVersioned(ctx, withTx(ctx, r.db), blog.ID, blog.Version, model, blogColumns...)
 	if err != nil {
+		if failure.Is(err, failure.ConflictFailure) {
+			return 0, err
+		}
+
 		return 0, failure.NewDatabaseFailure("Failed to update blog in database").WithCause(err)
 	}
 
+	if rowsAffected > 0 {
+		blog.Version = model.Version
+	}
+
 	return rowsAffected, nil
 }
 
 func (r *blogRepository) Delete(ctx context.Context, id string) (int, error) {
-	rowAffected, err := gorm.G[models.BlogModel](r.db).Where("id = ?", id).Delete(ctx)
+	rowAffected, err := gorm.G[models.BlogModel](withTx(ctx, r.db)).Where("id = ?", id).Delete(ctx)
 	if err != nil {
 		return 0, failure.NewDatabaseFailure("Failed to delete blog from database").WithCause(err)
 	}
@@ -49,7 +66,7 @@ func (r *blogRepository) Delete(ctx context.Context, id string) (int, error) {
 }
 
 func (r *blogRepository) DeleteMany(ctx context.Context, ids []string) (int, error) {
-	rowAffected, err := gorm.G[models.BlogModel](r.db).Where("id IN ?", ids).Delete(ctx)
+	rowAffected, err := gorm.G[models.BlogModel](withTx(ctx, r.db)).Where("id IN ?", ids).Delete(ctx)
 	if err != nil {
 		return 0, failure.NewDatabaseFailure("Failed to delete blogs from database").WithCause(err)
 	}
@@ -58,7 +75,7 @@ func (r *blogRepository) DeleteMany(ctx context.Context, ids []string) (int, err
 }
 
 func (r *blogRepository) FindByID(ctx context.Context, id string) (*blog.BlogEntity, error) {
-	blog, err := gorm.G[models.BlogModel](r.db).Where("id = ?", id).First(ctx)
+	blog, err := gorm.G[models.BlogModel](withTx(ctx, r.db)).Where("id = ?", id).First(ctx)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -70,7 +87,7 @@ func (r *blogRepository) FindByID(ctx context.Context, id string) (*blog.BlogEnt
 }
 
 func (r *blogRepository) FindAll(ctx context.Context) ([]*blog.BlogEntity, error) {
-	blogs, err := gorm.G[models.BlogModel](r.db).Find(ctx)
+	blogs, err := gorm.G[models.BlogModel](withTx(ctx, r.db)).Find(ctx)
 	if err != nil {
 		return nil, failure.NewDatabaseFailure("Failed to retrieve blogs from database").WithCause(err)
 	}
@@ -84,7 +101,7 @@ func (r *blogRepository) FindAll(ctx context.Context) ([]*blog.BlogEntity, error
 }
 
 func (r *blogRepository) FindByAuthorID(ctx context.Context, authorID string) ([]*blog.BlogEntity, error) {
-	blogs, err := gorm.G[models.BlogModel](r.db).Where("author_id = ?", authorID).Find(ctx)
+	blogs, err := gorm.G[models.BlogModel](withTx(ctx, r.db)).Where("author_id = ?", authorID).Find(ctx)
 	if err != nil {
 		return nil, failure.NewDatabaseFailure("Failed to retrieve blogs by author from database").WithCause(err)
 	}
@@ -98,7 +115,7 @@ func (r *blogRepository) FindByAuthorID(ctx context.Context, authorID string) ([
 }
 
 func (r *blogRepository) FindByName(ctx context.Context, name string) (*blog.BlogEntity, error) {
-	blog, err := gorm.G[models.BlogModel](r.db).Where("name = ?", name).First(ctx)
+	blog, err := gorm.G[models.BlogModel](withTx(ctx, r.db)).Where("name = ?", name).First(ctx)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -9,6 +9,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// categoryColumns are written on update, the blog count may drop to 0
+var categoryColumns = []string{"name", "num_blogs", "updated_at", "version"}
+
 type categoryRepository struct {
 	db *gorm.DB
 }
@@ -21,7 +24,7 @@ func NewCategoryRepository(db *gorm.DB) category.CategoryRepository {
 
 func (r *categoryRepository) Create(ctx context.Context, category *category.CategoryEntity) error {
 	model := models.FromCategoryEntity(category)
-	err := gorm.G[models.CategoryModel](r.db).Create(ctx, &model)
+	err := gorm.G[models.CategoryModel](withTx(ctx, r.db)).Create(ctx, &model)
 	if err != nil {
 		return failure.NewDatabaseFailure("Failed to create category in database").WithCause(err)
 	}
@@ -30,18 +33,27 @@ func (r *categoryRepository) Create(ctx context.Context, category *category.Cate
 }
 
 func (r *categoryRepository) Update(ctx context.Context, category *category.CategoryEntity) (int, error) {
-	rowsAffected, err := gorm.G[models.CategoryModel](r.db).
-		Where("id = ?", category.ID).
-		Updates(ctx, models.FromCategoryEntity(category))
+	model := models.FromCategoryEntity(category)
+	model.Version = category.Version + 1
+
+	rowsAffected, err := updateVersioned(ctx, withTx(ctx, r.db), category.ID, category.Version, model, categoryColumns...)
 	if err != nil {
+		if failure.Is(err, failure.ConflictFailure) {
+			return 0, err
+		}
+
 		return 0, failure.NewDatabaseFailure("Failed to update category in database").WithCause(err)
 	}
 
+	if rowsAffected > 0 {
+		category.Version = model.Version
+	}
+
 	return rowsAffected, nil
 }
 
 func (r *categoryRepository) Delete(ctx context.Context, id string) (int, error) {
-	rowAffected, err := gorm.G[models.CategoryModel](r.db).Where("id = ?", id).Delete(ctx)
+	rowAffected, err := gorm.G[models.CategoryModel](withTx(ctx, r.db)).Where("id = ?", id).Delete(ctx)
 	if err != nil {
 		return 0, failure.NewDatabaseFailure("Failed to delete category from database").WithCause(err)
 	}
@@ -50,7 +62,7 @@ func (r *categoryRepository) Delete(ctx context.Context, id string) (int, error)
 }
 
 func (r *categoryRepository) DeleteMany(ctx context.Context, ids []string) (int, error) {
-	rowAffected, err := gorm.G[models.CategoryModel](r.db).Where("id IN ?", ids).Delete(ctx)
+	rowAffected, err := gorm.G[models.CategoryModel](withTx(ctx, r.db)).Where("id IN ?", ids).Delete(ctx)
 	if err != nil {
 		return 0, failure.NewDatabaseFailure("Failed to delete categories from database").WithCause(err)
 	}
@@ -59,7 +71,7 @@ func (r *categoryRepository) DeleteMany(ctx context.Context, ids []string) (int,
 }
 
 func (r *categoryRepository) FindByID(ctx context.Context, id string) (*category.CategoryEntity, error) {
-	categoryModel, err := gorm.G[models.CategoryModel](r.db).Where("id = ?", id).First(ctx)
+	categoryModel, err := gorm.G[models.CategoryModel](withTx(ctx, r.db)).Where("id = ?", id).First(ctx)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -72,7 +84,7 @@ func (r *categoryRepository) FindByID(ctx context.Context, id string) (*category
 }
 
 func (r *categoryRepository) FindByName(ctx context.Context, name string) (*category.CategoryEntity, error) {
-	categoryModel, err := gorm.G[models.CategoryModel](r.db).Where("name = ?", name).First(ctx)
+	categoryModel, err := gorm.G[models.CategoryModel](withTx(ctx, r.db)).Where("name = ?", name).First(ctx)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -85,7 +97,7 @@ func (r *categoryRepository) FindByName(ctx context.Context, name string) (*cate
 }
 
 func (r *categoryRepository) FindAll(ctx context.Context) ([]*category.CategoryEntity, error) {
-	categories, err := gorm.G[models.CategoryModel](r.db).Find(ctx)
+	categories, err := gorm.G[models.CategoryModel](withTx(ctx, r.db)).Find(ctx)
 	if err != nil {
 		return nil, failure.NewDatabaseFailure("Failed to retrieve categories from database").WithCause(err)
 	}
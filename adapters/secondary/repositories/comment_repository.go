@@ -10,6 +10,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// commentColumns are written on update, the favorites may drop to 0
+var commentColumns = []string{"content", "favorites", "updated_at", "version"}
+
 type commentRepository struct {
 	db *gorm.DB
 }
@@ -22,7 +25,7 @@ func NewCommentRepository(db *gorm.DB) comment.CommentRepository {
 
 func (r *commentRepository) Create(ctx context.Context, comment *comment.CommentEntity) error {
 	model := models.FromCommentEntity(comment)
-	err := gorm.G[models.CommentModel](r.db).Create(ctx, &model)
+	err := gorm.G[models.CommentModel](withTx(ctx, r.db)).Create(ctx, &model)
 	if err != nil {
 		return failure.NewDatabaseFailure("Failed to create comment in database").WithCause(err)
 	}
@@ -31,16 +34,27 @@ func (r *commentRepository) Create(ctx context.Context, comment *comment.Comment
 }
 
 func (r *commentRepository) Update(ctx context.Context, comment *comment.CommentEntity) (int, error) {
-	rowsAffected, err := gorm.G[models.CommentModel](r.db).Where("id = ?", comment.ID).Updates(ctx, models.FromCommentEntity(comment))
+	model := models.FromCommentEntity(comment)
+	model.Version = comment.Version + 1
+
+	rowsAffected, err := updateVersioned(ctx, withTx(ctx, r.db), comment.ID, comment.Version, model, commentColumns...)
 	if err != nil {
+		if failure.Is(err, failure.ConflictFailure) {
+			return 0, err
+		}
+
 		return 0, failure.NewDatabaseFailure("Failed to update comment in database").WithCause(err)
 	}
 
+	if rowsAffected > 0 {
+		comment.Version = model.Version
+	}
+
 	return rowsAffected, nil
 }
 
 func (r *commentRepository) Delete(ctx context.Context, id string) (int, error) {
-	rowAffected, err := gorm.G[models.CommentModel](r.db).Where("id = ?", id).Delete(ctx)
+	rowAffected, err := gorm.G[models.CommentModel](withTx(ctx, r.db)).Where("id = ?", id).Delete(ctx)
 	if err != nil {
 		return 0, failure.NewDatabaseFailure("Failed to delete comment from database").WithCause(err)
 	}
@@ -49,7 +63,7 @@ func (r *commentRepository) Delete(ctx context.Context, id string) (int, error)
 }
 
 func (r *commentRepository) DeleteMany(ctx context.Context, ids []string) (int, error) {
-	rowAffected, err := gorm.G[models.CommentModel](r.db).Where("id IN ?", ids).Delete(ctx)
+	rowAffected, err := gorm.G[models.CommentModel](withTx(ctx, r.db)).Where("id IN ?", ids).Delete(ctx)
 	if err != nil {
 		return 0, failure.NewDatabaseFailure("Failed to delete comments from database").WithCause(err)
 	}
@@ -58,7 +72,7 @@ func (r *commentRepository) DeleteMany(ctx context.Context, ids []string) (int,
 }
 
 func (r *commentRepository) Find(ctx context.Context, id string) (*comment.CommentEntity, error) {
-	comment, err := gorm.G[models.CommentModel](r.db).Where("id = ?", id).First(ctx)
+	comment, err := gorm.G[models.CommentModel](withTx(ctx, r.db)).Where("id = ?", id).First(ctx)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -70,7 +84,7 @@ func (r *commentRepository) Find(ctx context.Context, id string) (*comment.Comme
 }
 
 func (r *commentRepository) Finds(ctx context.Context) ([]*comment.CommentEntity, error) {
-	comments, err := gorm.G[models.CommentModel](r.db).Find(ctx)
+	comments, err := gorm.G[models.CommentModel](withTx(ctx, r.db)).Find(ctx)
 	if err != nil {
 		return nil, failure.NewDatabaseFailure("Failed to retrieve comments from database").WithCause(err)
 	}
@@ -85,7 +99,7 @@ func (r *commentRepository) Finds(ctx context.Context) ([]*comment.CommentEntity
 
 func (r *commentRepository) FindByBlogID(ctx context.Context, blogId string) ([]*comment.CommentEntity, error) {
 	var comments []models.CommentModel
-	err := r.db.Model(&models.CommentModel{}).Where("blog_id = ?", blogId).Find(&comments).Error
+	err := withTx(ctx, r.db).WithContext(ctx).Model(&models.CommentModel{}).Where("blog_id = ?", blogId).Find(&comments).Error
 
 	if err != nil {
 		return nil, failure.NewDatabaseFailure("Failed to retrieve comments by blog ID from database").WithCause(err)
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"hinsun-backend/adapters/shared/models"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/devicetoken"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type deviceTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewDeviceTokenRepository(db *gorm.DB) devicetoken.DeviceTokenRepository {
+	return &deviceTokenRepository{
+		db: db,
+	}
+}
+
+// Upsert keeps the ID and creation time of a known token and updates the rest
+func (r *deviceTokenRepository) Upsert(ctx context.Context, token *devicetoken.DeviceTokenEntity) (*devicetoken.DeviceTokenEntity, error) {
+	model := models.FromDeviceTokenEntity(token)
+	onConflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "token"}},
+		DoUpdates: clause.AssignmentColumns([]string{"account_id", "platform", "last_seen_at"}),
+	}
+
+	err := gorm.G[models.DeviceTokenModel](withTx(ctx, r.db), onConflict, clause.Returning{}).Create(ctx, &model)
+	if err != nil {
+		return nil, failure.NewDatabaseFailure("Failed to save device token in database").WithCause(err)
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (r *deviceTokenRepository) FindByAccountID(ctx context.Context, accountID string) ([]*devicetoken.DeviceTokenEntity, error) {
+	tokenModels, err := gorm.G[models.DeviceTokenModel](withTx(ctx, r.db)).Where("account_id = ?", accountID).Order("last_seen_at DESC").Find(ctx)
+	if err != nil {
+		return nil, failure.NewDatabaseFailure("Failed to retrieve device tokens from database").WithCause(err)
+	}
+
+	tokenEntities := make([]*devicetoken.DeviceTokenEntity, 0, len(tokenModels))
+	for _, tokenModel := range tokenModels {
+		tokenEntities = append(tokenEntities, tokenModel.ToEntity())
+	}
+
+	return tokenEntities, nil
+}
+
+func (r *deviceTokenRepository) DeleteByID(ctx context.Context, accountID, id string) (int, error) {
+	rowAffected, err := gorm.G[models.DeviceTokenModel](withTx(ctx, r.db)).Where("id = ? AND account_id = ?", id, accountID).Delete(ctx)
+	if err != nil {
+		return 0, failure.NewDatabaseFailure("Failed to delete device token from database").WithCause(err)
+	}
+
+	return rowAffected, nil
+}
+
+func (r *deviceTokenRepository) DeleteByTokens(ctx context.Context, tokens []string) (int, error) {
+	rowAffected, err := gorm.G[models.DeviceTokenModel](withTx(ctx, r.db)).Where("token IN ?", tokens).Delete(ctx)
+	if err != nil {
+		return 0, failure.NewDatabaseFailure("Failed to delete device tokens from database").WithCause(err)
+	}
+
+	return rowAffected, nil
+}
@@ -5,6 +5,7 @@ import (
 	"hinsun-backend/adapters/shared/models"
 	"hinsun-backend/internal/core/failure"
 	"hinsun-backend/internal/domain/experience"
+	"hinsun-backend/internal/domain/values"
 
 	"gorm.io/gorm"
 )
@@ -15,6 +16,12 @@ import (
 // Note: In adapters, we use GORM models or any other model suitable for the database operations.
 // So, we need to convert between domain entities and GORM models when implementing the methods.
 
+// experienceColumns are written on update, the order may become 0 and the end date null
+var experienceColumns = []string{
+	"order_idx", "languages", "positions", "companies", "location", "technologies",
+	"responsibilities", "start_date", "end_date", "updated_at", "version",
+}
+
 type experienceRepository struct {
 	db *gorm.DB
 }
@@ -28,7 +35,7 @@ func NewExperienceRepository(db *gorm.DB) experience.ExperienceRepository {
 
 func (r *experienceRepository) Create(ctx context.Context, experience *experience.ExperienceEntity) error {
 	model := models.FromExperienceEntity(experience)
-	err := gorm.G[models.ExperienceModel](r.db).Create(ctx, &model)
+	err := gorm.G[models.ExperienceModel](withTx(ctx, r.db)).Create(ctx, &model)
 	if err != nil {
 		return failure.NewDatabaseFailure("Failed to create experience in database").WithCause(err)
 	}
@@ -37,16 +44,27 @@ func (r *experienceRepository) Create(ctx context.Context, experience *experienc
 }
 
 func (r *experienceRepository) Update(ctx context.Context, experience *experience.ExperienceEntity) (int, error) {
-	rowsAffected, err := gorm.G[models.ExperienceModel](r.db).Where("id = ?", experience.ID).Updates(ctx, models.FromExperienceEntity(experience))
+	model := models.FromExperienceEntity(experience)
+	model.Version = experience.Version + 1
+
+	rowsAffected, err := updateVersioned(ctx, withTx(ctx, r.db), experience.ID, experience.Version, model, experienceColumns...)
 	if err != nil {
+		if failure.Is(err, failure.ConflictFailure) {
+			return 0, err
+		}
+
 		return 0, failure.NewDatabaseFailure("Failed to update experience in database").WithCause(err)
 	}
 
+	if rowsAffected > 0 {
+		experience.Version = model.Version
+	}
+
 	return rowsAffected, nil
 }
 
 func (r *experienceRepository) Delete(ctx context.Context, id string) (int, error) {
-	rowAffected, err := gorm.G[models.ExperienceModel](r.db).Where("id = ?", id).Delete(ctx)
+	rowAffected, err := gorm.G[models.ExperienceModel](withTx(ctx, r.db)).Where("id = ?", id).Delete(ctx)
 	if err != nil {
 		return 0, failure.NewDatabaseFailure("Failed to delete experience from database").WithCause(err)
 	}
@@ -55,7 +73,7 @@ func (r *experienceRepository) Delete(ctx context.Context, id string) (int, erro
 }
 
 func (r *experienceRepository) DeleteMany(ctx context.Context, ids []string) (int, error) {
-	rowAffected, err := gorm.G[models.ExperienceModel](r.db).Where("id IN ?", ids).Delete(ctx)
+	rowAffected, err := gorm.G[models.ExperienceModel](withTx(ctx, r.db)).Where("id IN ?", ids).Delete(ctx)
 	if err != nil {
 		return 0, failure.NewDatabaseFailure("Failed to delete experiences from database").WithCause(err)
 	}
@@ -64,7 +82,7 @@ func (r *experienceRepository) DeleteMany(ctx context.Context, ids []string) (in
 }
 
 func (r *experienceRepository) FindByID(ctx context.Context, id string) (*experience.ExperienceEntity, error) {
-	experience, err := gorm.G[models.ExperienceModel](r.db).Where("id = ?", id).First(ctx)
+	experience, err := gorm.G[models.ExperienceModel](withTx(ctx, r.db)).Where("id = ?", id).First(ctx)
 	if err != nil {
 		return nil, failure.NewDatabaseFailure("Failed to retrieve experience from database").WithCause(err)
 	}
@@ -73,7 +91,7 @@ func (r *experienceRepository) FindByID(ctx context.Context, id string) (*experi
 }
 
 func (r *experienceRepository) FindAll(ctx context.Context) ([]*experience.ExperienceEntity, error) {
-	experiences, err := gorm.G[models.ExperienceModel](r.db).Find(ctx)
+	experiences, err := gorm.G[models.ExperienceModel](withTx(ctx, r.db)).Order("order_idx, start_date").Find(ctx)
 	if err != nil {
 		return nil, failure.NewDatabaseFailure("Failed to retrieve experiences from database").WithCause(err)
 	}
@@ -86,21 +104,45 @@ func (r *experienceRepository) FindAll(ctx context.Context) ([]*experience.Exper
 	return experienceEntities, nil
 }
 
-func (r *experienceRepository) FindByOrderIdx(ctx context.Context, orderIdx int8) (*experience.ExperienceEntity, error) {
-	experience, err := gorm.G[models.ExperienceModel](r.db).Where("order_idx = ?", orderIdx).First(ctx)
+func (r *experienceRepository) MaxOrderIdx(ctx context.Context) (int, error) {
+	var maxOrderIdx int
+	err := withTx(ctx, r.db).WithContext(ctx).Model(&models.ExperienceModel{}).
+		Select("COALESCE(MAX(order_idx), -1)").
+		Scan(&maxOrderIdx).Error
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, nil
+		return 0, failure.NewDatabaseFailure("Failed to retrieve experience order from database").WithCause(err)
+	}
+
+	return maxOrderIdx, nil
+}
+
+func (r *experienceRepository) UpdateOrder(ctx context.Context, ids []string) (int, error) {
+	rowsAffected := 0
+	err := withTx(ctx, r.db).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for orderIdx, id := range ids {
+			result := tx.Model(&models.ExperienceModel{}).Where("id = ?", id).Updates(map[string]any{
+				"order_idx": orderIdx,
+				"version":   gorm.Expr("version + 1"),
+			})
+			if result.Error != nil {
+				return result.Error
+			}
+
+			rowsAffected += int(result.RowsAffected)
 		}
 
-		return nil, failure.NewDatabaseFailure("Failed to retrieve experience by order index from database").WithCause(err)
+		return nil
+	})
+
+	if err != nil {
+		return 0, failure.NewDatabaseFailure("Failed to update experience order in database").WithCause(err)
 	}
 
-	return experience.ToEntity(), nil
+	return rowsAffected, nil
 }
 
-func (r *experienceRepository) FindByCompany(ctx context.Context, company string) (*experience.ExperienceEntity, error) {
-	experience, err := gorm.G[models.ExperienceModel](r.db).Where("company = ?", company).First(ctx)
+func (r *experienceRepository) FindByCompany(ctx context.Context, language values.MarkdownLanguageCode, company string) (*experience.ExperienceEntity, error) {
+	experience, err := gorm.G[models.ExperienceModel](withTx(ctx, r.db)).Where("companies ->> ? = ?", string(language), company).First(ctx)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
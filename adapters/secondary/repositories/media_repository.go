@@ -0,0 +1,223 @@
+package repositories
+
+import (
+	"context"
+	"hinsun-backend/adapters/shared/models"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/media"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// referencedTables is the table holding the rows each reference type points at
+var referencedTables = map[media.ReferenceType]string{
+	media.ReferenceProjectCover:  "projects",
+	media.ReferenceProject:       "projects",
+	media.ReferenceAccountAvatar: "accounts",
+	media.ReferenceBlog:          "blogs",
+}
+
+type mediaRepository struct {
+	db *gorm.DB
+}
+
+func NewMediaRepository(db *gorm.DB) media.MediaRepository {
+	return &mediaRepository{
+		db: db,
+	}
+}
+
+// Create rewrites a column of an existing upload to itself, so RETURNING yields the stored row;
+// a returned ID other than the new one means it already existed
+func (r *mediaRepository) Create(ctx context.Context, entity *media.MediaEntity) (*media.MediaEntity, bool, error) {
+	model := models.FromMediaEntity(entity)
+	onConflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "owner_id"}, {Name: "hash"}},
+		DoUpdates: clause.AssignmentColumns([]string{"hash"}),
+	}
+
+	err := gorm.G[models.MediaModel](withTx(ctx, r.db), onConflict, clause.Returning{}).Create(ctx, &model)
+	if err != nil {
+		return nil, false, failure.NewDatabaseFailure("Failed to save media in database").WithCause(err)
+	}
+
+	return model.ToEntity(), model.ID == entity.ID, nil
+}
+
+func (r *mediaRepository) FindByID(ctx context.Context, id string) (*media.MediaEntity, error) {
+	mediaModel, err := gorm.G[models.MediaModel](withTx(ctx, r.db)).Where("id = ?", id).First(ctx)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, failure.NewDatabaseFailure("Failed to retrieve media from database").WithCause(err)
+	}
+
+	return mediaModel.ToEntity(), nil
+}
+
+func (r *mediaRepository) FindByOwnerID(ctx context.Context, ownerID string) ([]*media.MediaEntity, error) {
+	mediaModels, err := gorm.G[models.MediaModel](withTx(ctx, r.db)).Where("owner_id = ?", ownerID).Order("created_at DESC").Find(ctx)
+	if err != nil {
+		return nil, failure.NewDatabaseFailure("Failed to retrieve media from database").WithCause(err)
+	}
+
+	return toMediaEntities(mediaModels), nil
+}
+
+func (r *mediaRepository) Delete(ctx context.Context, id string) (int, error) {
+	rowsAffected, err := gorm.G[models.MediaModel](withTx(ctx, r.db)).Where("id = ?", id).Delete(ctx)
+	if err != nil {
+		return 0, failure.NewDatabaseFailure("Failed to delete media from database").WithCause(err)
+	}
+
+	return rowsAffected, nil
+}
+
+func (r *mediaRepository) CountByHash(ctx context.Context, hash string) (int64, error) {
+	count, err := gorm.G[models.MediaModel](withTx(ctx, r.db)).Where("hash = ?", hash).Count(ctx, "*")
+	if err != nil {
+		return 0, failure.NewDatabaseFailure("Failed to count media in database").WithCause(err)
+	}
+
+	return count, nil
+}
+
+func (r *mediaRepository) IsReferenced(ctx context.Context, id string) (bool, error) {
+	count, err := gorm.G[models.MediaReferenceModel](withTx(ctx, r.db)).Where("media_id = ?", id).Count(ctx, "*")
+	if err != nil {
+		return false, failure.NewDatabaseFailure("Failed to count media references in database").WithCause(err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *mediaRepository) ReplaceReferences(ctx context.Context, referenceType media.ReferenceType, referenceID string, keys []string) error {
+	parsedReferenceID, err := uuid.Parse(referenceID)
+	if err != nil {
+		return failure.NewValidationFailure("invalid media reference ID")
+	}
+
+	err = withTx(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		_, err := gorm.G[models.MediaReferenceModel](tx).
+			Where("reference_type = ? AND reference_id = ?", string(referenceType), referenceID).
+			Delete(ctx)
+		if err != nil || len(keys) == 0 {
+			return err
+		}
+
+		mediaModels, err := gorm.G[models.MediaModel](tx).Select("id").Where("key IN ?", keys).Find(ctx)
+		if err != nil || len(mediaModels) == 0 {
+			return err
+		}
+
+		references := make([]models.MediaReferenceModel, 0, len(mediaModels))
+		for _, mediaModel := range mediaModels {
+			references = append(references, models.MediaReferenceModel{
+				MediaID:       mediaModel.ID,
+				ReferenceType: string(referenceType),
+				ReferenceID:   parsedReferenceID,
+			})
+		}
+
+		return gorm.G[models.MediaReferenceModel](tx, clause.OnConflict{DoNothing: true}).CreateInBatches(ctx, &references, len(references))
+	})
+
+	if err != nil {
+		return failure.NewDatabaseFailure("Failed to save media references in database").WithCause(err)
+	}
+
+	return nil
+}
+
+func (r *mediaRepository) PruneReferences(ctx context.Context) (int, error) {
+	pruned := 0
+	for referenceType, table := range referencedTables {
+		rowsAffected, err := gorm.G[models.MediaReferenceModel](withTx(ctx, r.db)).
+			Where("reference_type = ? AND NOT EXISTS (SELECT 1 FROM "+table+" WHERE "+table+".id = media_references.reference_id)", string(referenceType)).
+			Delete(ctx)
+		if err != nil {
+			return pruned, failure.NewDatabaseFailure("Failed to prune media references in database").WithCause(err)
+		}
+
+		pruned += rowsAffected
+	}
+
+	return pruned, nil
+}
+
+func (r *mediaRepository) FindUnreferenced(ctx context.Context, createdBefore time.Time, limit int) ([]*media.MediaEntity, error) {
+	mediaModels, err := gorm.G[models.MediaModel](withTx(ctx, r.db)).
+		Where("created_at < ? AND NOT EXISTS (SELECT 1 FROM media_references WHERE media_references.media_id = media.id)", createdBefore.Unix()).
+		Order("created_at").
+		Limit(limit).
+		Find(ctx)
+	if err != nil {
+		return nil, failure.NewDatabaseFailure("Failed to retrieve unreferenced media from database").WithCause(err)
+	}
+
+	return toMediaEntities(mediaModels), nil
+}
+
+func (r *mediaRepository) FindByKeys(ctx context.Context, keys []string) ([]*media.MediaEntity, error) {
+	if len(keys) == 0 {
+		return []*media.MediaEntity{}, nil
+	}
+
+	mediaModels, err := gorm.G[models.MediaModel](withTx(ctx, r.db)).Where("key IN ?", keys).Find(ctx)
+	if err != nil {
+		return nil, failure.NewDatabaseFailure("Failed to retrieve media from database").WithCause(err)
+	}
+
+	return toMediaEntities(mediaModels), nil
+}
+
+func (r *mediaRepository) FindProcessedByHash(ctx context.Context, hash string) (*media.MediaEntity, error) {
+	mediaModel, err := gorm.G[models.MediaModel](withTx(ctx, r.db)).Where("hash = ? AND processed_at IS NOT NULL", hash).First(ctx)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, failure.NewDatabaseFailure("Failed to retrieve media from database").WithCause(err)
+	}
+
+	return mediaModel.ToEntity(), nil
+}
+
+func (r *mediaRepository) FindUnprocessed(ctx context.Context, limit int) ([]*media.MediaEntity, error) {
+	mediaModels, err := gorm.G[models.MediaModel](withTx(ctx, r.db)).
+		Where("processed_at IS NULL").
+		Order("created_at").
+		Limit(limit).
+		Find(ctx)
+	if err != nil {
+		return nil, failure.NewDatabaseFailure("Failed to retrieve unprocessed media from database").WithCause(err)
+	}
+
+	return toMediaEntities(mediaModels), nil
+}
+
+func (r *mediaRepository) SaveVariants(ctx context.Context, hash string, variantKeys map[string]string, processedAt int64) error {
+	_, err := gorm.G[models.MediaModel](withTx(ctx, r.db)).
+		Where("hash = ?", hash).
+		Updates(ctx, models.MediaModel{Variants: models.VariantsJSON(variantKeys), ProcessedAt: &processedAt})
+	if err != nil {
+		return failure.NewDatabaseFailure("Failed to save media variants in database").WithCause(err)
+	}
+
+	return nil
+}
+
+func toMediaEntities(mediaModels []models.MediaModel) []*media.MediaEntity {
+	mediaEntities := make([]*media.MediaEntity, 0, len(mediaModels))
+	for _, mediaModel := range mediaModels {
+		mediaEntities = append(mediaEntities, mediaModel.ToEntity())
+	}
+
+	return mediaEntities
+}
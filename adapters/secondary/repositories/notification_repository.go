@@ -2,23 +2,111 @@ package repositories
 
 import (
 	"context"
+	"hinsun-backend/adapters/shared/models"
+	"hinsun-backend/internal/core/failure"
 	"hinsun-backend/internal/domain/notification"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type notificationRepository struct {
 	db *gorm.DB
 }
 
-func NewNotificationRepository(db *gorm.DB) *notificationRepository {
+func NewNotificationRepository(db *gorm.DB) notification.NotificationRepository {
 	return &notificationRepository{
 		db: db,
 	}
 }
 
-// Implement NotificationRepository methods here
 func (r *notificationRepository) Create(ctx context.Context, notification *notification.NotificationEntity) error {
-	// Implementation goes here
+	model := models.FromNotificationEntity(notification)
+	err := gorm.G[models.NotificationModel](withTx(ctx, r.db)).Create(ctx, &model)
+	if err != nil {
+		return failure.NewDatabaseFailure("Failed to create notification in database").WithCause(err)
+	}
+
+	return nil
+}
+
+func (r *notificationRepository) FindByID(ctx context.Context, id string) (*notification.NotificationEntity, error) {
+	notificationModel, err := gorm.G[models.NotificationModel](withTx(ctx, r.db)).Where("id = ?", id).First(ctx)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, failure.NewDatabaseFailure("Failed to retrieve notification from database").WithCause(err)
+	}
+
+	return notificationModel.ToEntity(), nil
+}
+
+func (r *notificationRepository) FindByAccountID(ctx context.Context, accountID string, limit int) ([]*notification.NotificationEntity, error) {
+	notificationModels, err := gorm.G[models.NotificationModel](withTx(ctx, r.db)).
+		Where("account_id = ?", accountID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(ctx)
+	if err != nil {
+		return nil, failure.NewDatabaseFailure("Failed to retrieve notifications from database").WithCause(err)
+	}
+
+	notificationEntities := make([]*notification.NotificationEntity, 0, len(notificationModels))
+	for _, notificationModel := range notificationModels {
+		notificationEntities = append(notificationEntities, notificationModel.ToEntity())
+	}
+
+	return notificationEntities, nil
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, notification *notification.NotificationEntity) (int, error) {
+	rowsAffected, err := gorm.G[models.NotificationModel](withTx(ctx, r.db)).
+		Select("is_read", "updated_at").
+		Where("id = ?", notification.ID).
+		Updates(ctx, models.FromNotificationEntity(notification))
+	if err != nil {
+		return 0, failure.NewDatabaseFailure("Failed to update notification in database").WithCause(err)
+	}
+
+	return rowsAffected, nil
+}
+
+type preferenceRepository struct {
+	db *gorm.DB
+}
+
+func NewPreferenceRepository(db *gorm.DB) notification.PreferenceRepository {
+	return &preferenceRepository{
+		db: db,
+	}
+}
+
+func (r *preferenceRepository) FindByAccountID(ctx context.Context, accountID string) (*notification.PreferenceEntity, error) {
+	preferenceModel, err := gorm.G[models.NotificationPreferenceModel](withTx(ctx, r.db)).Where("account_id = ?", accountID).First(ctx)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, failure.NewDatabaseFailure("Failed to retrieve notification preferences from database").WithCause(err)
+	}
+
+	return preferenceModel.ToEntity(), nil
+}
+
+func (r *preferenceRepository) Save(ctx context.Context, preference *notification.PreferenceEntity) error {
+	model := models.FromPreferenceEntity(preference)
+	onConflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account_id"}},
+		UpdateAll: true,
+	}
+
+	err := gorm.G[models.NotificationPreferenceModel](withTx(ctx, r.db), onConflict).Create(ctx, &model)
+	if err != nil {
+		return failure.NewDatabaseFailure("Failed to save notification preferences in database").WithCause(err)
+	}
+
 	return nil
 }
@@ -5,10 +5,18 @@ import (
 	"hinsun-backend/adapters/shared/models"
 	"hinsun-backend/internal/core/failure"
 	"hinsun-backend/internal/domain/project"
+	"hinsun-backend/internal/domain/values"
 
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
+// projectColumns are written on update, so the cover may be cleared. The featured order and
+// the repository metadata have writes of their own.
+var projectColumns = []string{
+	"languages", "names", "descriptions", "github", "cover", "tags", "markdowns", "updated_at", "version",
+}
+
 type projectRepository struct {
 	db *gorm.DB
 }
@@ -22,7 +30,7 @@ func NewProjectRepository(db *gorm.DB) project.ProjectRepository {
 
 func (r *projectRepository) Create(ctx context.Context, project *project.ProjectEntity) error {
 	model := models.FromProjectEntity(project)
-	err := gorm.G[models.ProjectModel](r.db).Create(ctx, &model)
+	err := gorm.G[models.ProjectModel](withTx(ctx, r.db)).Create(ctx, &model)
 	if err != nil {
 		return failure.NewDatabaseFailure("Failed to create project in database").WithCause(err)
 	}
@@ -31,16 +39,27 @@ func (r *projectRepository) Create(ctx context.Context, project *project.Project
 }
 
 func (r *projectRepository) Update(ctx context.Context, project *project.ProjectEntity) (int, error) {
-	rowsAffected, err := gorm.G[models.ProjectModel](r.db).Where("id = ?", project.ID).Updates(ctx, models.FromProjectEntity(project))
+	model := models.FromProjectEntity(project)
+	model.Version = project.Version + 1
+
+	rowsAffected, err := updateVersioned(ctx, withTx(ctx, r.db), project.ID, project.Version, model, projectColumns...)
 	if err != nil {
+		if failure.Is(err, failure.ConflictFailure) {
+			return 0, err
+		}
+
 		return 0, failure.NewDatabaseFailure("Failed to update project in database").WithCause(err)
 	}
 
+	if rowsAffected > 0 {
+		project.Version = model.Version
+	}
+
 	return rowsAffected, nil
 }
 
 func (r *projectRepository) Delete(ctx context.Context, id string) (int, error) {
-	rowAffected, err := gorm.G[models.ProjectModel](r.db).Where("id = ?", id).Delete(ctx)
+	rowAffected, err := gorm.G[models.ProjectModel](withTx(ctx, r.db)).Where("id = ?", id).Delete(ctx)
 	if err != nil {
 		return 0, failure.NewDatabaseFailure("Failed to delete project from database").WithCause(err)
 	}
@@ -49,7 +68,7 @@ func (r *projectRepository) Delete(ctx context.Context, id string) (int, error)
 }
 
 func (r *projectRepository) DeleteMany(ctx context.Context, ids []string) (int, error) {
-	rowAffected, err := gorm.G[models.ProjectModel](r.db).Where("id IN ?", ids).Delete(ctx)
+	rowAffected, err := gorm.G[models.ProjectModel](withTx(ctx, r.db)).Where("id IN ?", ids).Delete(ctx)
 	if err != nil {
 		return 0, failure.NewDatabaseFailure("Failed to delete projects from database").WithCause(err)
 	}
@@ -58,7 +77,7 @@ func (r *projectRepository) DeleteMany(ctx context.Context, ids []string) (int,
 }
 
 func (r *projectRepository) FindByID(ctx context.Context, id string) (*project.ProjectEntity, error) {
-	project, err := gorm.G[models.ProjectModel](r.db).Where("id = ?", id).First(ctx)
+	project, err := gorm.G[models.ProjectModel](withTx(ctx, r.db)).Where("id = ?", id).First(ctx)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -70,7 +89,7 @@ func (r *projectRepository) FindByID(ctx context.Context, id string) (*project.P
 }
 
 func (r *projectRepository) FindAll(ctx context.Context) ([]*project.ProjectEntity, error) {
-	projects, err := gorm.G[models.ProjectModel](r.db).Find(ctx)
+	projects, err := gorm.G[models.ProjectModel](withTx(ctx, r.db)).Find(ctx)
 	if err != nil {
 		return nil, failure.NewDatabaseFailure("Failed to retrieve projects from database").WithCause(err)
 	}
@@ -83,8 +102,44 @@ func (r *projectRepository) FindAll(ctx context.Context) ([]*project.ProjectEnti
 	return projectEntities, nil
 }
 
-func (r *projectRepository) FindByName(ctx context.Context, name string) (*project.ProjectEntity, error) {
-	project, err := gorm.G[models.ProjectModel](r.db).Where("name = ?", name).First(ctx)
+func (r *projectRepository) FindPage(ctx context.Context, filter project.ProjectFilter) ([]*project.ProjectEntity, int64, error) {
+	query := withTx(ctx, r.db).WithContext(ctx).Model(&models.ProjectModel{})
+	if filter.Tag != "" {
+		query = query.Where("tags @> ?", pq.StringArray{filter.Tag})
+	}
+
+	if filter.FeaturedOnly {
+		query = query.Where("featured = ?", true)
+	}
+
+	// Counting must not leave its clauses on the query the page is read with
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, failure.NewDatabaseFailure("Failed to count projects in database").WithCause(err)
+	}
+
+	query = query.Order("featured DESC, featured_order, created_at DESC, id").Offset(filter.Offset)
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var projects []models.ProjectModel
+	if err := query.Find(&projects).Error; err != nil {
+		return nil, 0, failure.NewDatabaseFailure("Failed to retrieve projects from database").WithCause(err)
+	}
+
+	projectEntities := make([]*project.ProjectEntity, 0, len(projects))
+	for _, projectModel := range projects {
+		projectEntities = append(projectEntities, projectModel.ToEntity())
+	}
+
+	return projectEntities, total, nil
+}
+
+func (r *projectRepository) FindByName(ctx context.Context, language values.MarkdownLanguageCode, name string) (*project.ProjectEntity, error) {
+	project, err := gorm.G[models.ProjectModel](withTx(ctx, r.db)).Where("names ->> ? = ?", string(language), name).First(ctx)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -95,3 +150,94 @@ func (r *projectRepository) FindByName(ctx context.Context, name string) (*proje
 
 	return project.ToEntity(), nil
 }
+
+func (r *projectRepository) SaveRepositoryMetadata(ctx context.Context, id string, metadata *project.RepositoryMetadata, bumpVersion bool) (int, error) {
+	query := withTx(ctx, r.db).WithContext(ctx).Model(&models.ProjectModel{}).Where("id = ?", id)
+
+	var result *gorm.DB
+	if bumpVersion {
+		result = query.Updates(map[string]any{
+			"repository_metadata": models.RepositoryMetadataJSON(metadata),
+			"version":             gorm.Expr("version + 1"),
+		})
+	} else {
+		// Without a new version, updated_at is left alone too
+		result = query.UpdateColumn("repository_metadata", models.RepositoryMetadataJSON(metadata))
+	}
+
+	if result.Error != nil {
+		return 0, failure.NewDatabaseFailure("Failed to save project repository metadata in database").WithCause(result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+func (r *projectRepository) SetFeatured(ctx context.Context, ids []string) (int, error) {
+	rowsAffected := 0
+	err := withTx(ctx, r.db).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Unfeature the projects left out of the list
+		unfeatured := tx.Model(&models.ProjectModel{}).Where("featured = ?", true)
+		if len(ids) > 0 {
+			unfeatured = unfeatured.Where("id NOT IN ?", ids)
+		}
+
+		result := unfeatured.Updates(map[string]any{
+			"featured":       false,
+			"featured_order": 0,
+			"version":        gorm.Expr("version + 1"),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		rowsAffected += int(result.RowsAffected)
+
+		// 2. Number the listed ones
+		for featuredOrder, id := range ids {
+			result := tx.Model(&models.ProjectModel{}).Where("id = ?", id).Updates(map[string]any{
+				"featured":       true,
+				"featured_order": featuredOrder,
+				"version":        gorm.Expr("version + 1"),
+			})
+			if result.Error != nil {
+				return result.Error
+			}
+
+			rowsAffected += int(result.RowsAffected)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, failure.NewDatabaseFailure("Failed to update featured projects in database").WithCause(err)
+	}
+
+	return rowsAffected, nil
+}
+
+func (r *projectRepository) CountByTag(ctx context.Context, tag string) (int64, error) {
+	var count int64
+	err := withTx(ctx, r.db).WithContext(ctx).Unscoped().Model(&models.ProjectModel{}).
+		Where("tags @> ?", pq.StringArray{tag}).
+		Count(&count).Error
+	if err != nil {
+		return 0, failure.NewDatabaseFailure("Failed to count projects by tag in database").WithCause(err)
+	}
+
+	return count, nil
+}
+
+func (r *projectRepository) ReplaceTag(ctx context.Context, oldTag, newTag string) (int, error) {
+	result := withTx(ctx, r.db).WithContext(ctx).Unscoped().Model(&models.ProjectModel{}).
+		Where("tags @> ?", pq.StringArray{oldTag}).
+		Updates(map[string]any{
+			"tags":    gorm.Expr("array_replace(tags, ?, ?)", oldTag, newTag),
+			"version": gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return 0, failure.NewDatabaseFailure("Failed to rename project tag in database").WithCause(result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
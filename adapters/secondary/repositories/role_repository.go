@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"context"
+	"hinsun-backend/adapters/shared/models"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/role"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type roleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) role.RoleRepository {
+	return &roleRepository{
+		db: db,
+	}
+}
+
+func (r *roleRepository) Create(ctx context.Context, role *role.RoleEntity) error {
+	model := models.FromRoleEntity(role)
+	err := gorm.G[models.RoleModel](withTx(ctx, r.db)).Create(ctx, &model)
+	if err != nil {
+		return failure.NewDatabaseFailure("Failed to create role in database").WithCause(err)
+	}
+
+	return nil
+}
+
+// Update writes description and permissions explicitly, so clearing them is persisted too
+func (r *roleRepository) Update(ctx context.Context, role *role.RoleEntity) (int, error) {
+	rowsAffected, err := gorm.G[models.RoleModel](withTx(ctx, r.db)).
+		Select("description", "permissions", "updated_at").
+		Where("id = ?", role.ID).
+		Updates(ctx, models.FromRoleEntity(role))
+	if err != nil {
+		return 0, failure.NewDatabaseFailure("Failed to update role in database").WithCause(err)
+	}
+
+	return rowsAffected, nil
+}
+
+func (r *roleRepository) Delete(ctx context.Context, id string) (int, error) {
+	rowAffected, err := gorm.G[models.RoleModel](withTx(ctx, r.db)).Where("id = ?", id).Delete(ctx)
+	if err != nil {
+		return 0, failure.NewDatabaseFailure("Failed to delete role from database").WithCause(err)
+	}
+
+	return rowAffected, nil
+}
+
+func (r *roleRepository) FindByID(ctx context.Context, id string) (*role.RoleEntity, error) {
+	roleModel, err := gorm.G[models.RoleModel](withTx(ctx, r.db)).Where("id = ?", id).First(ctx)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, failure.NewDatabaseFailure("Failed to retrieve role from database").WithCause(err)
+	}
+
+	return roleModel.ToEntity(), nil
+}
+
+func (r *roleRepository) FindByName(ctx context.Context, name string) (*role.RoleEntity, error) {
+	roleModel, err := gorm.G[models.RoleModel](withTx(ctx, r.db)).Where("name = ?", name).First(ctx)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, failure.NewDatabaseFailure("Failed to retrieve role from database").WithCause(err)
+	}
+
+	return roleModel.ToEntity(), nil
+}
+
+func (r *roleRepository) FindByNames(ctx context.Context, names []string) ([]*role.RoleEntity, error) {
+	roleModels, err := gorm.G[models.RoleModel](withTx(ctx, r.db)).Where("name IN ?", names).Find(ctx)
+	if err != nil {
+		return nil, failure.NewDatabaseFailure("Failed to retrieve roles from database").WithCause(err)
+	}
+
+	return toRoleEntities(roleModels), nil
+}
+
+func (r *roleRepository) FindAll(ctx context.Context) ([]*role.RoleEntity, error) {
+	roleModels, err := gorm.G[models.RoleModel](withTx(ctx, r.db)).Order("name ASC").Find(ctx)
+	if err != nil {
+		return nil, failure.NewDatabaseFailure("Failed to retrieve roles from database").WithCause(err)
+	}
+
+	return toRoleEntities(roleModels), nil
+}
+
+func (r *roleRepository) FindByAccountID(ctx context.Context, accountID string) ([]*role.RoleEntity, error) {
+	assignedRoleIDs := withTx(ctx, r.db).Model(&models.AccountRoleModel{}).Select("role_id").Where("account_id = ?", accountID)
+
+	roleModels, err := gorm.G[models.RoleModel](withTx(ctx, r.db)).Where("id IN (?)", assignedRoleIDs).Order("name ASC").Find(ctx)
+	if err != nil {
+		return nil, failure.NewDatabaseFailure("Failed to retrieve account roles from database").WithCause(err)
+	}
+
+	return toRoleEntities(roleModels), nil
+}
+
+func (r *roleRepository) ReplaceAccountRoles(ctx context.Context, accountID string, roleIDs []string) error {
+	accountUUID, err := uuid.Parse(accountID)
+	if err != nil {
+		return failure.NewValidationFailure("invalid account ID")
+	}
+
+	err = withTx(ctx, r.db).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := gorm.G[models.AccountRoleModel](tx).Where("account_id = ?", accountUUID).Delete(ctx); err != nil {
+			return err
+		}
+
+		if len(roleIDs) == 0 {
+			return nil
+		}
+
+		assignments := make([]models.AccountRoleModel, 0, len(roleIDs))
+		for _, roleID := range roleIDs {
+			roleUUID, err := uuid.Parse(roleID)
+			if err != nil {
+				return err
+			}
+
+			assignments = append(assignments, models.AccountRoleModel{AccountID: accountUUID, RoleID: roleUUID})
+		}
+
+		return gorm.G[models.AccountRoleModel](tx).CreateInBatches(ctx, &assignments, len(assignments))
+	})
+
+	if err != nil {
+		return failure.NewDatabaseFailure("Failed to assign roles to account").WithCause(err)
+	}
+
+	return nil
+}
+
+func toRoleEntities(roleModels []models.RoleModel) []*role.RoleEntity {
+	roleEntities := make([]*role.RoleEntity, 0, len(roleModels))
+	for _, roleModel := range roleModels {
+		roleEntities = append(roleEntities, roleModel.ToEntity())
+	}
+
+	return roleEntities
+}
@@ -0,0 +1,176 @@
+package repositories
+
+import (
+	"context"
+	"hinsun-backend/adapters/shared/models"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/subscription"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type followRepository struct {
+	db *gorm.DB
+}
+
+func NewFollowRepository(db *gorm.DB) subscription.FollowRepository {
+	return &followRepository{
+		db: db,
+	}
+}
+
+// Create rewrites a column of an existing follow to itself, so RETURNING yields the stored row
+// whether or not it was inserted
+func (r *followRepository) Create(ctx context.Context, follow *subscription.FollowEntity) (*subscription.FollowEntity, error) {
+	model := models.FromFollowEntity(follow)
+	onConflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account_id"}, {Name: "target_type"}, {Name: "target_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"target_id"}),
+	}
+
+	err := gorm.G[models.FollowModel](withTx(ctx, r.db), onConflict, clause.Returning{}).Create(ctx, &model)
+	if err != nil {
+		return nil, failure.NewDatabaseFailure("Failed to save follow in database").WithCause(err)
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (r *followRepository) FindByAccountID(ctx context.Context, accountID string) ([]*subscription.FollowEntity, error) {
+	followModels, err := gorm.G[models.FollowModel](withTx(ctx, r.db)).Where("account_id = ?", accountID).Order("created_at DESC").Find(ctx)
+	if err != nil {
+		return nil, failure.NewDatabaseFailure("Failed to retrieve follows from database").WithCause(err)
+	}
+
+	followEntities := make([]*subscription.FollowEntity, 0, len(followModels))
+	for _, followModel := range followModels {
+		followEntities = append(followEntities, followModel.ToEntity())
+	}
+
+	return followEntities, nil
+}
+
+func (r *followRepository) DeleteByID(ctx context.Context, accountID, id string) (int, error) {
+	rowAffected, err := gorm.G[models.FollowModel](withTx(ctx, r.db)).Where("id = ? AND account_id = ?", id, accountID).Delete(ctx)
+	if err != nil {
+		return 0, failure.NewDatabaseFailure("Failed to delete follow from database").WithCause(err)
+	}
+
+	return rowAffected, nil
+}
+
+func (r *followRepository) FindFollowerIDs(ctx context.Context, targets []subscription.Target) ([]string, error) {
+	query, args := targetsCondition(targets)
+	followModels, err := gorm.G[models.FollowModel](withTx(ctx, r.db)).Select("DISTINCT account_id").Where(query, args...).Find(ctx)
+	if err != nil {
+		return nil, failure.NewDatabaseFailure("Failed to retrieve followers from database").WithCause(err)
+	}
+
+	accountIDs := make([]string, 0, len(followModels))
+	for _, followModel := range followModels {
+		accountIDs = append(accountIDs, followModel.AccountID.String())
+	}
+
+	return accountIDs, nil
+}
+
+type emailSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewEmailSubscriptionRepository(db *gorm.DB) subscription.EmailSubscriptionRepository {
+	return &emailSubscriptionRepository{
+		db: db,
+	}
+}
+
+// Upsert issues a new token to a pending subscription and leaves a confirmed one as it is
+func (r *emailSubscriptionRepository) Upsert(ctx context.Context, emailSubscription *subscription.EmailSubscriptionEntity) (*subscription.EmailSubscriptionEntity, error) {
+	model := models.FromEmailSubscriptionEntity(emailSubscription)
+	onConflict := clause.OnConflict{
+		Columns: []clause.Column{{Name: "email"}, {Name: "target_type"}, {Name: "target_id"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"token": gorm.Expr("CASE WHEN email_subscriptions.confirmed_at IS NULL THEN excluded.token ELSE email_subscriptions.token END"),
+		}),
+	}
+
+	err := gorm.G[models.EmailSubscriptionModel](withTx(ctx, r.db), onConflict, clause.Returning{}).Create(ctx, &model)
+	if err != nil {
+		return nil, failure.NewDatabaseFailure("Failed to save email subscription in database").WithCause(err)
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (r *emailSubscriptionRepository) FindByToken(ctx context.Context, token string) (*subscription.EmailSubscriptionEntity, error) {
+	subscriptionModel, err := gorm.G[models.EmailSubscriptionModel](withTx(ctx, r.db)).Where("token = ?", token).First(ctx)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, failure.NewDatabaseFailure("Failed to retrieve email subscription from database").WithCause(err)
+	}
+
+	return subscriptionModel.ToEntity(), nil
+}
+
+func (r *emailSubscriptionRepository) Update(ctx context.Context, emailSubscription *subscription.EmailSubscriptionEntity) (int, error) {
+	rowsAffected, err := gorm.G[models.EmailSubscriptionModel](withTx(ctx, r.db)).
+		Select("confirmed_at").
+		Where("id = ?", emailSubscription.ID).
+		Updates(ctx, models.FromEmailSubscriptionEntity(emailSubscription))
+	if err != nil {
+		return 0, failure.NewDatabaseFailure("Failed to update email subscription in database").WithCause(err)
+	}
+
+	return rowsAffected, nil
+}
+
+func (r *emailSubscriptionRepository) DeleteByEmail(ctx context.Context, email string) (int, error) {
+	rowAffected, err := gorm.G[models.EmailSubscriptionModel](withTx(ctx, r.db)).Where("email = ?", email).Delete(ctx)
+	if err != nil {
+		return 0, failure.NewDatabaseFailure("Failed to delete email subscriptions from database").WithCause(err)
+	}
+
+	return rowAffected, nil
+}
+
+func (r *emailSubscriptionRepository) FindConfirmed(ctx context.Context, targets []subscription.Target) ([]*subscription.EmailSubscriptionEntity, error) {
+	query, args := targetsCondition(targets)
+	subscriptionModels, err := gorm.G[models.EmailSubscriptionModel](withTx(ctx, r.db)).
+		Where("confirmed_at IS NOT NULL").
+		Where(query, args...).
+		Order("created_at").
+		Find(ctx)
+	if err != nil {
+		return nil, failure.NewDatabaseFailure("Failed to retrieve email subscriptions from database").WithCause(err)
+	}
+
+	subscriptionEntities := make([]*subscription.EmailSubscriptionEntity, 0, len(subscriptionModels))
+	for _, subscriptionModel := range subscriptionModels {
+		subscriptionEntities = append(subscriptionEntities, subscriptionModel.ToEntity())
+	}
+
+	return subscriptionEntities, nil
+}
+
+// targetsCondition matches rows whose (target_type, target_id) is one of the targets
+func targetsCondition(targets []subscription.Target) (string, []any) {
+	idsByType := make(map[subscription.TargetType][]uuid.UUID)
+	for _, target := range targets {
+		idsByType[target.Type] = append(idsByType[target.Type], target.ID)
+	}
+
+	conditions := make([]string, 0, len(idsByType))
+	args := make([]any, 0, len(idsByType)*2)
+	for targetType, ids := range idsByType {
+		conditions = append(conditions, "(target_type = ? AND target_id IN ?)")
+		args = append(args, string(targetType), ids)
+	}
+
+	return "(" + strings.Join(conditions, " OR ") + ")", args
+}
@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"context"
+	"hinsun-backend/adapters/shared/models"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/tag"
+
+	"gorm.io/gorm"
+)
+
+// tagSelection reads a tag with the number of live projects using it
+const tagSelection = "tags.*, (SELECT COUNT(*) FROM projects p WHERE p.tags @> ARRAY[tags.name]::text[] AND p.deleted_at IS NULL) AS num_projects"
+
+// tagColumns are written on update
+var tagColumns = []string{"name", "updated_at", "version"}
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) tag.TagRepository {
+	return &tagRepository{
+		db: db,
+	}
+}
+
+func (r *tagRepository) Create(ctx context.Context, tag *tag.TagEntity) error {
+	model := models.FromTagEntity(tag)
+	err := gorm.G[models.TagModel](withTx(ctx, r.db)).Create(ctx, &model)
+	if err != nil {
+		return failure.NewDatabaseFailure("Failed to create tag in database").WithCause(err)
+	}
+
+	return nil
+}
+
+func (r *tagRepository) Update(ctx context.Context, tag *tag.TagEntity) (int, error) {
+	model := models.FromTagEntity(tag)
+	model.Version = tag.Version + 1
+
+	rowsAffected, err := updateVersioned(ctx, withTx(ctx, r.db), tag.ID, tag.Version, model, tagColumns...)
+	if err != nil {
+		if failure.Is(err, failure.ConflictFailure) {
+			return 0, err
+		}
+
+		return 0, failure.NewDatabaseFailure("Failed to update tag in database").WithCause(err)
+	}
+
+	if rowsAffected > 0 {
+		tag.Version = model.Version
+	}
+
+	return rowsAffected, nil
+}
+
+func (r *tagRepository) Delete(ctx context.Context, id string) (int, error) {
+	rowAffected, err := gorm.G[models.TagModel](withTx(ctx, r.db)).Where("id = ?", id).Delete(ctx)
+	if err != nil {
+		return 0, failure.NewDatabaseFailure("Failed to delete tag from database").WithCause(err)
+	}
+
+	return rowAffected, nil
+}
+
+func (r *tagRepository) DeleteMany(ctx context.Context, ids []string) (int, error) {
+	rowAffected, err := gorm.G[models.TagModel](withTx(ctx, r.db)).Where("id IN ?", ids).Delete(ctx)
+	if err != nil {
+		return 0, failure.NewDatabaseFailure("Failed to delete tags from database").WithCause(err)
+	}
+
+	return rowAffected, nil
+}
+
+func (r *tagRepository) FindByID(ctx context.Context, id string) (*tag.TagEntity, error) {
+	tagModel, err := gorm.G[models.TagModel](withTx(ctx, r.db)).Select(tagSelection).Where("id = ?", id).First(ctx)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, failure.NewDatabaseFailure("Failed to retrieve tag from database").WithCause(err)
+	}
+
+	return tagModel.ToEntity(), nil
+}
+
+func (r *tagRepository) FindByName(ctx context.Context, name string) (*tag.TagEntity, error) {
+	tagModel, err := gorm.G[models.TagModel](withTx(ctx, r.db)).Select(tagSelection).Where("name = ?", name).First(ctx)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, failure.NewDatabaseFailure("Failed to retrieve tag by name from database").WithCause(err)
+	}
+
+	return tagModel.ToEntity(), nil
+}
+
+func (r *tagRepository) FindByNames(ctx context.Context, names []string) ([]*tag.TagEntity, error) {
+	if len(names) == 0 {
+		return []*tag.TagEntity{}, nil
+	}
+
+	tags, err := gorm.G[models.TagModel](withTx(ctx, r.db)).Select(tagSelection).Where("name IN ?", names).Find(ctx)
+	if err != nil {
+		return nil, failure.NewDatabaseFailure("Failed to retrieve tags by name from database").WithCause(err)
+	}
+
+	tagEntities := make([]*tag.TagEntity, 0, len(tags))
+	for _, tagModel := range tags {
+		tagEntities = append(tagEntities, tagModel.ToEntity())
+	}
+
+	return tagEntities, nil
+}
+
+func (r *tagRepository) FindAll(ctx context.Context, byUsage bool) ([]*tag.TagEntity, error) {
+	order := "name"
+	if byUsage {
+		order = "num_projects DESC, name"
+	}
+
+	tags, err := gorm.G[models.TagModel](withTx(ctx, r.db)).Select(tagSelection).Order(order).Find(ctx)
+	if err != nil {
+		return nil, failure.NewDatabaseFailure("Failed to retrieve tags from database").WithCause(err)
+	}
+
+	var tagEntities []*tag.TagEntity
+	for _, tagModel := range tags {
+		tagEntities = append(tagEntities, tagModel.ToEntity())
+	}
+
+	return tagEntities, nil
+}
@@ -0,0 +1,165 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hinsun-backend/adapters/shared/models"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/trash"
+
+	"gorm.io/gorm"
+)
+
+const trashLabelMaxLength = 80
+
+type trashRepository struct {
+	db *gorm.DB
+}
+
+func NewTrashRepository(db *gorm.DB) trash.TrashRepository {
+	return &trashRepository{
+		db: db,
+	}
+}
+
+func (r *trashRepository) FindDeleted(ctx context.Context, resource trash.Resource) ([]*trash.TrashItemEntity, error) {
+	switch resource {
+	case trash.AccountResource:
+		return findDeleted(ctx, withTx(ctx, r.db), resource, func(m models.AccountModel) (string, string, int64) {
+			return m.ID.String(), fmt.Sprintf("%s <%s>", m.Name, m.Email), m.DeletedAt.Int64
+		})
+	case trash.BlogResource:
+		return findDeleted(ctx, withTx(ctx, r.db), resource, func(m models.BlogModel) (string, string, int64) {
+			return m.ID.String(), m.Slug, m.DeletedAt.Int64
+		})
+	case trash.CommentResource:
+		return findDeleted(ctx, withTx(ctx, r.db), resource, func(m models.CommentModel) (string, string, int64) {
+			return m.ID.String(), truncateLabel(m.Content), m.DeletedAt.Int64
+		})
+	case trash.CategoryResource:
+		return findDeleted(ctx, withTx(ctx, r.db), resource, func(m models.CategoryModel) (string, string, int64) {
+			return m.ID.String(), m.Name, m.DeletedAt.Int64
+		})
+	case trash.ExperienceResource:
+		return findDeleted(ctx, withTx(ctx, r.db), resource, func(m models.ExperienceModel) (string, string, int64) {
+			e := m.ToEntity()
+			return m.ID.String(), fmt.Sprintf("%s at %s", e.Positions.Pick(e.Languages...), e.Companies.Pick(e.Languages...)), m.DeletedAt.Int64
+		})
+	case trash.ProjectResource:
+		return findDeleted(ctx, withTx(ctx, r.db), resource, func(m models.ProjectModel) (string, string, int64) {
+			p := m.ToEntity()
+			return m.ID.String(), p.Names.Pick(p.Languages...), m.DeletedAt.Int64
+		})
+	case trash.TagResource:
+		return findDeleted(ctx, withTx(ctx, r.db), resource, func(m models.TagModel) (string, string, int64) {
+			return m.ID.String(), m.Name, m.DeletedAt.Int64
+		})
+	}
+
+	return nil, failure.NewValidationFailure(fmt.Sprintf("Invalid trash resource: %s", resource))
+}
+
+func (r *trashRepository) Restore(ctx context.Context, resource trash.Resource, id string) (int, error) {
+	switch resource {
+	case trash.AccountResource:
+		return restore[models.AccountModel](ctx, withTx(ctx, r.db), id)
+	case trash.BlogResource:
+		return restore[models.BlogModel](ctx, withTx(ctx, r.db), id)
+	case trash.CommentResource:
+		return restore[models.CommentModel](ctx, withTx(ctx, r.db), id)
+	case trash.CategoryResource:
+		return restore[models.CategoryModel](ctx, withTx(ctx, r.db), id)
+	case trash.ExperienceResource:
+		return restore[models.ExperienceModel](ctx, withTx(ctx, r.db), id)
+	case trash.ProjectResource:
+		return restore[models.ProjectModel](ctx, withTx(ctx, r.db), id)
+	case trash.TagResource:
+		return restore[models.TagModel](ctx, withTx(ctx, r.db), id)
+	}
+
+	return 0, failure.NewValidationFailure(fmt.Sprintf("Invalid trash resource: %s", resource))
+}
+
+func (r *trashRepository) Purge(ctx context.Context, resource trash.Resource, deletedBefore int64) (int, error) {
+	switch resource {
+	case trash.AccountResource:
+		return purge[models.AccountModel](ctx, withTx(ctx, r.db), deletedBefore)
+	case trash.BlogResource:
+		return purge[models.BlogModel](ctx, withTx(ctx, r.db), deletedBefore)
+	case trash.CommentResource:
+		return purge[models.CommentModel](ctx, withTx(ctx, r.db), deletedBefore)
+	case trash.CategoryResource:
+		return purge[models.CategoryModel](ctx, withTx(ctx, r.db), deletedBefore)
+	case trash.ExperienceResource:
+		return purge[models.ExperienceModel](ctx, withTx(ctx, r.db), deletedBefore)
+	case trash.ProjectResource:
+		return purge[models.ProjectModel](ctx, withTx(ctx, r.db), deletedBefore)
+	case trash.TagResource:
+		return purge[models.TagModel](ctx, withTx(ctx, r.db), deletedBefore)
+	}
+
+	return 0, failure.NewValidationFailure(fmt.Sprintf("Invalid trash resource: %s", resource))
+}
+
+// findDeleted loads the soft-deleted rows of a model, describing each with the given mapper
+func findDeleted[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	resource trash.Resource,
+	describe func(T) (id string, label string, deletedAt int64),
+) ([]*trash.TrashItemEntity, error) {
+	rows, err := gorm.G[T](db.Unscoped()).Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(ctx)
+	if err != nil {
+		return nil, failure.NewDatabaseFailure(fmt.Sprintf("Failed to retrieve deleted %s from database", resource)).WithCause(err)
+	}
+
+	items := make([]*trash.TrashItemEntity, 0, len(rows))
+	for _, row := range rows {
+		id, label, deletedAt := describe(row)
+		items = append(items, &trash.TrashItemEntity{
+			ID:        id,
+			Resource:  resource,
+			Label:     label,
+			DeletedAt: deletedAt,
+		})
+	}
+
+	return items, nil
+}
+
+func restore[T any](ctx context.Context, db *gorm.DB, id string) (int, error) {
+	rowsAffected, err := gorm.G[T](db.Unscoped()).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update(ctx, "deleted_at", nil)
+	if err != nil {
+		// A live row may have taken the unique name, email or slug in the meantime
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return 0, failure.NewConflictFailure("Cannot restore item because another item with the same unique value exists")
+		}
+
+		return 0, failure.NewDatabaseFailure("Failed to restore item in database").WithCause(err)
+	}
+
+	return rowsAffected, nil
+}
+
+func purge[T any](ctx context.Context, db *gorm.DB, deletedBefore int64) (int, error) {
+	rowsAffected, err := gorm.G[T](db.Unscoped()).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+		Delete(ctx)
+	if err != nil {
+		return 0, failure.NewDatabaseFailure("Failed to purge deleted items from database").WithCause(err)
+	}
+
+	return rowsAffected, nil
+}
+
+func truncateLabel(label string) string {
+	runes := []rune(label)
+	if len(runes) <= trashLabelMaxLength {
+		return label
+	}
+
+	return string(runes[:trashLabelMaxLength]) + "..."
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"hinsun-backend/internal/core/transaction"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+const (
+	serializationFailureCode = "40001"
+	deadlockDetectedCode     = "40P01"
+	maxTransactionAttempts   = 3
+)
+
+type txContextKey struct{}
+
+type unitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) transaction.UnitOfWork {
+	return &unitOfWork{
+		db: db,
+	}
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return u.DoWithIsolation(ctx, transaction.DefaultIsolation, fn)
+}
+
+func (u *unitOfWork) DoWithIsolation(ctx context.Context, isolation transaction.IsolationLevel, fn func(ctx context.Context) error) error {
+	// Nested units of work join the outer transaction, gorm turns them into savepoints
+	if tx, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return tx.Transaction(func(tx *gorm.DB) error {
+			return fn(context.WithValue(ctx, txContextKey{}, tx))
+		})
+	}
+
+	options := &sql.TxOptions{Isolation: toSQLIsolation(isolation)}
+
+	var err error
+	for attempt := 1; attempt <= maxTransactionAttempts; attempt++ {
+		err = u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(context.WithValue(ctx, txContextKey{}, tx))
+		}, options)
+
+		if err == nil || !isRetryableTxError(err) {
+			return err
+		}
+	}
+
+	return err
+}
+
+// withTx returns the transaction carried by ctx, or db when the call is not part of a unit of work
+func withTx(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return tx
+	}
+
+	return db
+}
+
+func toSQLIsolation(isolation transaction.IsolationLevel) sql.IsolationLevel {
+	switch isolation {
+	case transaction.ReadCommitted:
+		return sql.LevelReadCommitted
+	case transaction.RepeatableRead:
+		return sql.LevelRepeatableRead
+	case transaction.Serializable:
+		return sql.LevelSerializable
+	default:
+		return sql.LevelDefault
+	}
+}
+
+// isRetryableTxError reports whether the transaction failed only because a concurrent one won
+func isRetryableTxError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.Code == serializationFailureCode || pgErr.Code == deadlockDetectedCode
+}
@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"context"
+	"hinsun-backend/internal/core/failure"
+
+	"gorm.io/gorm"
+)
+
+// updateVersioned writes model over the row with the given id only while the row still has
+// the version the entity was read at; model must already carry the next version. When no
+// row matches it tells a missing row (0 rows, no error) from a stale one (version conflict).
+// Only the non-zero fields are written, unless columns lists the ones to write, zero or not.
+func updateVersioned[T any](ctx context.Context, db *gorm.DB, id any, version int64, model T, columns ...string) (int, error) {
+	query := gorm.G[T](db).Where("id = ? AND version = ?", id, version)
+	if len(columns) > 0 {
+		query = query.Select(columns[0], toAnys(columns[1:])...)
+	}
+
+	rowsAffected, err := query.Updates(ctx, model)
+	if err != nil || rowsAffected > 0 {
+		return rowsAffected, err
+	}
+
+	var currentVersions []int64
+	err = db.WithContext(ctx).Model(new(T)).Where("id = ?", id).Pluck("version", &currentVersions).Error
+	if err != nil {
+		return 0, err
+	}
+
+	if len(currentVersions) == 0 {
+		return 0, nil
+	}
+
+	return 0, failure.NewVersionConflictFailure(currentVersions[0])
+}
+
+func toAnys(values []string) []any {
+	anys := make([]any, len(values))
+	for i, value := range values {
+		anys[i] = value
+	}
+
+	return anys
+}
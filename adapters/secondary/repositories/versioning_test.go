@@ -0,0 +1,226 @@
+package repositories
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"hinsun-backend/adapters/shared/databases"
+	"hinsun-backend/internal/domain/account"
+	"hinsun-backend/internal/domain/blog"
+	"hinsun-backend/internal/domain/project"
+	"hinsun-backend/internal/domain/values"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// statementRecorder is a gorm logger keeping the SQL of every statement
+type statementRecorder struct {
+	mu         sync.Mutex
+	statements []string
+}
+
+func (r *statementRecorder) LogMode(logger.LogLevel) logger.Interface      { return r }
+func (r *statementRecorder) Info(context.Context, string, ...interface{})  {}
+func (r *statementRecorder) Warn(context.Context, string, ...interface{})  {}
+func (r *statementRecorder) Error(context.Context, string, ...interface{}) {}
+
+func (r *statementRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	sql, _ := fc()
+	r.mu.Lock()
+	r.statements = append(r.statements, sql)
+	r.mu.Unlock()
+}
+
+func (r *statementRecorder) update(t *testing.T) string {
+	t.Helper()
+	for _, statement := range r.statements {
+		if strings.HasPrefix(statement, "UPDATE") {
+			return statement
+		}
+	}
+
+	t.Fatalf("no UPDATE statement in %q", r.statements)
+	return ""
+}
+
+// newDryRunDB returns a database that renders the statements without sending them
+func newDryRunDB(t *testing.T) (*gorm.DB, *statementRecorder) {
+	t.Helper()
+	recorder := &statementRecorder{}
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=dry_run"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true, // beginning one needs a connection
+		Logger:                 recorder,
+	})
+	if err != nil {
+		t.Fatalf("open dry run database: %v", err)
+	}
+
+	return db, recorder
+}
+
+func newTestBlog(t *testing.T, authorID uuid.UUID, isPublished bool) *blog.BlogEntity {
+	t.Helper()
+	entity, err := blog.NewBlogEntity(
+		authorID,
+		[]values.MarkdownLanguageCode{values.English},
+		[]string{"go"},
+		values.MultiLangText{values.English: "Zero values in merge patches"},
+		values.MultiLangText{values.English: "Clearing a field with a JSON merge patch"},
+		values.MultiLangText{values.English: "# Zero values\n\nThey have to be written too."},
+		isPublished,
+		120,
+	)
+	if err != nil {
+		t.Fatalf("new blog: %v", err)
+	}
+
+	return entity
+}
+
+// Struct updates skip zero values, so each repository lists the columns it writes
+func TestUpdateWritesZeroValues(t *testing.T) {
+	email, err := values.NewEmail("zero@example.com")
+	if err != nil {
+		t.Fatalf("new email: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		update      func(db *gorm.DB) (int, error)
+		assignments []string
+	}{
+		{
+			name: "blog unpublished",
+			update: func(db *gorm.DB) (int, error) {
+				return NewBlogRepository(db).Update(context.Background(), newTestBlog(t, uuid.New(), false))
+			},
+			assignments: []string{`"is_published"=false`, `"favorites"=0`, `"views"=0`},
+		},
+		{
+			name: "account deactivated with an empty bio",
+			update: func(db *gorm.DB) (int, error) {
+				entity, err := account.NewAccount("Zero", email, "hash", "", "", values.NormalRole)
+				if err != nil {
+					t.Fatalf("new account: %v", err)
+				}
+
+				entity.IsActive = false
+				return NewAccountRepository(db).Update(context.Background(), entity)
+			},
+			assignments: []string{`"is_active"=false`, `"bio"=''`, `"avatar"=''`},
+		},
+		{
+			name: "project without cover",
+			update: func(db *gorm.DB) (int, error) {
+				entity := project.NewProjectEntity(
+					[]values.MarkdownLanguageCode{values.English},
+					values.MultiLangText{values.English: "Zero"},
+					values.MultiLangText{values.English: "A project without a cover"},
+					"https://github.com/owner/zero",
+					"",
+					[]string{"go"},
+					values.MultiLangText{values.English: "# Zero"},
+				)
+				return NewProjectRepository(db).Update(context.Background(), entity)
+			},
+			assignments: []string{`"cover"=''`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, recorder := newDryRunDB(t)
+			if _, err := tt.update(db); err != nil {
+				t.Fatalf("update: %v", err)
+			}
+
+			statement := recorder.update(t)
+			for _, assignment := range tt.assignments {
+				if !strings.Contains(statement, assignment) {
+					t.Errorf("%s missing in %s", assignment, statement)
+				}
+			}
+		})
+	}
+}
+
+// TestPatchUnpublishesBlog runs against the Postgres database of TEST_DATABASE_URL, migrated to
+// the latest version, and is skipped without one
+func TestPatchUnpublishesBlog(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("database handle: %v", err)
+	}
+
+	migrator, err := databases.NewMigrator(sqlDB)
+	if err != nil {
+		t.Fatalf("new migrator: %v", err)
+	}
+
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	email, err := values.NewEmail("patch-" + uuid.NewString() + "@example.com")
+	if err != nil {
+		t.Fatalf("new email: %v", err)
+	}
+
+	author, err := account.NewAccount("Patch", email, "hash", "", "", values.NormalRole)
+	if err != nil {
+		t.Fatalf("new account: %v", err)
+	}
+
+	accounts := NewAccountRepository(db)
+	if err := accounts.Create(ctx, author); err != nil {
+		t.Fatalf("create account: %v", err)
+	}
+	t.Cleanup(func() { db.Unscoped().Exec("DELETE FROM accounts WHERE id = ?", author.ID) })
+
+	blogs := NewBlogRepository(db)
+	published := newTestBlog(t, author.ID, true)
+	published.Slug += "-" + uuid.NewString()
+	if err := blogs.Create(ctx, published); err != nil {
+		t.Fatalf("create blog: %v", err)
+	}
+
+	// What PATCH /blogs/{id} with {"isPublished":false} saves
+	published.IsPublished = false
+	rowsAffected, err := blogs.Update(ctx, published)
+	if err != nil || rowsAffected != 1 {
+		t.Fatalf("update blog: %d rows, %v", rowsAffected, err)
+	}
+
+	reread, err := blogs.FindByID(ctx, published.ID.String())
+	if err != nil || reread == nil {
+		t.Fatalf("find blog: %v, %v", reread, err)
+	}
+
+	if reread.IsPublished {
+		t.Error("blog is still published after patching isPublished to false")
+	}
+
+	if reread.Version != published.Version {
+		t.Errorf("version = %d, want %d", reread.Version, published.Version)
+	}
+}
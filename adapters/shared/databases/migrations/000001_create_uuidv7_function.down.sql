@@ -0,0 +1,2 @@
+-- Only drops the fallback; the built-in function of Postgres 18 lives in pg_catalog
+DROP FUNCTION IF EXISTS public.uuidv7();
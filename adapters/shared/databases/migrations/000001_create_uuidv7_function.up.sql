@@ -0,0 +1,18 @@
+-- Postgres 18 ships uuidv7(); older servers get an equivalent SQL implementation
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_proc WHERE proname = 'uuidv7' AND pronargs = 0) THEN
+        CREATE FUNCTION public.uuidv7() RETURNS uuid AS $fn$
+            SELECT encode(
+                set_bit(
+                    set_bit(
+                        overlay(uuid_send(gen_random_uuid())
+                            placing substring(int8send(floor(extract(epoch FROM clock_timestamp()) * 1000)::bigint) FROM 3)
+                            FROM 1 FOR 6),
+                        52, 1),
+                    53, 1),
+                'hex')::uuid;
+        $fn$ LANGUAGE sql VOLATILE;
+    END IF;
+END
+$$;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS projects;
+DROP TABLE IF EXISTS experiences;
+DROP TABLE IF EXISTS comments;
+DROP TABLE IF EXISTS blogs;
+DROP TABLE IF EXISTS categories;
+DROP TABLE IF EXISTS accounts;
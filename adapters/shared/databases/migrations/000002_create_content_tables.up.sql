@@ -0,0 +1,101 @@
+CREATE TABLE accounts (
+    id             uuid         PRIMARY KEY DEFAULT uuidv7(),
+    name           varchar(100) NOT NULL,
+    email          varchar(100) NOT NULL,
+    email_verified boolean      NOT NULL DEFAULT false,
+    role           int          NOT NULL DEFAULT 0,
+    is_active      boolean      NOT NULL DEFAULT true,
+    password       varchar(255) NOT NULL,
+    avatar         varchar(255),
+    bio            text,
+    created_at     bigint,
+    updated_at     bigint,
+    deleted_at     bigint
+);
+
+CREATE UNIQUE INDEX idx_accounts_email ON accounts (email);
+CREATE INDEX idx_accounts_deleted_at ON accounts (deleted_at);
+
+CREATE TABLE categories (
+    id         uuid        PRIMARY KEY DEFAULT uuidv7(),
+    name       varchar(50) NOT NULL,
+    num_blogs  bigint      NOT NULL DEFAULT 0,
+    created_at bigint,
+    updated_at bigint,
+    deleted_at bigint
+);
+
+CREATE UNIQUE INDEX idx_categories_name ON categories (name);
+CREATE INDEX idx_categories_deleted_at ON categories (deleted_at);
+
+CREATE TABLE blogs (
+    id                          uuid    PRIMARY KEY DEFAULT uuidv7(),
+    author_id                   uuid    NOT NULL REFERENCES accounts (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    slug                        text    NOT NULL,
+    languages                   text[]  NOT NULL,
+    categories                  text[]  NOT NULL,
+    names                       jsonb   NOT NULL,
+    descriptions                jsonb   NOT NULL,
+    is_published                boolean NOT NULL DEFAULT false,
+    markdowns                   jsonb   NOT NULL,
+    favorites                   bigint  NOT NULL DEFAULT 0,
+    views                       bigint  NOT NULL DEFAULT 0,
+    estimated_read_time_seconds bigint  NOT NULL,
+    created_at                  bigint,
+    updated_at                  bigint,
+    deleted_at                  bigint
+);
+
+CREATE UNIQUE INDEX idx_blogs_slug ON blogs (slug);
+CREATE INDEX idx_blogs_author_id ON blogs (author_id);
+CREATE INDEX idx_blogs_deleted_at ON blogs (deleted_at);
+
+CREATE TABLE comments (
+    id         uuid   PRIMARY KEY DEFAULT uuidv7(),
+    author_id  uuid   NOT NULL REFERENCES accounts (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    blog_id    uuid   NOT NULL REFERENCES blogs (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    parent_id  uuid,
+    content    text   NOT NULL,
+    favorites  bigint NOT NULL DEFAULT 0,
+    created_at bigint,
+    updated_at bigint,
+    deleted_at bigint
+);
+
+CREATE INDEX idx_comments_author_id ON comments (author_id);
+CREATE INDEX idx_comments_blog_id ON comments (blog_id);
+CREATE INDEX idx_comments_parent_id ON comments (parent_id);
+CREATE INDEX idx_comments_deleted_at ON comments (deleted_at);
+
+CREATE TABLE experiences (
+    id               uuid         PRIMARY KEY DEFAULT uuidv7(),
+    order_idx        int          NOT NULL,
+    position         varchar(100) NOT NULL,
+    company          varchar(100) NOT NULL,
+    location         varchar(100) NOT NULL,
+    technologies     text[]       NOT NULL,
+    responsibilities text[]       NOT NULL,
+    period           varchar(100) NOT NULL,
+    extra            jsonb,
+    created_at       bigint,
+    updated_at       bigint,
+    deleted_at       bigint
+);
+
+CREATE INDEX idx_experiences_deleted_at ON experiences (deleted_at);
+
+CREATE TABLE projects (
+    id          uuid         PRIMARY KEY DEFAULT uuidv7(),
+    name        varchar(100) NOT NULL,
+    description varchar(500) NOT NULL,
+    github      varchar(255) NOT NULL,
+    cover       varchar(255) NOT NULL,
+    tags        text[]       NOT NULL,
+    markdown    text         NOT NULL,
+    created_at  bigint,
+    updated_at  bigint,
+    deleted_at  bigint
+);
+
+CREATE UNIQUE INDEX idx_projects_name ON projects (name);
+CREATE INDEX idx_projects_deleted_at ON projects (deleted_at);
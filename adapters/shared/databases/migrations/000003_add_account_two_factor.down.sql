@@ -0,0 +1,4 @@
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS recovery_codes,
+    DROP COLUMN IF EXISTS two_factor_secret,
+    DROP COLUMN IF EXISTS two_factor_enabled;
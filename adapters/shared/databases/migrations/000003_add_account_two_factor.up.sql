@@ -0,0 +1,4 @@
+ALTER TABLE accounts
+    ADD COLUMN two_factor_enabled boolean     NOT NULL DEFAULT false,
+    ADD COLUMN two_factor_secret  varchar(64),
+    ADD COLUMN recovery_codes     text[];
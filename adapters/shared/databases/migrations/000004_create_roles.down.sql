@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS account_roles;
+DROP TABLE IF EXISTS roles;
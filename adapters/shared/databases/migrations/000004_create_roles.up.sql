@@ -0,0 +1,20 @@
+CREATE TABLE roles (
+    id          uuid         PRIMARY KEY DEFAULT uuidv7(),
+    name        varchar(50)  NOT NULL,
+    description varchar(255),
+    permissions text[]       NOT NULL DEFAULT '{}',
+    is_system   boolean      NOT NULL DEFAULT false,
+    created_at  bigint,
+    updated_at  bigint
+);
+
+CREATE UNIQUE INDEX idx_roles_name ON roles (name);
+
+CREATE TABLE account_roles (
+    account_id uuid NOT NULL REFERENCES accounts (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    role_id    uuid NOT NULL REFERENCES roles (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    created_at bigint,
+    PRIMARY KEY (account_id, role_id)
+);
+
+CREATE INDEX idx_account_roles_role_id ON account_roles (role_id);
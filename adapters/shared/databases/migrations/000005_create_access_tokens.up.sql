@@ -0,0 +1,16 @@
+CREATE TABLE access_tokens (
+    id           uuid         PRIMARY KEY DEFAULT uuidv7(),
+    account_id   uuid         NOT NULL REFERENCES accounts (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    name         varchar(100) NOT NULL,
+    lookup_id    varchar(16)  NOT NULL,
+    secret_hash  varchar(255) NOT NULL,
+    scopes       text[]       NOT NULL DEFAULT '{}',
+    two_factor   boolean      NOT NULL DEFAULT false,
+    expires_at   bigint,
+    last_used_at bigint,
+    revoked_at   bigint,
+    created_at   bigint
+);
+
+CREATE UNIQUE INDEX idx_access_tokens_lookup_id ON access_tokens (lookup_id);
+CREATE INDEX idx_access_tokens_account_id ON access_tokens (account_id);
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_accounts_email;
+CREATE UNIQUE INDEX idx_accounts_email ON accounts (email);
+
+DROP INDEX IF EXISTS idx_categories_name;
+CREATE UNIQUE INDEX idx_categories_name ON categories (name);
+
+DROP INDEX IF EXISTS idx_blogs_slug;
+CREATE UNIQUE INDEX idx_blogs_slug ON blogs (slug);
+
+DROP INDEX IF EXISTS idx_projects_name;
+CREATE UNIQUE INDEX idx_projects_name ON projects (name);
@@ -0,0 +1,13 @@
+-- Soft-deleted rows keep their values while in the trash, so uniqueness only
+-- applies to live rows; restoring a row whose value was reused is rejected.
+DROP INDEX IF EXISTS idx_accounts_email;
+CREATE UNIQUE INDEX idx_accounts_email ON accounts (email) WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_categories_name;
+CREATE UNIQUE INDEX idx_categories_name ON categories (name) WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_blogs_slug;
+CREATE UNIQUE INDEX idx_blogs_slug ON blogs (slug) WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_projects_name;
+CREATE UNIQUE INDEX idx_projects_name ON projects (name) WHERE deleted_at IS NULL;
@@ -0,0 +1,6 @@
+ALTER TABLE projects DROP COLUMN version;
+ALTER TABLE experiences DROP COLUMN version;
+ALTER TABLE comments DROP COLUMN version;
+ALTER TABLE categories DROP COLUMN version;
+ALTER TABLE blogs DROP COLUMN version;
+ALTER TABLE accounts DROP COLUMN version;
@@ -0,0 +1,7 @@
+-- Row versions for optimistic concurrency, bumped by every update and exposed as ETags
+ALTER TABLE accounts ADD COLUMN version bigint NOT NULL DEFAULT 1;
+ALTER TABLE blogs ADD COLUMN version bigint NOT NULL DEFAULT 1;
+ALTER TABLE categories ADD COLUMN version bigint NOT NULL DEFAULT 1;
+ALTER TABLE comments ADD COLUMN version bigint NOT NULL DEFAULT 1;
+ALTER TABLE experiences ADD COLUMN version bigint NOT NULL DEFAULT 1;
+ALTER TABLE projects ADD COLUMN version bigint NOT NULL DEFAULT 1;
@@ -0,0 +1,12 @@
+CREATE TABLE device_tokens (
+    id           uuid        PRIMARY KEY DEFAULT uuidv7(),
+    account_id   uuid        NOT NULL REFERENCES accounts (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    token        text        NOT NULL,
+    platform     varchar(16) NOT NULL,
+    created_at   bigint,
+    last_seen_at bigint
+);
+
+-- A device token belongs to one account at a time, registering it again moves it
+CREATE UNIQUE INDEX idx_device_tokens_token ON device_tokens (token);
+CREATE INDEX idx_device_tokens_account_id ON device_tokens (account_id);
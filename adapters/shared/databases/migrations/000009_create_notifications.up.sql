@@ -0,0 +1,26 @@
+CREATE TABLE notifications (
+    id         uuid         PRIMARY KEY DEFAULT uuidv7(),
+    account_id uuid         NOT NULL REFERENCES accounts (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    type       varchar(32)  NOT NULL,
+    title      varchar(255) NOT NULL,
+    content    text         NOT NULL,
+    data       jsonb        NOT NULL DEFAULT '{}',
+    is_read    boolean      NOT NULL DEFAULT false,
+    created_at bigint,
+    updated_at bigint
+);
+
+CREATE INDEX idx_notifications_account_id_created_at ON notifications (account_id, created_at DESC);
+
+-- One row per account that changed the defaults, see notification.DefaultPreference
+CREATE TABLE notification_preferences (
+    account_id     uuid        PRIMARY KEY REFERENCES accounts (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    language       varchar(8)  NOT NULL,
+    channels       jsonb       NOT NULL DEFAULT '{}',
+    quiet_start    varchar(5),
+    quiet_end      varchar(5),
+    quiet_timezone varchar(64),
+    webhook_url    text        NOT NULL DEFAULT '',
+    webhook_secret varchar(64) NOT NULL DEFAULT '',
+    updated_at     bigint
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS email_subscriptions;
+DROP TABLE IF EXISTS follows;
@@ -0,0 +1,26 @@
+-- Targets are categories or accounts, so target_id has no foreign key; follows of a deleted
+-- target simply never match again
+CREATE TABLE follows (
+    id          uuid        PRIMARY KEY DEFAULT uuidv7(),
+    account_id  uuid        NOT NULL REFERENCES accounts (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    target_type varchar(16) NOT NULL,
+    target_id   uuid        NOT NULL,
+    created_at  bigint
+);
+
+CREATE UNIQUE INDEX idx_follows_account_target ON follows (account_id, target_type, target_id);
+CREATE INDEX idx_follows_target ON follows (target_type, target_id);
+
+CREATE TABLE email_subscriptions (
+    id           uuid         PRIMARY KEY DEFAULT uuidv7(),
+    email        varchar(254) NOT NULL,
+    target_type  varchar(16)  NOT NULL,
+    target_id    uuid         NOT NULL,
+    token        varchar(64)  NOT NULL,
+    confirmed_at bigint,
+    created_at   bigint
+);
+
+CREATE UNIQUE INDEX idx_email_subscriptions_email_target ON email_subscriptions (email, target_type, target_id);
+CREATE UNIQUE INDEX idx_email_subscriptions_token ON email_subscriptions (token);
+CREATE INDEX idx_email_subscriptions_target ON email_subscriptions (target_type, target_id) WHERE confirmed_at IS NOT NULL;
@@ -0,0 +1,25 @@
+-- owner_id is cleared when the account goes, the files are then collected once unreferenced
+CREATE TABLE media (
+    id           uuid         PRIMARY KEY DEFAULT uuidv7(),
+    owner_id     uuid         REFERENCES accounts (id) ON UPDATE CASCADE ON DELETE SET NULL,
+    hash         varchar(64)  NOT NULL,
+    key          varchar(255) NOT NULL,
+    content_type varchar(64)  NOT NULL,
+    size         bigint       NOT NULL,
+    created_at   bigint
+);
+
+-- Blobs are stored once per hash, each owner gets one row per content
+CREATE UNIQUE INDEX idx_media_owner_hash ON media (owner_id, hash);
+CREATE INDEX idx_media_hash ON media (hash);
+CREATE INDEX idx_media_key ON media (key);
+
+-- Which resource field points at which file, reference_id is a project, account or blog
+CREATE TABLE media_references (
+    media_id       uuid        NOT NULL REFERENCES media (id) ON DELETE CASCADE,
+    reference_type varchar(32) NOT NULL,
+    reference_id   uuid        NOT NULL,
+    PRIMARY KEY (media_id, reference_type, reference_id)
+);
+
+CREATE INDEX idx_media_references_reference ON media_references (reference_type, reference_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_media_unprocessed;
+
+ALTER TABLE media
+    DROP COLUMN IF EXISTS processed_at,
+    DROP COLUMN IF EXISTS variants;
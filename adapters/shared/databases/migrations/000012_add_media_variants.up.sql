@@ -0,0 +1,7 @@
+-- Resized copies of uploaded images, variant name -> storage key. processed_at stays NULL until
+-- the variants were generated, or the image found not to be processable.
+ALTER TABLE media
+    ADD COLUMN variants     jsonb  NOT NULL DEFAULT '{}',
+    ADD COLUMN processed_at bigint;
+
+CREATE INDEX idx_media_unprocessed ON media (created_at) WHERE processed_at IS NULL;
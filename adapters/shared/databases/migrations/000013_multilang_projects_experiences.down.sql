@@ -0,0 +1,42 @@
+-- Each row keeps the text of its first language
+ALTER TABLE projects
+    ADD COLUMN name        varchar(100),
+    ADD COLUMN description varchar(500),
+    ADD COLUMN markdown    text;
+
+UPDATE projects SET
+    name        = names ->> languages[1],
+    description = descriptions ->> languages[1],
+    markdown    = markdowns ->> languages[1];
+
+ALTER TABLE projects
+    ALTER COLUMN name SET NOT NULL,
+    ALTER COLUMN description SET NOT NULL,
+    ALTER COLUMN markdown SET NOT NULL,
+    DROP COLUMN languages,
+    DROP COLUMN names,
+    DROP COLUMN descriptions,
+    DROP COLUMN markdowns;
+
+CREATE UNIQUE INDEX idx_projects_name ON projects (name) WHERE deleted_at IS NULL;
+
+ALTER TABLE experiences
+    ADD COLUMN position             varchar(100),
+    ADD COLUMN company              varchar(100),
+    ADD COLUMN responsibility_items text[];
+
+UPDATE experiences SET
+    position             = positions ->> languages[1],
+    company              = companies ->> languages[1],
+    responsibility_items = ARRAY(SELECT jsonb_array_elements_text(responsibilities -> languages[1]));
+
+ALTER TABLE experiences
+    ALTER COLUMN position SET NOT NULL,
+    ALTER COLUMN company SET NOT NULL,
+    ALTER COLUMN responsibility_items SET NOT NULL,
+    DROP COLUMN languages,
+    DROP COLUMN positions,
+    DROP COLUMN companies,
+    DROP COLUMN responsibilities;
+
+ALTER TABLE experiences RENAME COLUMN responsibility_items TO responsibilities;
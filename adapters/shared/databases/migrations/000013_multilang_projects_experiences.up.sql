@@ -0,0 +1,41 @@
+-- Projects and experiences hold their text per language like blogs, map of language code -> text.
+-- Existing content was written in English, it becomes the only language of each row.
+ALTER TABLE projects
+    ADD COLUMN languages    text[] NOT NULL DEFAULT '{en}',
+    ADD COLUMN names        jsonb,
+    ADD COLUMN descriptions jsonb,
+    ADD COLUMN markdowns    jsonb;
+
+UPDATE projects SET
+    names        = jsonb_build_object('en', name),
+    descriptions = jsonb_build_object('en', description),
+    markdowns    = jsonb_build_object('en', markdown);
+
+-- Names are unique per language now, which the service checks
+DROP INDEX IF EXISTS idx_projects_name;
+
+ALTER TABLE projects
+    ALTER COLUMN languages DROP DEFAULT,
+    ALTER COLUMN names SET NOT NULL,
+    ALTER COLUMN descriptions SET NOT NULL,
+    ALTER COLUMN markdowns SET NOT NULL,
+    DROP COLUMN name,
+    DROP COLUMN description,
+    DROP COLUMN markdown;
+
+ALTER TABLE experiences
+    ADD COLUMN languages text[] NOT NULL DEFAULT '{en}',
+    ADD COLUMN positions jsonb,
+    ADD COLUMN companies jsonb;
+
+UPDATE experiences SET
+    positions = jsonb_build_object('en', "position"),
+    companies = jsonb_build_object('en', company);
+
+ALTER TABLE experiences
+    ALTER COLUMN languages DROP DEFAULT,
+    ALTER COLUMN positions SET NOT NULL,
+    ALTER COLUMN companies SET NOT NULL,
+    ALTER COLUMN responsibilities TYPE jsonb USING jsonb_build_object('en', to_jsonb(responsibilities)),
+    DROP COLUMN position,
+    DROP COLUMN company;
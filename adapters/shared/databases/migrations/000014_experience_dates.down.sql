@@ -0,0 +1,9 @@
+ALTER TABLE experiences ADD COLUMN period varchar(100);
+
+UPDATE experiences SET
+    period = to_char(start_date, 'Mon YYYY') || ' - ' || COALESCE(to_char(end_date, 'Mon YYYY'), 'Present');
+
+ALTER TABLE experiences
+    ALTER COLUMN period SET NOT NULL,
+    DROP COLUMN start_date,
+    DROP COLUMN end_date;
@@ -0,0 +1,21 @@
+-- Experiences get a start and an end date instead of the free-text period, a null end date
+-- marks the current one. The years written in the period seed the dates: the first one is the
+-- start, a second one the end. Rows without a year start on their creation day.
+ALTER TABLE experiences
+    ADD COLUMN start_date date,
+    ADD COLUMN end_date   date;
+
+UPDATE experiences SET
+    start_date = COALESCE(
+        make_date(substring(period FROM '\d{4}')::int, 1, 1),
+        to_timestamp(created_at)::date,
+        CURRENT_DATE
+    ),
+    end_date   = make_date(substring(period FROM '\d{4}\D+(\d{4})')::int, 12, 1);
+
+-- A period written backwards is kept as current rather than ending before it starts
+UPDATE experiences SET end_date = NULL WHERE end_date < start_date;
+
+ALTER TABLE experiences
+    ALTER COLUMN start_date SET NOT NULL,
+    DROP COLUMN period;
@@ -0,0 +1,3 @@
+-- Metadata of the GitHub repository of each project (stars, language, topics, last push, ...),
+-- synced periodically and null until the first sync
+ALTER TABLE projects ADD COLUMN repository_metadata jsonb;
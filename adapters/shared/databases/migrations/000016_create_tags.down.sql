@@ -0,0 +1,6 @@
+ALTER TABLE projects
+    DROP COLUMN featured_order,
+    DROP COLUMN featured;
+
+DROP INDEX IF EXISTS idx_projects_tags;
+DROP TABLE tags;
@@ -0,0 +1,37 @@
+-- Vocabulary of the project tags. Projects keep referencing their tags by name, the way blogs
+-- reference categories, so the tags column only holds normalized names from this table.
+CREATE TABLE tags (
+    id         uuid        PRIMARY KEY DEFAULT uuidv7(),
+    name       varchar(50) NOT NULL,
+    created_at bigint,
+    updated_at bigint,
+    version    bigint      NOT NULL DEFAULT 1,
+    deleted_at bigint
+);
+
+CREATE UNIQUE INDEX idx_tags_name ON tags (name) WHERE deleted_at IS NULL;
+CREATE INDEX idx_tags_deleted_at ON tags (deleted_at);
+
+-- The free-form tags are normalized (trimmed, lower case, single spaces) keeping their first
+-- position, then seeded as the vocabulary, the projects in the trash included
+UPDATE projects p SET tags = ARRAY(
+    SELECT n.name
+    FROM (
+        SELECT lower(regexp_replace(btrim(t.tag), '\s+', ' ', 'g')) AS name, t.ord
+        FROM unnest(p.tags) WITH ORDINALITY AS t(tag, ord)
+    ) n
+    WHERE n.name <> ''
+    GROUP BY n.name
+    ORDER BY min(n.ord)
+);
+
+INSERT INTO tags (name, created_at, updated_at)
+SELECT DISTINCT unnest(tags), extract(epoch FROM now())::bigint, extract(epoch FROM now())::bigint
+FROM projects;
+
+CREATE INDEX idx_projects_tags ON projects USING gin (tags);
+
+-- Featured projects are listed first, in the order set by hand
+ALTER TABLE projects
+    ADD COLUMN featured       boolean NOT NULL DEFAULT false,
+    ADD COLUMN featured_order integer NOT NULL DEFAULT 0;
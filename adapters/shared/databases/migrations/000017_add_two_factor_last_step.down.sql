@@ -0,0 +1 @@
+ALTER TABLE accounts DROP COLUMN two_factor_last_step;
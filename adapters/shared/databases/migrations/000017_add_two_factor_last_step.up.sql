@@ -0,0 +1,2 @@
+-- TOTP time step of the last accepted code, so a code cannot be replayed inside its window
+ALTER TABLE accounts ADD COLUMN two_factor_last_step BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+ALTER TABLE accounts
+    DROP COLUMN two_factor_locked_until,
+    DROP COLUMN two_factor_failed_attempts;
@@ -0,0 +1,4 @@
+-- Failed second factors in a row and the lockout they trigger (unix seconds, 0 when unlocked)
+ALTER TABLE accounts
+    ADD COLUMN two_factor_failed_attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN two_factor_locked_until BIGINT NOT NULL DEFAULT 0;
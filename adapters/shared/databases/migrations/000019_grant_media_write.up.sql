@@ -0,0 +1,4 @@
+-- Uploads now require media:write, the admin role created before it keeps uploading
+UPDATE roles
+SET permissions = array_append(permissions, 'media:write')
+WHERE name = 'admin' AND is_system AND NOT 'media:write' = ANY(permissions);
@@ -0,0 +1,7 @@
+-- Sealed secrets neither fit the former width nor can be read by the previous version, those
+-- accounts have to enroll again
+UPDATE accounts
+SET two_factor_secret = NULL, two_factor_enabled = false, recovery_codes = '{}'
+WHERE two_factor_secret LIKE 'v1:%';
+
+ALTER TABLE accounts ALTER COLUMN two_factor_secret TYPE varchar(64);
@@ -0,0 +1,2 @@
+-- TOTP secrets are stored sealed with AES-GCM, which no longer fits 64 characters
+ALTER TABLE accounts ALTER COLUMN two_factor_secret TYPE varchar(255);
@@ -32,7 +32,8 @@ func NewPostgresClient() (*PostgresClient, error) {
 			loc, _ := time.LoadLocation(cfg.TimeZone)
 			return time.Now().In(loc)
 		},
-		PrepareStmt: true,
+		PrepareStmt:    true,
+		TranslateError: true,
 	})
 
 	if err != nil {
@@ -55,15 +56,7 @@ func NewPostgresClient() (*PostgresClient, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	// gormDB.AutoMigrate(
-	// 	&models.ExperienceModel{},
-	// 	&models.AccountModel{},
-	// 	&models.ProjectModel{},
-	// 	&models.BlogModel{},
-	// 	&models.CategoryModel{},
-	// 	&models.CommentModel{},
-	// )
-
+	// Schema changes are applied by the versioned migrations in ./migrations, see Migrator
 	log.Logger.Info("✅ Database connection established successfully")
 	return &PostgresClient{DB: gormDB}, nil
 }
@@ -0,0 +1,36 @@
+package databases
+
+import (
+	"context"
+	"fmt"
+	"hinsun-backend/configs"
+	"hinsun-backend/internal/core/log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func NewRedisClient() (*redis.Client, error) {
+	cfg := configs.GlobalConfig.Caching
+
+	client := redis.NewClient(&redis.Options{
+		Addr:            cfg.GetRedisAddr(),
+		Password:        cfg.Password,
+		DB:              cfg.DB,
+		PoolSize:        cfg.PoolSize,
+		MinIdleConns:    cfg.MinIdleConns,
+		ConnMaxIdleTime: time.Duration(cfg.IdleTimeout) * time.Second,
+	})
+
+	// Test connection
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to ping Redis: %w", err)
+	}
+
+	log.Logger.Info("✅ Redis connection established successfully")
+	return client, nil
+}
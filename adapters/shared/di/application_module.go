@@ -1,67 +1,285 @@
 package di
 
 import (
+	"context"
+	"fmt"
+	"hinsun-backend/adapters/shared/streaming"
+	"hinsun-backend/configs"
+	"hinsun-backend/internal/core/cache"
 	"hinsun-backend/internal/core/events"
+	"hinsun-backend/internal/core/log"
+	"hinsun-backend/internal/core/transaction"
+	"hinsun-backend/internal/core/worker"
+	"hinsun-backend/internal/domain/accesstoken"
 	"hinsun-backend/internal/domain/account"
 	"hinsun-backend/internal/domain/applications"
 	"hinsun-backend/internal/domain/auth"
 	"hinsun-backend/internal/domain/blog"
 	"hinsun-backend/internal/domain/category"
 	"hinsun-backend/internal/domain/comment"
+	"hinsun-backend/internal/domain/devicetoken"
 	"hinsun-backend/internal/domain/experience"
+	"hinsun-backend/internal/domain/media"
 	"hinsun-backend/internal/domain/notification"
 	"hinsun-backend/internal/domain/project"
+	"hinsun-backend/internal/domain/role"
+	"hinsun-backend/internal/domain/subscription"
+	"hinsun-backend/internal/domain/tag"
+	"hinsun-backend/internal/domain/trash"
+	"hinsun-backend/pkg/mailer"
+	"time"
 
 	"go.uber.org/fx"
 )
 
 var ApplicationModule = fx.Module("applications",
 	fx.Provide(
-		ProvideNotificationAppService,
+		fx.Annotate(ProvideNotificationAppService, fx.ParamTags("", "", notificationChannelsGroup)),
+		ProvideSubscriptionAppService,
+		ProvideDeviceAppService,
 		ProvideAsyncEventBus,
 		ProvideAuthAppService,
 		ProvideGlobalAppService,
 		ProvideBlogAppService,
 		ProvideAccountAppService,
 		ProvideCategoryAppService,
+		ProvideTagAppService,
+		ProvideRoleAppService,
+		ProvideTrashAppService,
+		ProvideMediaAppService,
+		ProvideMediaWorkerPool,
 	),
+	fx.Invoke(RegisterEventHandlers),
+	fx.Invoke(RegisterMediaProcessingHook),
+	fx.Invoke(RegisterRepositorySyncHook),
+	fx.Invoke(RegisterTwoFactorSecretsHook),
 )
 
-func ProvideNotificationAppService(notificationService notification.NotificationService) applications.NotificationAppService {
-	return applications.NewNotificationAppService(notificationService)
+func ProvideNotificationAppService(
+	notificationService notification.NotificationService,
+	accountService account.AccountService,
+	channels []notification.NotificationChannel,
+	topicChannel notification.TopicChannel,
+) applications.NotificationAppService {
+	unsubscribeURL := configs.GlobalConfig.Subscription.UnsubscribeURL
+	return applications.NewNotificationAppService(notificationService, accountService, channels, topicChannel, unsubscribeURL)
 }
 
-func ProvideAuthAppService(authService auth.AuthService, accountService account.AccountService) applications.AuthAppService {
-	return applications.NewAuthAppService(authService, accountService)
+func ProvideSubscriptionAppService(
+	subscriptionService subscription.SubscriptionService,
+	categoryService category.CategoryService,
+	accountService account.AccountService,
+	mailer mailer.Mailer,
+) applications.SubscriptionAppService {
+	confirmURL := configs.GlobalConfig.Subscription.ConfirmURL
+	return applications.NewSubscriptionAppService(subscriptionService, categoryService, accountService, mailer, confirmURL)
+}
+
+func ProvideDeviceAppService(deviceTokenService devicetoken.DeviceTokenService) applications.DeviceAppService {
+	return applications.NewDeviceAppService(deviceTokenService)
+}
+
+func ProvideAuthAppService(
+	authService auth.AuthService,
+	accountService account.AccountService,
+	roleService role.RoleService,
+	accessTokenService accesstoken.AccessTokenService,
+	unitOfWork transaction.UnitOfWork,
+) applications.AuthAppService {
+	return applications.NewAuthAppService(authService, accountService, roleService, accessTokenService, unitOfWork)
 }
 
 func ProvideGlobalAppService(
 	experienceService experience.ExperienceService,
 	projectService project.ProjectService,
+	tagService tag.TagService,
+	mediaService media.MediaService,
 	asyncEventBus *events.AsyncEventBus,
+	readThroughCache *cache.ReadThrough,
+	unitOfWork transaction.UnitOfWork,
 ) applications.GlobalAppService {
-	return applications.NewGlobalAppService(experienceService, projectService, asyncEventBus)
+	return applications.NewGlobalAppService(experienceService, projectService, tagService, mediaService, asyncEventBus, readThroughCache, unitOfWork)
 }
 
-func ProvideBlogAppService(blogService blog.BlogService, commentService comment.CommentService, accountService account.AccountService) applications.BlogAppService {
-	return applications.NewBlogAppService(blogService, commentService, accountService)
+func ProvideBlogAppService(
+	blogService blog.BlogService,
+	commentService comment.CommentService,
+	accountService account.AccountService,
+	categoryService category.CategoryService,
+	subscriptionService subscription.SubscriptionService,
+	mediaService media.MediaService,
+	unitOfWork transaction.UnitOfWork,
+	readThroughCache *cache.ReadThrough,
+	asyncEventBus *events.AsyncEventBus,
+) applications.BlogAppService {
+	return applications.NewBlogAppService(
+		blogService,
+		commentService,
+		accountService,
+		categoryService,
+		subscriptionService,
+		mediaService,
+		unitOfWork,
+		readThroughCache,
+		asyncEventBus,
+	)
 }
 
-func ProvideAccountAppService(accountService account.AccountService, authService auth.AuthService) applications.AccountAppService {
-	return applications.NewAccountAppService(accountService, authService)
+func ProvideAccountAppService(
+	accountService account.AccountService,
+	authService auth.AuthService,
+	roleService role.RoleService,
+	mediaService media.MediaService,
+	unitOfWork transaction.UnitOfWork,
+) applications.AccountAppService {
+	return applications.NewAccountAppService(accountService, authService, roleService, mediaService, unitOfWork)
 }
 
 func ProvideCategoryAppService(categoryService category.CategoryService) applications.CategoryAppService {
 	return applications.NewCategoryAppService(categoryService)
 }
 
+func ProvideTagAppService(
+	tagService tag.TagService,
+	projectService project.ProjectService,
+	unitOfWork transaction.UnitOfWork,
+	readThroughCache *cache.ReadThrough,
+) applications.TagAppService {
+	return applications.NewTagAppService(tagService, projectService, unitOfWork, readThroughCache)
+}
+
+func ProvideRoleAppService(roleService role.RoleService) applications.RoleAppService {
+	return applications.NewRoleAppService(roleService)
+}
+
 // ProvideAsyncEventBus provides an asynchronous event bus
-func ProvideAsyncEventBus(notificationAppService applications.NotificationAppService) *events.AsyncEventBus {
-	eventBus := events.NewAsyncEventBus()
+func ProvideAsyncEventBus() *events.AsyncEventBus {
+	return events.NewAsyncEventBus()
+}
 
+// RegisterEventHandlers subscribes the event handlers once everything is built, handlers may
+// themselves publish on the bus (e.g. the in-app notification channel)
+func RegisterEventHandlers(
+	eventBus *events.AsyncEventBus,
+	notificationAppService applications.NotificationAppService,
+	streamBroker *streaming.Broker,
+) {
 	// Here you can subscribe your event handlers
 	// e.g., eventBus.Subscribe(yourEventHandler)
 
 	eventBus.Subscribe(notificationAppService)
-	return eventBus
+	eventBus.Subscribe(streamBroker)
+}
+
+func ProvideTrashAppService(trashService trash.TrashService, readThroughCache *cache.ReadThrough) applications.TrashAppService {
+	return applications.NewTrashAppService(trashService, readThroughCache)
+}
+
+func ProvideMediaAppService(
+	mediaService media.MediaService,
+	workerPool *worker.Pool,
+	readThroughCache *cache.ReadThrough,
+) applications.MediaAppService {
+	return applications.NewMediaAppService(mediaService, workerPool, readThroughCache)
+}
+
+// ProvideMediaWorkerPool provides the pool generating image variants, sized by MEDIA_PROCESSING_*
+func ProvideMediaWorkerPool() *worker.Pool {
+	mediaConfig := configs.GlobalConfig.Media
+	return worker.NewPool(mediaConfig.ProcessingWorkers, mediaConfig.ProcessingQueueSize)
+}
+
+// RegisterMediaProcessingHook runs the worker pool with the app and queues the images left
+// pending by the previous run
+func RegisterMediaProcessingHook(lc fx.Lifecycle, workerPool *worker.Pool, mediaAppService applications.MediaAppService) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			workerPool.Start()
+
+			queued, err := mediaAppService.ResumeProcessing(ctx)
+			if err != nil {
+				log.Logger.Error(fmt.Sprintf("❌ Failed to resume image processing: %v", err))
+			}
+
+			if queued > 0 {
+				log.Logger.Info(fmt.Sprintf("🖼️ Queued %d pending images for processing", queued))
+			}
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return workerPool.Stop(ctx)
+		},
+	})
+}
+
+// RegisterRepositorySyncHook periodically refreshes the GitHub metadata of the projects. Only
+// metadata older than half the interval is synced, so frequent restarts do not spend the rate
+// limit while a tick still catches the projects synced a moment after the previous one.
+func RegisterRepositorySyncHook(lc fx.Lifecycle, globalAppService applications.GlobalAppService) {
+	githubConfig := configs.GlobalConfig.Github
+	if githubConfig.SyncInterval <= 0 {
+		return
+	}
+
+	interval := time.Duration(githubConfig.SyncInterval) * time.Second
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	sync := func() {
+		synced, err := globalAppService.SyncRepositories(ctx, interval/2)
+		if err != nil {
+			log.Logger.Error(fmt.Sprintf("❌ Failed to sync project repositories: %v", err))
+		}
+
+		if synced > 0 {
+			log.Logger.Info(fmt.Sprintf("🔄 Synced %d project repositories", synced))
+		}
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+
+				sync()
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						sync()
+					}
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+
+			return nil
+		},
+	})
+}
+
+// RegisterTwoFactorSecretsHook seals the TOTP secrets stored in plain text by earlier versions
+func RegisterTwoFactorSecretsHook(lc fx.Lifecycle, authAppService applications.AuthAppService) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			sealed, err := authAppService.SealTwoFactorSecrets(ctx)
+			if sealed > 0 {
+				log.Logger.Info(fmt.Sprintf("🔐 Encrypted %d two-factor secrets", sealed))
+			}
+
+			return err
+		},
+	})
 }
@@ -2,10 +2,18 @@ package di
 
 import (
 	"context"
+	"fmt"
+	"hinsun-backend/adapters/secondary/caches"
 	"hinsun-backend/adapters/shared/databases"
+	"hinsun-backend/adapters/shared/metrics"
 	"hinsun-backend/configs"
+	"hinsun-backend/internal/core/cache"
+	"hinsun-backend/internal/core/log"
+	"hinsun-backend/pkg/firebase"
 	"hinsun-backend/pkg/jwt"
 	"hinsun-backend/pkg/security"
+	"hinsun-backend/pkg/storage"
+	"path/filepath"
 	"time"
 
 	"go.uber.org/fx"
@@ -16,10 +24,17 @@ var CoreModule = fx.Module("database",
 	fx.Provide(
 		PrivideDatabase,
 		ProvidePasswordHasher,
+		ProvideTOTP,
+		ProvideSecretBox,
 		ProvideKeyManager,
 		ProvideJwtService,
+		ProvideCache,
+		ProvideReadThroughCache,
+		ProvideFCMService,
+		ProvideBlobStorage,
 	),
 	fx.Invoke(RegisterDatabaseHook),
+	fx.Invoke(RegisterCacheHook),
 )
 
 func PrivideDatabase() (*gorm.DB, error) {
@@ -34,8 +49,27 @@ func PrivideDatabase() (*gorm.DB, error) {
 func RegisterDatabaseHook(lc fx.Lifecycle, db *gorm.DB) {
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
-			// Database is already connected in ProvideDatabase
-			return nil
+			// Database is already connected in ProvideDatabase, only the schema version is checked here
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+
+			migrator, err := databases.NewMigrator(sqlDB)
+			if err != nil {
+				return err
+			}
+
+			if configs.GlobalConfig.Database.AutoMigrate {
+				applied, err := migrator.Up(ctx)
+				for _, migration := range applied {
+					log.Logger.Info(fmt.Sprintf("⬆️ Applied migration %06d_%s", migration.Version, migration.Name))
+				}
+
+				return err
+			}
+
+			return migrator.EnsureUpToDate(ctx)
 		},
 		OnStop: func(ctx context.Context) error {
 			sqlDB, err := db.DB()
@@ -47,23 +81,130 @@ func RegisterDatabaseHook(lc fx.Lifecycle, db *gorm.DB) {
 	})
 }
 
-func ProvidePasswordHasher() security.PasswordHasher {
-	return security.NewArgon2Hasher(security.DefaultArgon2Params())
+// ProvideCache provides the cache store selected by CACHE_DRIVER
+func ProvideCache() (cache.Cache, error) {
+	cachingConfig := configs.GlobalConfig.Caching
+
+	switch cachingConfig.Driver {
+	case "memory":
+		return caches.NewMemoryCache(cachingConfig.MaxEntries), nil
+	case "redis":
+		client, err := databases.NewRedisClient()
+		if err != nil {
+			return nil, err
+		}
+
+		return caches.NewRedisCache(client), nil
+	default:
+		return nil, fmt.Errorf("unsupported cache driver %q, expected memory or redis", cachingConfig.Driver)
+	}
+}
+
+func ProvideReadThroughCache(store cache.Cache) *cache.ReadThrough {
+	return cache.NewReadThrough(store, time.Duration(configs.GlobalConfig.Caching.TTL)*time.Second)
+}
+
+func RegisterCacheHook(lc fx.Lifecycle, store cache.Cache, readThroughCache *cache.ReadThrough) {
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			for _, stats := range readThroughCache.Stats() {
+				log.Logger.Info(fmt.Sprintf("📦 Cache %s: %d hits, %d misses", stats.Namespace, stats.Hits, stats.Misses))
+			}
+
+			return store.Close()
+		},
+	})
 }
 
-func ProvideKeyManager() *jwt.KeyManager {
+// ProvideFCMService provides Firebase Cloud Messaging, or a service dropping every push when Firebase is disabled
+func ProvideFCMService() (firebase.FCMService, error) {
+	firebaseConfig := configs.GlobalConfig.Firebase
+	if !firebaseConfig.Enabled {
+		log.Logger.Warn("⚠️ Firebase is disabled, push notifications are not sent")
+		return firebase.NewNoopFCMService(), nil
+	}
+
+	app, err := firebase.NewFirebase(context.Background(), firebaseConfig.CredentialsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return firebase.NewFCMService(app, log.Logger)
+}
+
+// ProvideBlobStorage provides the file storage selected by STORAGE_DRIVER
+func ProvideBlobStorage() (storage.BlobStorage, error) {
+	storageConfig := configs.GlobalConfig.Storage
+
+	switch storageConfig.Driver {
+	case "local":
+		return storage.NewLocalStorage(storageConfig.LocalRoot, storageConfig.PublicURL)
+	case "s3":
+		return storage.NewS3Storage(storage.S3Params{
+			Endpoint:     storageConfig.S3Endpoint,
+			Region:       storageConfig.S3Region,
+			Bucket:       storageConfig.S3Bucket,
+			AccessKey:    storageConfig.S3AccessKey,
+			SecretKey:    storageConfig.S3SecretKey,
+			UsePathStyle: storageConfig.S3UsePathStyle,
+			PublicURL:    storageConfig.PublicURL,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported storage driver %q, expected local or s3", storageConfig.Driver)
+	}
+}
+
+func ProvidePasswordHasher(m *metrics.Metrics) security.PasswordHasher {
+	return metrics.InstrumentPasswordHasher(security.NewArgon2Hasher(security.DefaultArgon2Params()), m)
+}
+
+func ProvideTOTP() security.TOTP {
+	return security.NewTOTP(security.DefaultTOTPParams(configs.GlobalConfig.TwoFactor.Issuer))
+}
+
+// twoFactorKeyFileName holds the generated TOTP secret key under the JWT keys path
+const twoFactorKeyFileName = "two_factor.key"
+
+// ProvideSecretBox provides the encryption of TOTP secrets, keyed by TWO_FACTOR_ENCRYPTION_KEY or
+// by a key generated next to the JWT keys
+func ProvideSecretBox() (security.SecretBox, error) {
+	var key []byte
+	var err error
+	if encodedKey := configs.GlobalConfig.TwoFactor.EncryptionKey; encodedKey != "" {
+		key, err = security.ParseSecretBoxKey(encodedKey)
+	} else {
+		key, err = security.LoadOrCreateSecretBoxKey(filepath.Join(configs.GlobalConfig.Jwt.KeysPath, twoFactorKeyFileName))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the two-factor encryption key: %w", err)
+	}
+
+	return security.NewSecretBox(key)
+}
+
+func ProvideKeyManager() (*jwt.KeyManager, error) {
 	jwtConfig := configs.GlobalConfig.Jwt
-	return jwt.NewKeyManager(jwtConfig.Algorithm, jwtConfig.KeySize)
+	return jwt.NewKeyManager(&jwt.KeyManagerParams{
+		KeysPath:       jwtConfig.KeysPath,
+		Algorithm:      jwtConfig.Algorithm,
+		KeySize:        jwtConfig.KeySize,
+		MaxRetiredKeys: jwtConfig.MaxRetiredKeys,
+		AccessSecret:   jwtConfig.AccessSecret,
+		RefreshSecret:  jwtConfig.RefreshSecret,
+	})
 }
 
 func ProvideJwtService(keyManager *jwt.KeyManager) jwt.JwtService {
 	jwtConfig := configs.GlobalConfig.Jwt
 	accessTokenExpiry := time.Duration(jwtConfig.AccessTokenExpiry) * time.Second
 	refreshTokenExpiry := time.Duration(jwtConfig.RefreshTokenExpiry) * time.Second
+	challengeTokenExpiry := time.Duration(configs.GlobalConfig.TwoFactor.ChallengeTokenExpiry) * time.Second
 
 	return jwt.NewJwtService(
 		keyManager,
 		accessTokenExpiry,
 		refreshTokenExpiry,
+		challengeTokenExpiry,
 	)
 }
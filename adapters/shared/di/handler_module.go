@@ -4,9 +4,13 @@ import (
 	v1 "hinsun-backend/adapters/primary/v1"
 	"hinsun-backend/adapters/primary/v1/handlers"
 	v2 "hinsun-backend/adapters/primary/v2"
+	"hinsun-backend/adapters/primary/wellknown"
 	"hinsun-backend/adapters/shared/middlewares"
+	"hinsun-backend/adapters/shared/streaming"
+	"hinsun-backend/configs"
 	"hinsun-backend/internal/domain/applications"
 	"hinsun-backend/pkg/jwt"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"go.uber.org/fx"
@@ -16,22 +20,31 @@ var HandlerModule = fx.Module("v1_handlers",
 	fx.Provide(
 		ProvideValidator,
 		ProvideAuthMiddleware,
-		ProvideRoleMiddleware,
+		ProvidePermissionMiddleware,
 		ProvideAuthHandler,
 		ProvideExperienceHandler,
 		ProvideBlogHandler,
 		ProvideProjectHandler,
 		ProvideAccountHandler,
 		ProvideCategoryHandler,
+		ProvideTagHandler,
+		ProvideRoleHandler,
+		ProvideTrashHandler,
+		ProvideDeviceHandler,
+		ProvideNotificationHandler,
+		ProvideStreamHandler,
+		ProvideFollowHandler,
+		ProvideSubscriptionHandler,
+		ProvideMediaHandler,
 	),
 )
 
-func ProvideAuthMiddleware(jwtService jwt.JwtService) *middlewares.AuthMiddleware {
-	return middlewares.NewAuthMiddleware(jwtService)
+func ProvideAuthMiddleware(jwtService jwt.JwtService, authApp applications.AuthAppService) *middlewares.AuthMiddleware {
+	return middlewares.NewAuthMiddleware(jwtService, authApp)
 }
 
-func ProvideRoleMiddleware() *middlewares.RoleMiddleware {
-	return middlewares.NewRoleMiddleware()
+func ProvidePermissionMiddleware() *middlewares.PermissionMiddleware {
+	return middlewares.NewPermissionMiddleware(configs.GlobalConfig.TwoFactor.RequiredForAdmin)
 }
 
 func ProvideValidator() *validator.Validate {
@@ -42,60 +55,129 @@ func ProvideAuthHandler(
 	app applications.AuthAppService,
 	validator *validator.Validate,
 	authMiddleware *middlewares.AuthMiddleware,
-	roleMiddleware *middlewares.RoleMiddleware,
+	permissionMiddleware *middlewares.PermissionMiddleware,
 ) *handlers.AuthHandler {
-	return handlers.NewAuthHandler(app, validator, authMiddleware, roleMiddleware)
+	return handlers.NewAuthHandler(app, validator, authMiddleware, permissionMiddleware)
 }
 
 func ProvideExperienceHandler(
 	app applications.GlobalAppService,
 	validator *validator.Validate,
 	authMiddleware *middlewares.AuthMiddleware,
-	roleMiddleware *middlewares.RoleMiddleware,
+	permissionMiddleware *middlewares.PermissionMiddleware,
 ) *handlers.ExperienceHandler {
-	return handlers.NewExperienceHandler(app, validator, authMiddleware, roleMiddleware)
+	return handlers.NewExperienceHandler(app, validator, authMiddleware, permissionMiddleware)
 }
 
 func ProvideBlogHandler(
 	app applications.BlogAppService,
 	validator *validator.Validate,
 	authMiddleware *middlewares.AuthMiddleware,
-	roleMiddleware *middlewares.RoleMiddleware,
+	permissionMiddleware *middlewares.PermissionMiddleware,
 ) *handlers.BlogHandler {
-	return handlers.NewBlogHandler(app, validator, authMiddleware, roleMiddleware)
+	return handlers.NewBlogHandler(app, validator, authMiddleware, permissionMiddleware)
 }
 
 func ProvideProjectHandler(
 	app applications.GlobalAppService,
 	validator *validator.Validate,
 	authMiddleware *middlewares.AuthMiddleware,
-	roleMiddleware *middlewares.RoleMiddleware,
+	permissionMiddleware *middlewares.PermissionMiddleware,
 ) *handlers.ProjectHandler {
-	return handlers.NewProjectHandler(app, validator, authMiddleware, roleMiddleware)
+	return handlers.NewProjectHandler(app, validator, authMiddleware, permissionMiddleware)
 }
 
 func ProvideAccountHandler(
 	app applications.AccountAppService,
 	validator *validator.Validate,
 	authMiddleware *middlewares.AuthMiddleware,
-	roleMiddleware *middlewares.RoleMiddleware,
+	permissionMiddleware *middlewares.PermissionMiddleware,
 ) *handlers.AccountHandler {
-	return handlers.NewAccountHandler(app, validator, authMiddleware, roleMiddleware)
+	return handlers.NewAccountHandler(app, validator, authMiddleware, permissionMiddleware)
 }
 
 func ProvideCategoryHandler(
 	app applications.CategoryAppService,
 	validator *validator.Validate,
 	authMiddleware *middlewares.AuthMiddleware,
-	roleMiddleware *middlewares.RoleMiddleware,
+	permissionMiddleware *middlewares.PermissionMiddleware,
 ) *handlers.CategoryHandler {
-	return handlers.NewCategoryHandler(app, validator, authMiddleware, roleMiddleware)
+	return handlers.NewCategoryHandler(app, validator, authMiddleware, permissionMiddleware)
+}
+
+func ProvideTagHandler(
+	app applications.TagAppService,
+	validator *validator.Validate,
+	authMiddleware *middlewares.AuthMiddleware,
+	permissionMiddleware *middlewares.PermissionMiddleware,
+) *handlers.TagHandler {
+	return handlers.NewTagHandler(app, validator, authMiddleware, permissionMiddleware)
+}
+
+func ProvideRoleHandler(
+	app applications.RoleAppService,
+	validator *validator.Validate,
+	authMiddleware *middlewares.AuthMiddleware,
+	permissionMiddleware *middlewares.PermissionMiddleware,
+) *handlers.RoleHandler {
+	return handlers.NewRoleHandler(app, validator, authMiddleware, permissionMiddleware)
+}
+
+func ProvideTrashHandler(
+	app applications.TrashAppService,
+	authMiddleware *middlewares.AuthMiddleware,
+	permissionMiddleware *middlewares.PermissionMiddleware,
+) *handlers.TrashHandler {
+	return handlers.NewTrashHandler(app, authMiddleware, permissionMiddleware)
+}
+
+func ProvideDeviceHandler(
+	app applications.DeviceAppService,
+	validator *validator.Validate,
+	authMiddleware *middlewares.AuthMiddleware,
+) *handlers.DeviceHandler {
+	return handlers.NewDeviceHandler(app, validator, authMiddleware)
+}
+
+func ProvideNotificationHandler(
+	app applications.NotificationAppService,
+	validator *validator.Validate,
+	authMiddleware *middlewares.AuthMiddleware,
+) *handlers.NotificationHandler {
+	return handlers.NewNotificationHandler(app, validator, authMiddleware)
+}
+
+func ProvideStreamHandler(broker *streaming.Broker, authMiddleware *middlewares.AuthMiddleware) *handlers.StreamHandler {
+	heartbeatInterval := time.Duration(configs.GlobalConfig.Stream.HeartbeatInterval) * time.Second
+	return handlers.NewStreamHandler(broker, heartbeatInterval, authMiddleware)
+}
+
+func ProvideFollowHandler(
+	app applications.SubscriptionAppService,
+	validator *validator.Validate,
+	authMiddleware *middlewares.AuthMiddleware,
+) *handlers.FollowHandler {
+	return handlers.NewFollowHandler(app, validator, authMiddleware)
+}
+
+func ProvideSubscriptionHandler(app applications.SubscriptionAppService, validator *validator.Validate) *handlers.SubscriptionHandler {
+	return handlers.NewSubscriptionHandler(app, validator)
+}
+
+func ProvideMediaHandler(
+	app applications.MediaAppService,
+	authMiddleware *middlewares.AuthMiddleware,
+	permissionMiddleware *middlewares.PermissionMiddleware,
+) *handlers.MediaHandler {
+	maxUploadSize := int64(configs.GlobalConfig.Media.MaxUploadSize) << 20
+	return handlers.NewMediaHandler(app, maxUploadSize, authMiddleware, permissionMiddleware)
 }
 
 var RouterVersionModule = fx.Module("routers",
 	fx.Provide(
 		ProvideV1Route,
 		ProvideV2Route,
+		ProvideWellKnownRoute,
 	),
 )
 
@@ -106,7 +188,25 @@ func ProvideV1Route(
 	projectHandler *handlers.ProjectHandler,
 	accountHandler *handlers.AccountHandler,
 	categoryHandler *handlers.CategoryHandler,
+	tagHandler *handlers.TagHandler,
+	roleHandler *handlers.RoleHandler,
+	trashHandler *handlers.TrashHandler,
+	deviceHandler *handlers.DeviceHandler,
+	notificationHandler *handlers.NotificationHandler,
+	streamHandler *handlers.StreamHandler,
+	followHandler *handlers.FollowHandler,
+	subscriptionHandler *handlers.SubscriptionHandler,
+	mediaHandler *handlers.MediaHandler,
 ) *v1.V1Routes {
+	httpCacheConfig := configs.GlobalConfig.HttpCache
+	cachePolicies := middlewares.CachePolicies{
+		Blogs:       httpCacheConfig.BlogsPolicy,
+		Projects:    httpCacheConfig.ProjectsPolicy,
+		Experiences: httpCacheConfig.ExperiencesPolicy,
+		Categories:  httpCacheConfig.CategoriesPolicy,
+		Tags:        httpCacheConfig.TagsPolicy,
+	}
+
 	return v1.NewV1Routes(
 		authHandler,
 		experienceHandler,
@@ -114,9 +214,23 @@ func ProvideV1Route(
 		projectHandler,
 		accountHandler,
 		categoryHandler,
+		tagHandler,
+		roleHandler,
+		trashHandler,
+		deviceHandler,
+		notificationHandler,
+		streamHandler,
+		followHandler,
+		subscriptionHandler,
+		mediaHandler,
+		cachePolicies,
 	)
 }
 
 func ProvideV2Route() *v2.V2Routes {
 	return v2.NewV2Routes()
 }
+
+func ProvideWellKnownRoute(authHandler *handlers.AuthHandler) *wellknown.WellKnownRoutes {
+	return wellknown.NewWellKnownRoutes(authHandler)
+}
@@ -0,0 +1,102 @@
+package di
+
+import (
+	healthRoutes "hinsun-backend/adapters/primary/health"
+	"hinsun-backend/adapters/shared/databases"
+	"hinsun-backend/adapters/shared/health"
+	"hinsun-backend/configs"
+	"hinsun-backend/internal/core/cache"
+	"hinsun-backend/pkg/firebase"
+	"time"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// readinessChecksGroup collects the checks of every dependency, modules add theirs with
+// fx.ResultTags(`group:"readiness_checks,flatten"`)
+const readinessChecksGroup = `group:"readiness_checks"`
+
+var HealthModule = fx.Module("health",
+	fx.Provide(
+		fx.Annotate(ProvideDatabaseReadinessChecks, fx.ResultTags(`group:"readiness_checks,flatten"`)),
+		fx.Annotate(ProvideCacheReadinessChecks, fx.ResultTags(`group:"readiness_checks,flatten"`)),
+		fx.Annotate(ProvideFirebaseReadinessChecks, fx.ResultTags(`group:"readiness_checks,flatten"`)),
+		fx.Annotate(ProvideReadiness, fx.ParamTags(readinessChecksGroup)),
+		ProvideHealthRoutes,
+	),
+)
+
+func ProvideReadiness(checks []health.Check) *health.Readiness {
+	return health.NewReadiness(checks)
+}
+
+func ProvideHealthRoutes(readiness *health.Readiness) *healthRoutes.HealthRoutes {
+	return healthRoutes.NewHealthRoutes(readiness)
+}
+
+// ProvideDatabaseReadinessChecks checks the connection and that every migration has been applied
+func ProvideDatabaseReadinessChecks(db *gorm.DB) ([]health.Check, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	migrator, err := databases.NewMigrator(sqlDB)
+	if err != nil {
+		return nil, err
+	}
+
+	return []health.Check{
+		{
+			Name:    "database",
+			Timeout: readinessTimeout(),
+			Run:     sqlDB.PingContext,
+		},
+		{
+			Name:    "migrations",
+			Timeout: readinessTimeout(),
+			Run:     migrator.EnsureUpToDate,
+		},
+	}, nil
+}
+
+// ProvideCacheReadinessChecks checks the cache connection, the in-memory store has nothing to check
+func ProvideCacheReadinessChecks(store cache.Cache) []health.Check {
+	pinger, ok := store.(health.Pinger)
+	if !ok {
+		return nil
+	}
+
+	return []health.Check{
+		{
+			Name:    configs.GlobalConfig.Caching.Driver,
+			Timeout: readinessTimeout(),
+			Run:     pinger.Ping,
+		},
+	}
+}
+
+// ProvideFirebaseReadinessChecks checks FCM with a dry-run message when Firebase is enabled
+func ProvideFirebaseReadinessChecks(fcmService firebase.FCMService) []health.Check {
+	if !configs.GlobalConfig.Firebase.Enabled {
+		return nil
+	}
+
+	pinger, ok := fcmService.(health.Pinger)
+	if !ok {
+		return nil
+	}
+
+	return []health.Check{
+		{
+			Name:    "firebase",
+			Timeout: readinessTimeout(),
+			Run:     pinger.Ping,
+		},
+	}
+}
+
+func readinessTimeout() time.Duration {
+	return time.Duration(configs.GlobalConfig.Server.ReadinessTimeout) * time.Millisecond
+}
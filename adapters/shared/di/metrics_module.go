@@ -0,0 +1,94 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hinsun-backend/adapters/shared/metrics"
+	"hinsun-backend/configs"
+	"hinsun-backend/internal/core/cache"
+	"hinsun-backend/internal/core/events"
+	"hinsun-backend/internal/core/log"
+	"hinsun-backend/internal/core/worker"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+var MetricsModule = fx.Module("metrics",
+	fx.Provide(ProvideMetrics),
+	fx.Invoke(RegisterMetricsCollectors),
+	fx.Invoke(RegisterMetricsServerHook),
+)
+
+func ProvideMetrics() *metrics.Metrics {
+	return metrics.NewMetrics()
+}
+
+// RegisterMetricsCollectors instruments GORM and exports the state of the pool, event bus, media
+// workers and cache
+func RegisterMetricsCollectors(
+	m *metrics.Metrics,
+	db *gorm.DB,
+	asyncEventBus *events.AsyncEventBus,
+	mediaWorkerPool *worker.Pool,
+	readThroughCache *cache.ReadThrough,
+) error {
+	if err := db.Use(metrics.NewGormPlugin(m)); err != nil {
+		return err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	if err := m.Register(collectors.NewDBStatsCollector(sqlDB, configs.GlobalConfig.Database.Database)); err != nil {
+		return err
+	}
+
+	if err := m.Register(metrics.NewEventBusCollectors(asyncEventBus)...); err != nil {
+		return err
+	}
+
+	if err := m.Register(metrics.NewWorkerPoolCollectors("media_processing", mediaWorkerPool)...); err != nil {
+		return err
+	}
+
+	return m.Register(metrics.NewCacheCollector(readThroughCache))
+}
+
+// RegisterMetricsServerHook serves /metrics on its own port, so it is never exposed with the API
+func RegisterMetricsServerHook(lc fx.Lifecycle, m *metrics.Metrics) {
+	metricsConfig := configs.GlobalConfig.Metrics
+	if !metricsConfig.Enabled {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", metricsConfig.Port),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			go func() {
+				log.Logger.Info("📈 Metrics server starting on address " + server.Addr)
+				if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					log.Logger.Error("Metrics server error: " + err.Error())
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return server.Shutdown(ctx)
+		},
+	})
+}
@@ -0,0 +1,66 @@
+package di
+
+import (
+	"hinsun-backend/adapters/secondary/notifiers"
+	"hinsun-backend/configs"
+	"hinsun-backend/internal/core/events"
+	"hinsun-backend/internal/core/log"
+	"hinsun-backend/internal/domain/devicetoken"
+	"hinsun-backend/internal/domain/notification"
+	"hinsun-backend/pkg/firebase"
+	"hinsun-backend/pkg/mailer"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+// notificationChannelsGroup collects the delivery channels handed to the notification dispatcher
+const notificationChannelsGroup = `group:"notification_channels"`
+
+var NotifierModule = fx.Module("notifiers",
+	fx.Provide(
+		ProvideMailer,
+		fx.Annotate(ProvideInAppChannel, fx.ResultTags(notificationChannelsGroup)),
+		fx.Annotate(ProvidePushChannel, fx.ResultTags(notificationChannelsGroup)),
+		fx.Annotate(ProvideEmailChannel, fx.ResultTags(notificationChannelsGroup)),
+		fx.Annotate(ProvideWebhookChannel, fx.ResultTags(notificationChannelsGroup)),
+		ProvideTopicChannel,
+	),
+)
+
+// ProvideMailer provides the SMTP mailer, or an in-memory fake when mail is disabled
+func ProvideMailer() (mailer.Mailer, error) {
+	mailConfig := configs.GlobalConfig.Mail
+	if !mailConfig.Enabled {
+		log.Logger.Warn("⚠️ Mail is disabled, notification emails are recorded in memory only")
+		return mailer.NewFakeMailer(), nil
+	}
+
+	return mailer.NewSMTPMailer(mailer.SMTPParams{
+		Host:     mailConfig.Host,
+		Port:     mailConfig.Port,
+		Username: mailConfig.Username,
+		Password: mailConfig.Password,
+		From:     mailConfig.From,
+	})
+}
+
+func ProvideInAppChannel(notificationService notification.NotificationService, asyncEventBus *events.AsyncEventBus) notification.NotificationChannel {
+	return notifiers.NewInAppChannel(notificationService, asyncEventBus)
+}
+
+func ProvidePushChannel(deviceTokenService devicetoken.DeviceTokenService, fcmService firebase.FCMService) notification.NotificationChannel {
+	return notifiers.NewPushChannel(deviceTokenService, fcmService)
+}
+
+func ProvideEmailChannel(mailer mailer.Mailer) notification.NotificationChannel {
+	return notifiers.NewEmailChannel(mailer)
+}
+
+func ProvideWebhookChannel() notification.NotificationChannel {
+	return notifiers.NewWebhookChannel(time.Duration(configs.GlobalConfig.Notifier.WebhookTimeout) * time.Second)
+}
+
+func ProvideTopicChannel(fcmService firebase.FCMService) notification.TopicChannel {
+	return notifiers.NewTopicChannel(fcmService)
+}
@@ -2,13 +2,21 @@ package di
 
 import (
 	"hinsun-backend/adapters/secondary/repositories"
+	"hinsun-backend/internal/core/transaction"
+	"hinsun-backend/internal/domain/accesstoken"
 	"hinsun-backend/internal/domain/account"
 	"hinsun-backend/internal/domain/blog"
 	"hinsun-backend/internal/domain/category"
 	"hinsun-backend/internal/domain/comment"
+	"hinsun-backend/internal/domain/devicetoken"
 	"hinsun-backend/internal/domain/experience"
+	"hinsun-backend/internal/domain/media"
 	"hinsun-backend/internal/domain/notification"
 	"hinsun-backend/internal/domain/project"
+	"hinsun-backend/internal/domain/role"
+	"hinsun-backend/internal/domain/subscription"
+	"hinsun-backend/internal/domain/tag"
+	"hinsun-backend/internal/domain/trash"
 
 	"go.uber.org/fx"
 	"gorm.io/gorm"
@@ -18,11 +26,21 @@ var RepositoryModule = fx.Module("repositories",
 	fx.Provide(
 		ProvideExperienceRepository,
 		ProvideNotificationRepository,
+		ProvidePreferenceRepository,
 		ProvideAccountRepository,
 		ProvideBlogRepository,
 		ProvideProjectRepository,
 		ProvideCategoryRepository,
+		ProvideTagRepository,
 		ProvideCommentRepository,
+		ProvideRoleRepository,
+		ProvideAccessTokenRepository,
+		ProvideTrashRepository,
+		ProvideDeviceTokenRepository,
+		ProvideFollowRepository,
+		ProvideEmailSubscriptionRepository,
+		ProvideMediaRepository,
+		ProvideUnitOfWork,
 	),
 )
 
@@ -34,6 +52,10 @@ func ProvideNotificationRepository(db *gorm.DB) notification.NotificationReposit
 	return repositories.NewNotificationRepository(db)
 }
 
+func ProvidePreferenceRepository(db *gorm.DB) notification.PreferenceRepository {
+	return repositories.NewPreferenceRepository(db)
+}
+
 func ProvideAccountRepository(db *gorm.DB) account.AccountRepository {
 	return repositories.NewAccountRepository(db)
 }
@@ -50,6 +72,42 @@ func ProvideCategoryRepository(db *gorm.DB) category.CategoryRepository {
 	return repositories.NewCategoryRepository(db)
 }
 
+func ProvideTagRepository(db *gorm.DB) tag.TagRepository {
+	return repositories.NewTagRepository(db)
+}
+
 func ProvideCommentRepository(db *gorm.DB) comment.CommentRepository {
 	return repositories.NewCommentRepository(db)
 }
+
+func ProvideRoleRepository(db *gorm.DB) role.RoleRepository {
+	return repositories.NewRoleRepository(db)
+}
+
+func ProvideAccessTokenRepository(db *gorm.DB) accesstoken.AccessTokenRepository {
+	return repositories.NewAccessTokenRepository(db)
+}
+
+func ProvideTrashRepository(db *gorm.DB) trash.TrashRepository {
+	return repositories.NewTrashRepository(db)
+}
+
+func ProvideDeviceTokenRepository(db *gorm.DB) devicetoken.DeviceTokenRepository {
+	return repositories.NewDeviceTokenRepository(db)
+}
+
+func ProvideFollowRepository(db *gorm.DB) subscription.FollowRepository {
+	return repositories.NewFollowRepository(db)
+}
+
+func ProvideEmailSubscriptionRepository(db *gorm.DB) subscription.EmailSubscriptionRepository {
+	return repositories.NewEmailSubscriptionRepository(db)
+}
+
+func ProvideMediaRepository(db *gorm.DB) media.MediaRepository {
+	return repositories.NewMediaRepository(db)
+}
+
+func ProvideUnitOfWork(db *gorm.DB) transaction.UnitOfWork {
+	return repositories.NewUnitOfWork(db)
+}
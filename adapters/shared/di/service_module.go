@@ -1,16 +1,30 @@
 package di
 
 import (
+	"context"
+	"fmt"
+	"hinsun-backend/adapters/secondary/apis"
+	"hinsun-backend/configs"
+	"hinsun-backend/internal/core/log"
+	"hinsun-backend/internal/domain/accesstoken"
 	"hinsun-backend/internal/domain/account"
 	"hinsun-backend/internal/domain/auth"
 	"hinsun-backend/internal/domain/blog"
 	"hinsun-backend/internal/domain/category"
 	"hinsun-backend/internal/domain/comment"
+	"hinsun-backend/internal/domain/devicetoken"
 	"hinsun-backend/internal/domain/experience"
+	"hinsun-backend/internal/domain/media"
 	"hinsun-backend/internal/domain/notification"
 	"hinsun-backend/internal/domain/project"
+	"hinsun-backend/internal/domain/role"
+	"hinsun-backend/internal/domain/subscription"
+	"hinsun-backend/internal/domain/tag"
+	"hinsun-backend/internal/domain/trash"
 	"hinsun-backend/pkg/jwt"
 	"hinsun-backend/pkg/security"
+	"hinsun-backend/pkg/storage"
+	"time"
 
 	"go.uber.org/fx"
 )
@@ -24,38 +38,223 @@ var ServiceModule = fx.Module("services",
 		ProvideBlogService,
 		ProvideProjectService,
 		ProvideCategoryService,
+		ProvideTagService,
 		ProvideCommentService,
+		ProvideRoleService,
+		ProvideAccessTokenService,
+		ProvideTrashService,
+		ProvideDeviceTokenService,
+		ProvideSubscriptionService,
+		ProvideMediaService,
+		ProvideRepositoryMetadataProvider,
 	),
+	fx.Invoke(RegisterSystemRolesHook),
+	fx.Invoke(RegisterTrashPurgeHook),
+	fx.Invoke(RegisterMediaGCHook),
 )
 
 func ProvideExperienceService(repository experience.ExperienceRepository) experience.ExperienceService {
 	return experience.NewExperienceService(repository)
 }
 
-func ProvideNotificationService(repository notification.NotificationRepository) notification.NotificationService {
-	return notification.NewNotificationService(repository)
+func ProvideNotificationService(
+	repository notification.NotificationRepository,
+	preferenceRepository notification.PreferenceRepository,
+) notification.NotificationService {
+	return notification.NewNotificationService(repository, preferenceRepository)
 }
 
 func ProvideAccountService(repository account.AccountRepository) account.AccountService {
-	return account.NewAccountService(repository)
+	twoFactorConfig := configs.GlobalConfig.TwoFactor
+	return account.NewAccountService(
+		repository,
+		twoFactorConfig.MaxAttempts,
+		time.Duration(twoFactorConfig.LockoutDuration)*time.Second,
+	)
 }
 
-func ProvideAuthService(passwordHasher security.PasswordHasher, jwtService jwt.JwtService) auth.AuthService {
-	return auth.NewAuthService(passwordHasher, jwtService)
+func ProvideAuthService(
+	passwordHasher security.PasswordHasher,
+	jwtService jwt.JwtService,
+	totp security.TOTP,
+	secretBox security.SecretBox,
+) auth.AuthService {
+	return auth.NewAuthService(passwordHasher, jwtService, totp, secretBox, configs.GlobalConfig.TwoFactor.RecoveryCodeCount)
 }
 
 func ProvideBlogService(repository blog.BlogRepository) blog.BlogService {
 	return blog.NewBlogService(repository)
 }
 
-func ProvideProjectService(repository project.ProjectRepository) project.ProjectService {
-	return project.NewProjectService(repository)
+func ProvideProjectService(repository project.ProjectRepository, metadataProvider project.RepositoryMetadataProvider) project.ProjectService {
+	return project.NewProjectService(repository, metadataProvider)
 }
 
 func ProvideCategoryService(repository category.CategoryRepository) category.CategoryService {
 	return category.NewCategoryService(repository)
 }
 
+func ProvideTagService(repository tag.TagRepository) tag.TagService {
+	return tag.NewTagService(repository)
+}
+
 func ProvideCommentService(repository comment.CommentRepository) comment.CommentService {
 	return comment.NewCommentService(repository)
 }
+
+func ProvideRoleService(repository role.RoleRepository) role.RoleService {
+	return role.NewRoleService(repository)
+}
+
+func ProvideAccessTokenService(repository accesstoken.AccessTokenRepository, passwordHasher security.PasswordHasher) accesstoken.AccessTokenService {
+	return accesstoken.NewAccessTokenService(repository, passwordHasher)
+}
+
+func ProvideTrashService(repository trash.TrashRepository) trash.TrashService {
+	return trash.NewTrashService(repository)
+}
+
+func ProvideDeviceTokenService(repository devicetoken.DeviceTokenRepository) devicetoken.DeviceTokenService {
+	return devicetoken.NewDeviceTokenService(repository)
+}
+
+func ProvideSubscriptionService(
+	followRepository subscription.FollowRepository,
+	emailSubscriptionRepository subscription.EmailSubscriptionRepository,
+) subscription.SubscriptionService {
+	return subscription.NewSubscriptionService(followRepository, emailSubscriptionRepository)
+}
+
+func ProvideMediaService(repository media.MediaRepository, blobStorage storage.BlobStorage) media.MediaService {
+	maxUploadSize := int64(configs.GlobalConfig.Media.MaxUploadSize) << 20
+	return media.NewMediaService(repository, blobStorage, maxUploadSize)
+}
+
+// ProvideRepositoryMetadataProvider provides the GitHub client reading the project repositories
+func ProvideRepositoryMetadataProvider() project.RepositoryMetadataProvider {
+	githubConfig := configs.GlobalConfig.Github
+	return apis.NewGithubMetadataProvider(githubConfig.APIURL, githubConfig.Token, time.Duration(githubConfig.Timeout)*time.Second)
+}
+
+// RegisterSystemRolesHook seeds the built-in roles so legacy accounts keep their access
+func RegisterSystemRolesHook(lc fx.Lifecycle, roleService role.RoleService) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			return roleService.EnsureSystemRoles(ctx)
+		},
+	})
+}
+
+// RegisterTrashPurgeHook periodically removes items that outlived the trash retention
+func RegisterTrashPurgeHook(lc fx.Lifecycle, trashService trash.TrashService) {
+	trashConfig := configs.GlobalConfig.Trash
+	if trashConfig.PurgeInterval <= 0 {
+		return
+	}
+
+	interval := time.Duration(trashConfig.PurgeInterval) * time.Second
+	retention := time.Duration(trashConfig.RetentionDays) * 24 * time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	purge := func() {
+		purged, err := trashService.PurgeExpired(ctx, retention)
+		if err != nil {
+			log.Logger.Error(fmt.Sprintf("❌ Failed to purge trash: %v", err))
+		}
+
+		for resource, count := range purged {
+			if count > 0 {
+				log.Logger.Info(fmt.Sprintf("🗑️ Purged %d %s from trash", count, resource))
+			}
+		}
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+
+				purge()
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						purge()
+					}
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+
+			return nil
+		},
+	})
+}
+
+// RegisterMediaGCHook periodically deletes the uploaded files nothing points at anymore
+func RegisterMediaGCHook(lc fx.Lifecycle, mediaService media.MediaService) {
+	mediaConfig := configs.GlobalConfig.Media
+	if mediaConfig.GCInterval <= 0 {
+		return
+	}
+
+	interval := time.Duration(mediaConfig.GCInterval) * time.Second
+	gracePeriod := time.Duration(mediaConfig.GCGracePeriod) * time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	collect := func() {
+		collected, err := mediaService.CollectGarbage(ctx, gracePeriod)
+		if err != nil {
+			log.Logger.Error(fmt.Sprintf("❌ Failed to collect unused media: %v", err))
+		}
+
+		if collected > 0 {
+			log.Logger.Info(fmt.Sprintf("🧹 Deleted %d unused media files", collected))
+		}
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+
+				collect()
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						collect()
+					}
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+
+			return nil
+		},
+	})
+}
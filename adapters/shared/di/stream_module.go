@@ -0,0 +1,18 @@
+package di
+
+import (
+	"hinsun-backend/adapters/shared/streaming"
+	"hinsun-backend/configs"
+
+	"go.uber.org/fx"
+)
+
+var StreamModule = fx.Module("stream",
+	fx.Provide(ProvideStreamBroker),
+)
+
+// ProvideStreamBroker provides the broker behind the Server-Sent Events endpoint
+func ProvideStreamBroker() *streaming.Broker {
+	streamConfig := configs.GlobalConfig.Stream
+	return streaming.NewBroker(streamConfig.MaxConnectionsPerAccount, streamConfig.ReplayBufferSize)
+}
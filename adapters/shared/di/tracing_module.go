@@ -0,0 +1,43 @@
+package di
+
+import (
+	"context"
+	"hinsun-backend/adapters/shared/tracing"
+	"hinsun-backend/configs"
+	"hinsun-backend/internal/core/log"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+var TracingModule = fx.Module("tracing",
+	fx.Invoke(RegisterTracingHook),
+)
+
+// RegisterTracingHook installs the global tracer provider and traces GORM statements. Without
+// TRACING_ENABLED the global no-op provider stays in place and spans cost nothing.
+func RegisterTracingHook(lc fx.Lifecycle, db *gorm.DB) error {
+	if !configs.GlobalConfig.Tracing.Enabled {
+		return nil
+	}
+
+	provider, err := tracing.NewTracerProvider(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if err := db.Use(tracing.NewGormPlugin()); err != nil {
+		return err
+	}
+
+	log.Logger.Info("🔭 Tracing enabled with the " + configs.GlobalConfig.Tracing.Exporter + " exporter")
+
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			// Flushes the spans still waiting in the batch processor
+			return provider.Shutdown(ctx)
+		},
+	})
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Pinger is implemented by clients whose connection can be checked, e.g. the Redis cache
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Check is one dependency the service needs to serve traffic
+type Check struct {
+	Name    string
+	Timeout time.Duration
+	Run     func(ctx context.Context) error
+}
+
+type CheckResult struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latencyMs"`
+	Error     string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status   string        `json:"status"`
+	Draining bool          `json:"draining,omitempty"`
+	Checks   []CheckResult `json:"checks"`
+}
+
+// Readiness runs the dependency checks and reports whether the instance should receive traffic
+type Readiness struct {
+	checks   []Check
+	draining atomic.Bool
+}
+
+func NewReadiness(checks []Check) *Readiness {
+	return &Readiness{checks: checks}
+}
+
+// Drain reports the instance as not ready from now on, so load balancers stop routing to it
+// while in-flight requests finish during graceful shutdown
+func (r *Readiness) Drain() {
+	r.draining.Store(true)
+}
+
+// Check runs every check concurrently, each bounded by its own timeout
+func (r *Readiness) Check(ctx context.Context) *Report {
+	report := &Report{
+		Status:   StatusUp,
+		Draining: r.draining.Load(),
+		Checks:   make([]CheckResult, len(r.checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, check := range r.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = runCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	if report.Draining {
+		report.Status = StatusDown
+	}
+
+	for _, result := range report.Checks {
+		if result.Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+
+	return report
+}
+
+func runCheck(ctx context.Context, check Check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, check.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Run(ctx)
+	result := CheckResult{
+		Name:      check.Name,
+		Status:    StatusUp,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
@@ -0,0 +1,44 @@
+package https
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SetETag exposes the version of a resource as a strong entity tag
+func SetETag(w http.ResponseWriter, version int64) {
+	w.Header().Set("ETag", FormatETag(version))
+}
+
+func FormatETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// SetLastModified exposes the update time of a resource, given in unix seconds
+func SetLastModified(w http.ResponseWriter, updatedAt int64) {
+	w.Header().Set("Last-Modified", time.Unix(updatedAt, 0).UTC().Format(http.TimeFormat))
+}
+
+// ParseIfMatch reads the version the client expects from the If-Match header.
+// It returns nil when the header is absent or "*", so the update is unconditional.
+func ParseIfMatch(r *http.Request) (*int64, error) {
+	ifMatch := strings.TrimSpace(r.Header.Get("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return nil, nil
+	}
+
+	tag, err := strconv.Unquote(strings.TrimPrefix(ifMatch, "W/"))
+	if err != nil {
+		return nil, errors.New("If-Match must be a single quoted entity tag")
+	}
+
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil {
+		return nil, errors.New("If-Match does not hold a version of this resource")
+	}
+
+	return &version, nil
+}
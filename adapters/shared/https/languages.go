@@ -0,0 +1,85 @@
+package https
+
+import (
+	"hinsun-backend/internal/domain/values"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PreferredLanguages reads the languages the client asks for: the lang query parameter first, then
+// Accept-Language by descending quality. Region subtags are ignored (vi-VN asks for vi) and
+// unsupported languages skipped. It returns nil when the client expressed no preference, the
+// response then carries every language.
+func PreferredLanguages(r *http.Request) []values.MarkdownLanguageCode {
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		if language, err := values.FromStringToMarkdownLanguageCode(strings.ToLower(lang)); err == nil {
+			return []values.MarkdownLanguageCode{language}
+		}
+	}
+
+	type weighted struct {
+		language values.MarkdownLanguageCode
+		quality  float64
+	}
+
+	var candidates []weighted
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+
+		language, err := values.FromStringToMarkdownLanguageCode(primary)
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				quality = parsed
+			}
+		}
+
+		if quality > 0 {
+			candidates = append(candidates, weighted{language: language, quality: quality})
+		}
+	}
+
+	// Stable, so equal qualities keep the order of the header
+	slices.SortStableFunc(candidates, func(a, b weighted) int {
+		switch {
+		case a.quality > b.quality:
+			return -1
+		case a.quality < b.quality:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	var languages []values.MarkdownLanguageCode
+	for _, candidate := range candidates {
+		if !slices.Contains(languages, candidate.language) {
+			languages = append(languages, candidate.language)
+		}
+	}
+
+	return languages
+}
+
+// SetContentLanguage tells which language a localized response is in
+func SetContentLanguage(w http.ResponseWriter, language values.MarkdownLanguageCode) {
+	w.Header().Set("Content-Language", string(language))
+}
+
+// VaryLanguage tells caches the response depends on Accept-Language, whether or not it was sent
+func VaryLanguage(w http.ResponseWriter) {
+	w.Header().Add("Vary", "Accept-Language")
+}
+
+// SetLocalizedETag tags a localized response, it differs from the ETag of the full resource so a
+// cached translation is never revalidated as another one. It cannot be used in If-Match.
+func SetLocalizedETag(w http.ResponseWriter, version int64, language values.MarkdownLanguageCode) {
+	w.Header().Set("ETag", strconv.Quote(strconv.FormatInt(version, 10)+"-"+string(language)))
+}
@@ -0,0 +1,30 @@
+package https
+
+import (
+	"errors"
+	"hinsun-backend/pkg/mergepatch"
+	"io"
+	"mime"
+	"net/http"
+)
+
+const (
+	MergePatchContentType = "application/merge-patch+json"
+	maxPatchBodyBytes     = 1 << 20
+)
+
+// DecodeMergePatch applies the JSON merge patch in the request body to target,
+// which must already hold the current state of the resource
+func DecodeMergePatch(r *http.Request, target any) error {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || (mediaType != MergePatchContentType && mediaType != "application/json") {
+		return errors.New("PATCH requires Content-Type " + MergePatchContentType)
+	}
+
+	patch, err := io.ReadAll(io.LimitReader(r.Body, maxPatchBodyBytes))
+	if err != nil {
+		return err
+	}
+
+	return mergepatch.ApplyTo(target, patch)
+}
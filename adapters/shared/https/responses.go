@@ -80,6 +80,10 @@ func RespondWithFailure(w http.ResponseWriter, err error) {
 	// Check if it's a domain failure
 	if domainFailure, ok := failure.AsFailure(err); ok {
 		statusCode, response := mapFailureToResponse(domainFailure)
+		if currentVersion, ok := domainFailure.Details[failure.CurrentVersionDetail].(int64); ok {
+			SetETag(w, currentVersion)
+		}
+
 		w.WriteHeader(statusCode)
 		json.NewEncoder(w).Encode(response)
 		return
@@ -137,6 +141,8 @@ func mapFailureCodeToHTTPStatus(code failure.FailureCode) int {
 		return http.StatusUnprocessableEntity
 	case failure.DatabaseFailure:
 		return http.StatusBadRequest
+	case failure.TooManyRequestsFailure:
+		return http.StatusTooManyRequests
 	default:
 		return http.StatusInternalServerError
 	}
@@ -144,10 +150,17 @@ func mapFailureCodeToHTTPStatus(code failure.FailureCode) int {
 
 func mapFailureToResponse(f *failure.Failure) (int, FailureResponse) {
 	statusCode := mapFailureCodeToHTTPStatus(f.Code)
+
+	// Stale writes are conflicts in the domain, but a failed If-Match precondition over HTTP
+	if _, ok := f.Details[failure.CurrentVersionDetail]; ok && f.Code == failure.ConflictFailure {
+		statusCode = http.StatusPreconditionFailed
+	}
+
 	if f.Cause == nil {
 		response := FailureResponse{
 			Code:    string(f.Code),
 			Message: f.Message,
+			Details: f.Details,
 		}
 
 		return statusCode, response
@@ -157,6 +170,7 @@ func mapFailureToResponse(f *failure.Failure) (int, FailureResponse) {
 		Code:     string(f.Code),
 		Message:  f.Message,
 		CausedBy: f.Cause.Error(),
+		Details:  f.Details,
 	}
 
 	return statusCode, response
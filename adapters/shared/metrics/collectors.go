@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"hinsun-backend/internal/core/cache"
+	"hinsun-backend/internal/core/events"
+	"hinsun-backend/internal/core/worker"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NewEventBusCollectors exports the number of running handlers and the handler failures of the bus
+func NewEventBusCollectors(eventBus *events.AsyncEventBus) []prometheus.Collector {
+	return []prometheus.Collector{
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "event_bus",
+			Name:      "pending_handlers",
+			Help:      "Event handlers started and not yet finished.",
+		}, func() float64 {
+			return float64(eventBus.Pending())
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "event_bus",
+			Name:      "handler_failures_total",
+			Help:      "Event handler calls that returned an error.",
+		}, func() float64 {
+			return float64(eventBus.Failures())
+		}),
+	}
+}
+
+// NewWorkerPoolCollectors exports the number of queued or running jobs and the job failures of a pool
+func NewWorkerPoolCollectors(subsystem string, pool *worker.Pool) []prometheus.Collector {
+	return []prometheus.Collector{
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "pending_jobs",
+			Help:      "Jobs queued or running.",
+		}, func() float64 {
+			return float64(pool.Pending())
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "job_failures_total",
+			Help:      "Jobs that returned an error or panicked.",
+		}, func() float64 {
+			return float64(pool.Failures())
+		}),
+	}
+}
+
+type cacheCollector struct {
+	cache    *cache.ReadThrough
+	requests *prometheus.Desc
+}
+
+// NewCacheCollector exports the hit and miss counts of the read-through cache per namespace
+func NewCacheCollector(readThroughCache *cache.ReadThrough) prometheus.Collector {
+	return &cacheCollector{
+		cache: readThroughCache,
+		requests: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "cache", "requests_total"),
+			"Read-through cache lookups by namespace and result (hit or miss).",
+			[]string{"namespace", "result"},
+			nil,
+		),
+	}
+}
+
+func (c *cacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.requests
+}
+
+func (c *cacheCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, stats := range c.cache.Stats() {
+		ch <- prometheus.MustNewConstMetric(c.requests, prometheus.CounterValue, float64(stats.Hits), stats.Namespace, "hit")
+		ch <- prometheus.MustNewConstMetric(c.requests, prometheus.CounterValue, float64(stats.Misses), stats.Namespace, "miss")
+	}
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const gormStartKey = "metrics:start"
+
+type gormPlugin struct {
+	metrics *Metrics
+}
+
+// NewGormPlugin observes the duration of every statement GORM executes
+func NewGormPlugin(metrics *Metrics) gorm.Plugin {
+	return &gormPlugin{metrics: metrics}
+}
+
+func (p *gormPlugin) Name() string {
+	return "metrics"
+}
+
+func (p *gormPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	return errors.Join(
+		callback.Create().Before("gorm:create").Register("metrics:before_create", p.start),
+		callback.Create().After("gorm:create").Register("metrics:after_create", p.observe("create")),
+		callback.Query().Before("gorm:query").Register("metrics:before_query", p.start),
+		callback.Query().After("gorm:query").Register("metrics:after_query", p.observe("query")),
+		callback.Update().Before("gorm:update").Register("metrics:before_update", p.start),
+		callback.Update().After("gorm:update").Register("metrics:after_update", p.observe("update")),
+		callback.Delete().Before("gorm:delete").Register("metrics:before_delete", p.start),
+		callback.Delete().After("gorm:delete").Register("metrics:after_delete", p.observe("delete")),
+		callback.Row().Before("gorm:row").Register("metrics:before_row", p.start),
+		callback.Row().After("gorm:row").Register("metrics:after_row", p.observe("row")),
+		callback.Raw().Before("gorm:raw").Register("metrics:before_raw", p.start),
+		callback.Raw().After("gorm:raw").Register("metrics:after_raw", p.observe("raw")),
+	)
+}
+
+func (p *gormPlugin) start(db *gorm.DB) {
+	db.InstanceSet(gormStartKey, time.Now())
+}
+
+func (p *gormPlugin) observe(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(gormStartKey)
+		if !ok {
+			return
+		}
+
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		p.metrics.dbQueryDuration.WithLabelValues(operation, db.Statement.Table).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"hinsun-backend/pkg/security"
+	"time"
+)
+
+type instrumentedPasswordHasher struct {
+	next    security.PasswordHasher
+	metrics *Metrics
+}
+
+// InstrumentPasswordHasher observes how long hashing and verification take, which tracks
+// the cost of the Argon2 parameters on the hardware the service runs on
+func InstrumentPasswordHasher(next security.PasswordHasher, metrics *Metrics) security.PasswordHasher {
+	return &instrumentedPasswordHasher{
+		next:    next,
+		metrics: metrics,
+	}
+}
+
+func (h *instrumentedPasswordHasher) Hash(password string) (string, error) {
+	start := time.Now()
+	defer h.observe("hash", start)
+
+	return h.next.Hash(password)
+}
+
+func (h *instrumentedPasswordHasher) Verify(password, hash string) (bool, error) {
+	start := time.Now()
+	defer h.observe("verify", start)
+
+	return h.next.Verify(password, hash)
+}
+
+func (h *instrumentedPasswordHasher) observe(operation string, start time.Time) {
+	h.metrics.passwordHashDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"hinsun-backend/adapters/shared/https"
 	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/core/log"
+	"hinsun-backend/internal/domain/accesstoken"
 	"hinsun-backend/pkg/jwt"
 	"net/http"
 	"strings"
@@ -15,17 +17,24 @@ const (
 	ClaimsContextKey contextKey = "claims"
 )
 
+// AccessTokenAuthenticator resolves a personal access token into the claims of its owner
+type AccessTokenAuthenticator interface {
+	AuthenticateAccessToken(ctx context.Context, token string) (*jwt.Claims, error)
+}
+
 type AuthMiddleware struct {
-	jwtService jwt.JwtService
+	jwtService               jwt.JwtService
+	accessTokenAuthenticator AccessTokenAuthenticator
 }
 
-func NewAuthMiddleware(jwtService jwt.JwtService) *AuthMiddleware {
+func NewAuthMiddleware(jwtService jwt.JwtService, accessTokenAuthenticator AccessTokenAuthenticator) *AuthMiddleware {
 	return &AuthMiddleware{
-		jwtService: jwtService,
+		jwtService:               jwtService,
+		accessTokenAuthenticator: accessTokenAuthenticator,
 	}
 }
 
-// RequireAuth validates JWT access token
+// RequireAuth validates a JWT access token or a personal access token
 func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Extract token from Authorization header
@@ -44,6 +53,18 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 
 		token := parts[1]
 
+		// Personal access tokens carry their own prefix and are checked against the database
+		if accesstoken.IsAccessToken(token) {
+			claims, err := m.accessTokenAuthenticator.AuthenticateAccessToken(r.Context(), token)
+			if err != nil {
+				https.RespondWithFailure(w, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(contextWithClaims(r.Context(), claims)))
+			return
+		}
+
 		// Validate token
 		claims, err := m.jwtService.ValidateAccessToken(token)
 		if err != nil {
@@ -52,8 +73,7 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 		}
 
 		// Add claims to context
-		ctx := context.WithValue(r.Context(), ClaimsContextKey, claims)
-		next.ServeHTTP(w, r.WithContext(ctx))
+		next.ServeHTTP(w, r.WithContext(contextWithClaims(r.Context(), claims)))
 	})
 }
 
@@ -62,3 +82,9 @@ func GetClaimsFromContext(ctx context.Context) (*jwt.Claims, bool) {
 	claims, ok := ctx.Value(ClaimsContextKey).(*jwt.Claims)
 	return claims, ok
 }
+
+// contextWithClaims stores the claims and tags the request logs with the authenticated account
+func contextWithClaims(ctx context.Context, claims *jwt.Claims) context.Context {
+	ctx = context.WithValue(ctx, ClaimsContextKey, claims)
+	return log.ContextWithLogContext(ctx, log.NewLogContext().WithAccountID(claims.AccountID))
+}
@@ -0,0 +1,123 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// NoStorePolicy keeps authenticated and administrative responses out of every cache
+	NoStorePolicy = "private, no-store"
+)
+
+// CachePolicies holds the Cache-Control value of each public route group
+type CachePolicies struct {
+	Blogs       string
+	Projects    string
+	Experiences string
+	Categories  string
+	Tags        string
+}
+
+// NoStore marks every response as uncacheable unless a later middleware allows it
+func NoStore(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", NoStorePolicy)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// PublicCache makes successful anonymous GET responses cacheable with the given Cache-Control
+// policy and answers conditional requests. Responses without an ETag from the handler get one
+// computed from the body, and If-None-Match / If-Modified-Since are answered with 304.
+// Requests carrying credentials stay private, since the same route may serve them more data.
+func PublicCache(policy string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			recorder := &bufferedResponseWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+
+			if recorder.status != http.StatusOK {
+				recorder.flush()
+				return
+			}
+
+			header := w.Header()
+			if r.Header.Get("Authorization") == "" {
+				header.Set("Cache-Control", policy)
+			} else {
+				header.Set("Cache-Control", NoStorePolicy)
+			}
+
+			if header.Get("ETag") == "" {
+				sum := sha256.Sum256(recorder.body.Bytes())
+				header.Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+			}
+
+			if isNotModified(r, header) {
+				header.Del("Content-Type")
+				header.Del("Content-Length")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+
+			recorder.flush()
+		})
+	}
+}
+
+// isNotModified evaluates the preconditions of RFC 9110, If-None-Match wins over If-Modified-Since
+func isNotModified(r *http.Request, header http.Header) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		etag := strings.TrimPrefix(header.Get("ETag"), "W/")
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	ifModifiedSince, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	lastModified, err := http.ParseTime(header.Get("Last-Modified"))
+	if err != nil {
+		return false
+	}
+
+	return !lastModified.Truncate(time.Second).After(ifModifiedSince)
+}
+
+// bufferedResponseWriter holds the response back until the validators have been checked
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedResponseWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(w.body.Bytes())
+}
@@ -0,0 +1,67 @@
+package middlewares
+
+import (
+	"context"
+	"hinsun-backend/adapters/shared/https"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/values"
+	"hinsun-backend/pkg/jwt"
+	"net/http"
+)
+
+type PermissionMiddleware struct {
+	// requireTwoFactorForAdmin rejects sessions that did not pass a second factor on every
+	// permission-protected route, since only administrative accounts hold permissions
+	requireTwoFactorForAdmin bool
+}
+
+func NewPermissionMiddleware(requireTwoFactorForAdmin bool) *PermissionMiddleware {
+	return &PermissionMiddleware{
+		requireTwoFactorForAdmin: requireTwoFactorForAdmin,
+	}
+}
+
+// RequirePermission checks if the token grants every given permission
+func (m *PermissionMiddleware) RequirePermission(permissions ...values.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Get claims from context (set by AuthMiddleware)
+			claims, ok := GetClaimsFromContext(r.Context())
+			if !ok {
+				https.RespondWithFailure(w, failure.NewAuthenticationFailure("authentication required"))
+				return
+			}
+
+			for _, permission := range permissions {
+				if !claims.HasPermission(string(permission)) {
+					https.RespondWithFailure(w, failure.NewForbiddenFailure("missing permission "+string(permission)))
+					return
+				}
+			}
+
+			// Administrative routes require a two-factor session when enforced by configuration
+			if m.requireTwoFactorForAdmin && !claims.TwoFactor {
+				https.RespondWithFailure(w, failure.NewForbiddenFailure("two-factor authentication is required for this action"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// GetActorFromContext builds the use case actor from the claims in the request context
+func GetActorFromContext(ctx context.Context) (*values.Actor, bool) {
+	claims, ok := GetClaimsFromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	actor := values.NewActor(claims.AccountID, claims.Permissions)
+	actor.TwoFactor = claims.TwoFactor
+	if claims.Purpose == jwt.AccessTokenPurpose {
+		actor.AccessTokenID = claims.JTI
+	}
+
+	return actor, true
+}
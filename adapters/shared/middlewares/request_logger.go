@@ -0,0 +1,17 @@
+package middlewares
+
+import (
+	"hinsun-backend/internal/core/log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// RequestLogger puts the request ID into the log context, so log.FromContext(ctx) tags every
+// line written while serving the request. Must run after middleware.RequestID.
+func RequestLogger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lc := log.NewLogContext().WithRequestID(middleware.GetReqID(r.Context()))
+		next.ServeHTTP(w, r.WithContext(log.ContextWithLogContext(r.Context(), lc)))
+	})
+}
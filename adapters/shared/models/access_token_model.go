@@ -0,0 +1,69 @@
+package models
+
+import (
+	"hinsun-backend/internal/domain/accesstoken"
+	"hinsun-backend/internal/domain/values"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type AccessTokenModel struct {
+	ID         uuid.UUID      `gorm:"primaryKey;type:uuid;default:uuidv7()"`
+	AccountID  uuid.UUID      `gorm:"type:uuid;index;not null"`
+	Name       string         `gorm:"type:varchar(100);not null"`
+	LookupID   string         `gorm:"type:varchar(16);uniqueIndex;not null"`
+	SecretHash string         `gorm:"type:varchar(255);not null"`
+	Scopes     pq.StringArray `gorm:"type:text[];not null;default:'{}'"`
+	TwoFactor  bool           `gorm:"type:boolean;default:false;not null"`
+	ExpiresAt  *int64         `gorm:"type:bigint"`
+	LastUsedAt *int64         `gorm:"type:bigint"`
+	RevokedAt  *int64         `gorm:"type:bigint"`
+	CreatedAt  int64          `gorm:"autoCreateTime"`
+
+	Account AccountModel `gorm:"foreignKey:AccountID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (AccessTokenModel) TableName() string { return "access_tokens" }
+
+func (m *AccessTokenModel) ToEntity() *accesstoken.AccessTokenEntity {
+	scopes := make([]values.Permission, 0, len(m.Scopes))
+	for _, s := range m.Scopes {
+		scopes = append(scopes, values.Permission(s))
+	}
+
+	return &accesstoken.AccessTokenEntity{
+		ID:         m.ID,
+		AccountID:  m.AccountID,
+		Name:       m.Name,
+		LookupID:   m.LookupID,
+		SecretHash: m.SecretHash,
+		Scopes:     scopes,
+		TwoFactor:  m.TwoFactor,
+		ExpiresAt:  m.ExpiresAt,
+		LastUsedAt: m.LastUsedAt,
+		RevokedAt:  m.RevokedAt,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+func FromAccessTokenEntity(entity *accesstoken.AccessTokenEntity) AccessTokenModel {
+	scopes := make(pq.StringArray, 0, len(entity.Scopes))
+	for _, s := range entity.Scopes {
+		scopes = append(scopes, string(s))
+	}
+
+	return AccessTokenModel{
+		ID:         entity.ID,
+		AccountID:  entity.AccountID,
+		Name:       entity.Name,
+		LookupID:   entity.LookupID,
+		SecretHash: entity.SecretHash,
+		Scopes:     scopes,
+		TwoFactor:  entity.TwoFactor,
+		ExpiresAt:  entity.ExpiresAt,
+		LastUsedAt: entity.LastUsedAt,
+		RevokedAt:  entity.RevokedAt,
+		CreatedAt:  entity.CreatedAt,
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"hinsun-backend/internal/domain/values"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type AccountModel struct {
@@ -20,10 +21,19 @@ type AccountModel struct {
 	Bio           string    `gorm:"type:text"`
 	CreatedAt     int64     `gorm:"autoCreateTime"`
 	UpdatedAt     int64     `gorm:"autoUpdateTime"`
-	DeletedAt     *int64    `gorm:"index"`
+	Version       int64     `gorm:"not null;default:1"`
+	DeletedAt     DeletedAt `gorm:"index"`
 
-	Blogs    []BlogModel    `gorm:"foreignKey:AuthorID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
-	Comments []CommentModel `gorm:"foreignKey:AuthorID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	TwoFactorEnabled  bool           `gorm:"type:boolean;default:false;not null"`
+	TwoFactorSecret   string         `gorm:"type:varchar(255)"`
+	RecoveryCodes     pq.StringArray `gorm:"type:text[]"`
+	TwoFactorLastStep int64          `gorm:"type:bigint;default:0;not null"`
+	// Written by the attempt counter only, never mapped to the entity
+	TwoFactorFailedAttempts int   `gorm:"type:int;default:0;not null"`
+	TwoFactorLockedUntil    int64 `gorm:"type:bigint;default:0;not null"`
+
+	Blogs    []BlogModel    `gorm:"foreignKey:AuthorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Comments []CommentModel `gorm:"foreignKey:AuthorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
 
 func (AccountModel) TableName() string { return "accounts" }
@@ -51,7 +61,13 @@ func (e *AccountModel) ToEntity() (*account.AccountEntity, error) {
 		Bio:           e.Bio,
 		CreatedAt:     e.CreatedAt,
 		UpdatedAt:     e.UpdatedAt,
-		DeletedAt:     e.DeletedAt,
+		Version:       e.Version,
+		DeletedAt:     e.DeletedAt.Ptr(),
+
+		TwoFactorEnabled:  e.TwoFactorEnabled,
+		TwoFactorSecret:   e.TwoFactorSecret,
+		RecoveryCodes:     e.RecoveryCodes,
+		TwoFactorLastStep: e.TwoFactorLastStep,
 	}, nil
 }
 
@@ -67,6 +83,12 @@ func FromAccountEntity(entity *account.AccountEntity) AccountModel {
 		Bio:           entity.Bio,
 		CreatedAt:     entity.CreatedAt,
 		UpdatedAt:     entity.UpdatedAt,
-		DeletedAt:     entity.DeletedAt,
+		Version:       entity.Version,
+		DeletedAt:     NewDeletedAt(entity.DeletedAt),
+
+		TwoFactorEnabled:  entity.TwoFactorEnabled,
+		TwoFactorSecret:   entity.TwoFactorSecret,
+		RecoveryCodes:     entity.RecoveryCodes,
+		TwoFactorLastStep: entity.TwoFactorLastStep,
 	}
 }
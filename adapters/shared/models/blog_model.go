@@ -25,10 +25,11 @@ type BlogModel struct {
 	EstimatedReadTimeSeconds int64          `gorm:"type:bigint;not null"`
 	CreatedAt                int64          `gorm:"autoCreateTime"`
 	UpdatedAt                int64          `gorm:"autoUpdateTime"`
-	DeletedAt                *int64         `gorm:"index"`
+	Version                  int64          `gorm:"not null;default:1"`
+	DeletedAt                DeletedAt      `gorm:"index"`
 
-	Author   *AccountModel  `gorm:"foreignKey:AuthorID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
-	Comments []CommentModel `gorm:"foreignKey:BlogID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	Author   *AccountModel  `gorm:"foreignKey:AuthorID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Comments []CommentModel `gorm:"foreignKey:BlogID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
 
 func (BlogModel) TableName() string { return "blogs" }
@@ -70,7 +71,8 @@ func (b *BlogModel) ToEntity() *blog.BlogEntity {
 		EstimatedReadTimeSeconds: b.EstimatedReadTimeSeconds,
 		CreatedAt:                b.CreatedAt,
 		UpdatedAt:                b.UpdatedAt,
-		DeletedAt:                b.DeletedAt,
+		Version:                  b.Version,
+		DeletedAt:                b.DeletedAt.Ptr(),
 	}
 }
 
@@ -94,6 +96,7 @@ func FromBlogEntity(b *blog.BlogEntity) BlogModel {
 		EstimatedReadTimeSeconds: b.EstimatedReadTimeSeconds,
 		CreatedAt:                b.CreatedAt,
 		UpdatedAt:                b.UpdatedAt,
-		DeletedAt:                b.DeletedAt,
+		Version:                  b.Version,
+		DeletedAt:                NewDeletedAt(b.DeletedAt),
 	}
 }
@@ -12,7 +12,8 @@ type CategoryModel struct {
 	NumBlogs  int64     `gorm:"type:bigint;default:0;not null"`
 	CreatedAt int64     `gorm:"autoCreateTime:nano"`
 	UpdatedAt int64     `gorm:"autoUpdateTime:nano"`
-	DeletedAt *int64    `gorm:"index"`
+	Version   int64     `gorm:"not null;default:1"`
+	DeletedAt DeletedAt `gorm:"index"`
 }
 
 func (CategoryModel) TableName() string { return "categories" }
@@ -24,7 +25,8 @@ func (c *CategoryModel) ToEntity() *category.CategoryEntity {
 		NumBlogs:  c.NumBlogs,
 		CreatedAt: c.CreatedAt,
 		UpdatedAt: c.UpdatedAt,
-		DeletedAt: c.DeletedAt,
+		Version:   c.Version,
+		DeletedAt: c.DeletedAt.Ptr(),
 	}
 }
 
@@ -35,6 +37,7 @@ func FromCategoryEntity(entity *category.CategoryEntity) CategoryModel {
 		NumBlogs:  entity.NumBlogs,
 		CreatedAt: entity.CreatedAt,
 		UpdatedAt: entity.UpdatedAt,
-		DeletedAt: entity.DeletedAt,
+		Version:   entity.Version,
+		DeletedAt: NewDeletedAt(entity.DeletedAt),
 	}
 }
@@ -15,9 +15,10 @@ type CommentModel struct {
 	Favorites int64         `gorm:"type:bigint;not null;default:0"`
 	CreatedAt int64         `gorm:"autoCreateTime"`
 	UpdatedAt int64         `gorm:"autoUpdateTime"`
-	DeletedAt *int64        `gorm:"index"`
-	Author    *AccountModel `gorm:"foreignKey:AuthorID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
-	Blog      *BlogModel    `gorm:"foreignKey:BlogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	Version   int64         `gorm:"not null;default:1"`
+	DeletedAt DeletedAt     `gorm:"index"`
+	Author    *AccountModel `gorm:"foreignKey:AuthorID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Blog      *BlogModel    `gorm:"foreignKey:BlogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
 
 func (CommentModel) TableName() string { return "comments" }
@@ -32,7 +33,8 @@ func (c *CommentModel) ToEntity() *comment.CommentEntity {
 		Favorites: c.Favorites,
 		CreatedAt: c.CreatedAt,
 		UpdatedAt: c.UpdatedAt,
-		DeletedAt: c.DeletedAt,
+		Version:   c.Version,
+		DeletedAt: c.DeletedAt.Ptr(),
 	}
 }
 
@@ -46,6 +48,7 @@ func FromCommentEntity(entity *comment.CommentEntity) CommentModel {
 		Favorites: entity.Favorites,
 		CreatedAt: entity.CreatedAt,
 		UpdatedAt: entity.UpdatedAt,
-		DeletedAt: entity.DeletedAt,
+		Version:   entity.Version,
+		DeletedAt: NewDeletedAt(entity.DeletedAt),
 	}
 }
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"hinsun-backend/internal/domain/devicetoken"
+
+	"github.com/google/uuid"
+)
+
+type DeviceTokenModel struct {
+	ID         uuid.UUID `gorm:"primaryKey;type:uuid;default:uuidv7()"`
+	AccountID  uuid.UUID `gorm:"type:uuid;index;not null"`
+	Token      string    `gorm:"type:text;uniqueIndex;not null"`
+	Platform   string    `gorm:"type:varchar(16);not null"`
+	CreatedAt  int64     `gorm:"autoCreateTime"`
+	LastSeenAt int64     `gorm:"type:bigint"`
+
+	Account AccountModel `gorm:"foreignKey:AccountID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (DeviceTokenModel) TableName() string { return "device_tokens" }
+
+func (m *DeviceTokenModel) ToEntity() *devicetoken.DeviceTokenEntity {
+	return &devicetoken.DeviceTokenEntity{
+		ID:         m.ID,
+		AccountID:  m.AccountID,
+		Token:      m.Token,
+		Platform:   devicetoken.Platform(m.Platform),
+		CreatedAt:  m.CreatedAt,
+		LastSeenAt: m.LastSeenAt,
+	}
+}
+
+func FromDeviceTokenEntity(entity *devicetoken.DeviceTokenEntity) DeviceTokenModel {
+	return DeviceTokenModel{
+		ID:         entity.ID,
+		AccountID:  entity.AccountID,
+		Token:      entity.Token,
+		Platform:   string(entity.Platform),
+		CreatedAt:  entity.CreatedAt,
+		LastSeenAt: entity.LastSeenAt,
+	}
+}
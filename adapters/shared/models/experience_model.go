@@ -1,7 +1,10 @@
 package models
 
 import (
+	"encoding/json"
 	"hinsun-backend/internal/domain/experience"
+	"hinsun-backend/internal/domain/values"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -10,36 +13,69 @@ import (
 
 type ExperienceModel struct {
 	ID               uuid.UUID      `gorm:"primaryKey;type:uuid;default:uuidv7()"`
-	OrderIdx         int8           `gorm:"type:int;not null"`
-	Position         string         `gorm:"type:varchar(100);not null"`
-	Company          string         `gorm:"type:varchar(100);not null"`
+	OrderIdx         int            `gorm:"type:int;not null"`
+	Languages        pq.StringArray `gorm:"type:text[];not null"`
+	Positions        datatypes.JSON `gorm:"type:jsonb;not null"` // Map of language code -> position
+	Companies        datatypes.JSON `gorm:"type:jsonb;not null"` // Map of language code -> company
 	Location         string         `gorm:"type:varchar(100);not null"`
 	Technologies     pq.StringArray `gorm:"type:text[];not null"`
-	Responsibilities pq.StringArray `gorm:"type:text[];not null"`
-	Period           string         `gorm:"type:varchar(100);not null"`
+	Responsibilities datatypes.JSON `gorm:"type:jsonb;not null"` // Map of language code -> responsibilities
+	StartDate        time.Time      `gorm:"type:date;not null"`
+	EndDate          *time.Time     `gorm:"type:date"` // null while the experience is current
 	Extra            datatypes.JSON `gorm:"type:jsonb"`
 	CreatedAt        int64          `gorm:"autoCreateTime"`
 	UpdatedAt        int64          `gorm:"autoUpdateTime"`
-	DeletedAt        *int64         `gorm:"index"`
+	Version          int64          `gorm:"not null;default:1"`
+	DeletedAt        DeletedAt      `gorm:"index"`
 }
 
 func (ExperienceModel) TableName() string { return "experiences" }
 
 // =================================== CONVERTERS ===================================
 func (e *ExperienceModel) ToEntity() *experience.ExperienceEntity {
+	positions := make(values.MultiLangText)
+	companies := make(values.MultiLangText)
+	responsibilities := make(values.MultiLangList)
+
+	if len(e.Positions) > 0 {
+		json.Unmarshal(e.Positions, &positions)
+	}
+
+	if len(e.Companies) > 0 {
+		json.Unmarshal(e.Companies, &companies)
+	}
+
+	if len(e.Responsibilities) > 0 {
+		json.Unmarshal(e.Responsibilities, &responsibilities)
+	}
+
+	languages, err := values.ConvertStringArrayToMarkdownLanguageCodes(e.Languages)
+	if err != nil {
+		return nil
+	}
+
+	var endDate *values.Date
+	if e.EndDate != nil {
+		date := values.NewDate(*e.EndDate)
+		endDate = &date
+	}
+
 	return &experience.ExperienceEntity{
 		ID:               e.ID,
 		OrderIdx:         e.OrderIdx,
-		Position:         e.Position,
-		Company:          e.Company,
+		Languages:        languages,
+		Positions:        positions,
+		Companies:        companies,
 		Location:         e.Location,
 		Technologies:     e.Technologies,
-		Responsibilities: e.Responsibilities,
-		Period:           e.Period,
+		Responsibilities: responsibilities,
+		StartDate:        values.NewDate(e.StartDate),
+		EndDate:          endDate,
 		Extra:            e.Extra,
 		CreatedAt:        e.CreatedAt,
 		UpdatedAt:        e.UpdatedAt,
-		DeletedAt:        e.DeletedAt,
+		Version:          e.Version,
+		DeletedAt:        e.DeletedAt.Ptr(),
 	}
 }
 
@@ -49,18 +85,30 @@ func FromExperienceEntity(e *experience.ExperienceEntity) ExperienceModel {
 		Extra = datatypes.JSON(e.Extra.(datatypes.JSON))
 	}
 
+	positionsJSON, _ := json.Marshal(e.Positions)
+	companiesJSON, _ := json.Marshal(e.Companies)
+	responsibilitiesJSON, _ := json.Marshal(e.Responsibilities)
+
+	var endDate *time.Time
+	if e.EndDate != nil {
+		endDate = &e.EndDate.Time
+	}
+
 	return ExperienceModel{
 		ID:               e.ID,
 		OrderIdx:         e.OrderIdx,
-		Position:         e.Position,
-		Company:          e.Company,
+		Languages:        values.ConvertMarkdownLanguageCodesToStringArray(e.Languages),
+		Positions:        positionsJSON,
+		Companies:        companiesJSON,
 		Location:         e.Location,
 		Technologies:     e.Technologies,
-		Responsibilities: e.Responsibilities,
-		Period:           e.Period,
+		Responsibilities: responsibilitiesJSON,
+		StartDate:        e.StartDate.Time,
+		EndDate:          endDate,
 		Extra:            Extra,
 		CreatedAt:        e.CreatedAt,
 		UpdatedAt:        e.UpdatedAt,
-		DeletedAt:        e.DeletedAt,
+		Version:          e.Version,
+		DeletedAt:        NewDeletedAt(e.DeletedAt),
 	}
 }
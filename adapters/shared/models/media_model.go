@@ -0,0 +1,72 @@
+package models
+
+import (
+	"encoding/json"
+	"hinsun-backend/internal/domain/media"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+type MediaModel struct {
+	ID          uuid.UUID      `gorm:"primaryKey;type:uuid;default:uuidv7()"`
+	OwnerID     uuid.UUID      `gorm:"type:uuid"`
+	Hash        string         `gorm:"type:varchar(64);index;not null"`
+	Key         string         `gorm:"type:varchar(255);index;not null"`
+	ContentType string         `gorm:"type:varchar(64);not null"`
+	Size        int64          `gorm:"not null"`
+	Variants    datatypes.JSON `gorm:"type:jsonb;not null"` // Map of variant name -> storage key
+	ProcessedAt *int64
+	CreatedAt   int64 `gorm:"autoCreateTime"`
+}
+
+func (MediaModel) TableName() string { return "media" }
+
+func (m *MediaModel) ToEntity() *media.MediaEntity {
+	var variantKeys map[string]string
+	json.Unmarshal(m.Variants, &variantKeys)
+
+	return &media.MediaEntity{
+		ID:          m.ID,
+		OwnerID:     m.OwnerID,
+		Hash:        m.Hash,
+		Key:         m.Key,
+		ContentType: m.ContentType,
+		Size:        m.Size,
+		VariantKeys: variantKeys,
+		ProcessedAt: m.ProcessedAt,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+func FromMediaEntity(entity *media.MediaEntity) MediaModel {
+	return MediaModel{
+		ID:          entity.ID,
+		OwnerID:     entity.OwnerID,
+		Hash:        entity.Hash,
+		Key:         entity.Key,
+		ContentType: entity.ContentType,
+		Size:        entity.Size,
+		Variants:    VariantsJSON(entity.VariantKeys),
+		ProcessedAt: entity.ProcessedAt,
+		CreatedAt:   entity.CreatedAt,
+	}
+}
+
+// VariantsJSON encodes variant keys for the variants column, which holds an object even when empty
+func VariantsJSON(variantKeys map[string]string) datatypes.JSON {
+	if variantKeys == nil {
+		return datatypes.JSON("{}")
+	}
+
+	variantsJSON, _ := json.Marshal(variantKeys)
+	return variantsJSON
+}
+
+type MediaReferenceModel struct {
+	MediaID       uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ReferenceType string    `gorm:"primaryKey;type:varchar(32)"`
+	ReferenceID   uuid.UUID `gorm:"primaryKey;type:uuid"`
+}
+
+func (MediaReferenceModel) TableName() string { return "media_references" }
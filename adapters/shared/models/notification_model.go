@@ -0,0 +1,128 @@
+package models
+
+import (
+	"encoding/json"
+	"hinsun-backend/internal/domain/notification"
+	"hinsun-backend/internal/domain/values"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+type NotificationModel struct {
+	ID        uuid.UUID      `gorm:"primaryKey;type:uuid;default:uuidv7()"`
+	AccountID uuid.UUID      `gorm:"type:uuid;index;not null"`
+	Type      string         `gorm:"type:varchar(32);not null"`
+	Title     string         `gorm:"type:varchar(255);not null"`
+	Content   string         `gorm:"type:text;not null"`
+	Data      datatypes.JSON `gorm:"type:jsonb;not null"`
+	IsRead    bool           `gorm:"type:boolean;default:false;not null"`
+	CreatedAt int64          `gorm:"autoCreateTime"`
+	UpdatedAt int64          `gorm:"autoUpdateTime"`
+
+	Account AccountModel `gorm:"foreignKey:AccountID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (NotificationModel) TableName() string { return "notifications" }
+
+func (m *NotificationModel) ToEntity() *notification.NotificationEntity {
+	data := make(map[string]string)
+	if len(m.Data) > 0 {
+		json.Unmarshal(m.Data, &data)
+	}
+
+	return &notification.NotificationEntity{
+		ID:        m.ID,
+		AccountID: m.AccountID.String(),
+		Type:      notification.EventType(m.Type),
+		Title:     m.Title,
+		Content:   m.Content,
+		Data:      data,
+		IsRead:    m.IsRead,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func FromNotificationEntity(entity *notification.NotificationEntity) NotificationModel {
+	data := entity.Data
+	if data == nil {
+		data = map[string]string{}
+	}
+
+	dataJSON, _ := json.Marshal(data)
+	accountID, _ := uuid.Parse(entity.AccountID)
+	return NotificationModel{
+		ID:        entity.ID,
+		AccountID: accountID,
+		Type:      string(entity.Type),
+		Title:     entity.Title,
+		Content:   entity.Content,
+		Data:      dataJSON,
+		IsRead:    entity.IsRead,
+		CreatedAt: entity.CreatedAt,
+		UpdatedAt: entity.UpdatedAt,
+	}
+}
+
+type NotificationPreferenceModel struct {
+	AccountID     uuid.UUID      `gorm:"primaryKey;type:uuid"`
+	Language      string         `gorm:"type:varchar(8);not null"`
+	Channels      datatypes.JSON `gorm:"type:jsonb;not null"` // Map of event type -> channels
+	QuietStart    *string        `gorm:"type:varchar(5)"`
+	QuietEnd      *string        `gorm:"type:varchar(5)"`
+	QuietTimezone *string        `gorm:"type:varchar(64)"`
+	WebhookURL    string         `gorm:"type:text;not null;default:''"`
+	WebhookSecret string         `gorm:"type:varchar(64);not null;default:''"`
+	UpdatedAt     int64          `gorm:"autoUpdateTime"`
+
+	Account AccountModel `gorm:"foreignKey:AccountID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (NotificationPreferenceModel) TableName() string { return "notification_preferences" }
+
+func (m *NotificationPreferenceModel) ToEntity() *notification.PreferenceEntity {
+	channels := make(map[notification.EventType][]notification.Channel)
+	if len(m.Channels) > 0 {
+		json.Unmarshal(m.Channels, &channels)
+	}
+
+	var quietHours *notification.QuietHours
+	if m.QuietStart != nil && m.QuietEnd != nil && m.QuietTimezone != nil {
+		quietHours = &notification.QuietHours{
+			Start:    *m.QuietStart,
+			End:      *m.QuietEnd,
+			TimeZone: *m.QuietTimezone,
+		}
+	}
+
+	return &notification.PreferenceEntity{
+		AccountID:     m.AccountID,
+		Language:      values.MarkdownLanguageCode(m.Language),
+		Channels:      channels,
+		QuietHours:    quietHours,
+		WebhookURL:    m.WebhookURL,
+		WebhookSecret: m.WebhookSecret,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+func FromPreferenceEntity(entity *notification.PreferenceEntity) NotificationPreferenceModel {
+	channelsJSON, _ := json.Marshal(entity.Channels)
+	model := NotificationPreferenceModel{
+		AccountID:     entity.AccountID,
+		Language:      string(entity.Language),
+		Channels:      channelsJSON,
+		WebhookURL:    entity.WebhookURL,
+		WebhookSecret: entity.WebhookSecret,
+		UpdatedAt:     entity.UpdatedAt,
+	}
+
+	if entity.QuietHours != nil {
+		model.QuietStart = &entity.QuietHours.Start
+		model.QuietEnd = &entity.QuietHours.End
+		model.QuietTimezone = &entity.QuietHours.TimeZone
+	}
+
+	return model
+}
@@ -1,54 +1,112 @@
 package models
 
 import (
+	"encoding/json"
 	"hinsun-backend/internal/domain/project"
+	"hinsun-backend/internal/domain/values"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
+	"gorm.io/datatypes"
 )
 
 type ProjectModel struct {
-	ID          uuid.UUID      `gorm:"primaryKey;type:uuid;default:uuidv7()"`
-	Name        string         `gorm:"type:varchar(100);not null;uniqueIndex"`
-	Description string         `gorm:"type:varchar(500);not null"`
-	Github      string         `gorm:"type:varchar(255);not null"`
-	Cover       string         `gorm:"type:varchar(255);not null"`
-	Tags        pq.StringArray `gorm:"type:text[];not null"`
-	Markdown    string         `gorm:"type:text;not null"`
-	CreatedAt   int64          `gorm:"autoCreateTime"`
-	UpdatedAt   int64          `gorm:"autoUpdateTime"`
-	DeletedAt   *int64         `gorm:"index"`
+	ID                 uuid.UUID      `gorm:"primaryKey;type:uuid;default:uuidv7()"`
+	Languages          pq.StringArray `gorm:"type:text[];not null"`
+	Names              datatypes.JSON `gorm:"type:jsonb;not null"` // Map of language code -> name
+	Descriptions       datatypes.JSON `gorm:"type:jsonb;not null"` // Map of language code -> description
+	Github             string         `gorm:"type:varchar(255);not null"`
+	Cover              string         `gorm:"type:varchar(255);not null"`
+	Tags               pq.StringArray `gorm:"type:text[];not null"`
+	Markdowns          datatypes.JSON `gorm:"type:jsonb;not null"` // Map of language code -> markdown
+	RepositoryMetadata datatypes.JSON `gorm:"type:jsonb"`          // null until the first sync
+	Featured           bool           `gorm:"not null;default:false"`
+	FeaturedOrder      int            `gorm:"not null;default:0"`
+	CreatedAt          int64          `gorm:"autoCreateTime"`
+	UpdatedAt          int64          `gorm:"autoUpdateTime"`
+	Version            int64          `gorm:"not null;default:1"`
+	DeletedAt          DeletedAt      `gorm:"index"`
 }
 
 func (ProjectModel) TableName() string { return "projects" }
 
 // =================================== CONVERTERS ===================================
 func (p *ProjectModel) ToEntity() *project.ProjectEntity {
+	names := make(values.MultiLangText)
+	descriptions := make(values.MultiLangText)
+	markdowns := make(values.MultiLangText)
+
+	if len(p.Names) > 0 {
+		json.Unmarshal(p.Names, &names)
+	}
+
+	if len(p.Descriptions) > 0 {
+		json.Unmarshal(p.Descriptions, &descriptions)
+	}
+
+	if len(p.Markdowns) > 0 {
+		json.Unmarshal(p.Markdowns, &markdowns)
+	}
+
+	var repository *project.RepositoryMetadata
+	if len(p.RepositoryMetadata) > 0 {
+		json.Unmarshal(p.RepositoryMetadata, &repository)
+	}
+
+	languages, err := values.ConvertStringArrayToMarkdownLanguageCodes(p.Languages)
+	if err != nil {
+		return nil
+	}
+
 	return &project.ProjectEntity{
-		ID:          p.ID,
-		Name:        p.Name,
-		Description: p.Description,
-		Github:      p.Github,
-		Cover:       p.Cover,
-		Tags:        p.Tags,
-		Markdown:    p.Markdown,
-		CreatedAt:   p.CreatedAt,
-		UpdatedAt:   p.UpdatedAt,
-		DeletedAt:   p.DeletedAt,
+		ID:            p.ID,
+		Languages:     languages,
+		Names:         names,
+		Descriptions:  descriptions,
+		Github:        p.Github,
+		Cover:         p.Cover,
+		Tags:          p.Tags,
+		Markdowns:     markdowns,
+		Repository:    repository,
+		Featured:      p.Featured,
+		FeaturedOrder: p.FeaturedOrder,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+		Version:       p.Version,
+		DeletedAt:     p.DeletedAt.Ptr(),
 	}
 }
 
 func FromProjectEntity(p *project.ProjectEntity) ProjectModel {
+	namesJSON, _ := json.Marshal(p.Names)
+	descsJSON, _ := json.Marshal(p.Descriptions)
+	markdownsJSON, _ := json.Marshal(p.Markdowns)
+
 	return ProjectModel{
-		ID:          p.ID,
-		Name:        p.Name,
-		Description: p.Description,
-		Cover:       p.Cover,
-		Github:      p.Github,
-		Tags:        p.Tags,
-		Markdown:    p.Markdown,
-		CreatedAt:   p.CreatedAt,
-		UpdatedAt:   p.UpdatedAt,
-		DeletedAt:   p.DeletedAt,
+		ID:                 p.ID,
+		Languages:          values.ConvertMarkdownLanguageCodesToStringArray(p.Languages),
+		Names:              namesJSON,
+		Descriptions:       descsJSON,
+		Cover:              p.Cover,
+		Github:             p.Github,
+		Tags:               p.Tags,
+		Markdowns:          markdownsJSON,
+		RepositoryMetadata: RepositoryMetadataJSON(p.Repository),
+		Featured:           p.Featured,
+		FeaturedOrder:      p.FeaturedOrder,
+		CreatedAt:          p.CreatedAt,
+		UpdatedAt:          p.UpdatedAt,
+		Version:            p.Version,
+		DeletedAt:          NewDeletedAt(p.DeletedAt),
 	}
 }
+
+// RepositoryMetadataJSON encodes the synced metadata of a project, nil gives a null column
+func RepositoryMetadataJSON(metadata *project.RepositoryMetadata) datatypes.JSON {
+	if metadata == nil {
+		return nil
+	}
+
+	encoded, _ := json.Marshal(metadata)
+	return encoded
+}
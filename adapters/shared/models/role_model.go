@@ -0,0 +1,67 @@
+package models
+
+import (
+	"hinsun-backend/internal/domain/role"
+	"hinsun-backend/internal/domain/values"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type RoleModel struct {
+	ID          uuid.UUID      `gorm:"primaryKey;type:uuid;default:uuidv7()"`
+	Name        string         `gorm:"type:varchar(50);uniqueIndex;not null"`
+	Description string         `gorm:"type:varchar(255)"`
+	Permissions pq.StringArray `gorm:"type:text[];not null;default:'{}'"`
+	IsSystem    bool           `gorm:"type:boolean;default:false;not null"`
+	CreatedAt   int64          `gorm:"autoCreateTime"`
+	UpdatedAt   int64          `gorm:"autoUpdateTime"`
+}
+
+func (RoleModel) TableName() string { return "roles" }
+
+// AccountRoleModel assigns roles to accounts; an account may hold several roles
+type AccountRoleModel struct {
+	AccountID uuid.UUID `gorm:"primaryKey;type:uuid"`
+	RoleID    uuid.UUID `gorm:"primaryKey;type:uuid;index"`
+	CreatedAt int64     `gorm:"autoCreateTime"`
+
+	Account AccountModel `gorm:"foreignKey:AccountID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Role    RoleModel    `gorm:"foreignKey:RoleID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (AccountRoleModel) TableName() string { return "account_roles" }
+
+func (r *RoleModel) ToEntity() *role.RoleEntity {
+	permissions := make([]values.Permission, 0, len(r.Permissions))
+	for _, p := range r.Permissions {
+		permissions = append(permissions, values.Permission(p))
+	}
+
+	return &role.RoleEntity{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		Permissions: permissions,
+		IsSystem:    r.IsSystem,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+func FromRoleEntity(entity *role.RoleEntity) RoleModel {
+	permissions := make(pq.StringArray, 0, len(entity.Permissions))
+	for _, p := range entity.Permissions {
+		permissions = append(permissions, string(p))
+	}
+
+	return RoleModel{
+		ID:          entity.ID,
+		Name:        entity.Name,
+		Description: entity.Description,
+		Permissions: permissions,
+		IsSystem:    entity.IsSystem,
+		CreatedAt:   entity.CreatedAt,
+		UpdatedAt:   entity.UpdatedAt,
+	}
+}
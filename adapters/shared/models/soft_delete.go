@@ -0,0 +1,131 @@
+package models
+
+import (
+	"database/sql"
+	"database/sql/driver"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// DeletedAt is a soft-delete marker stored as unix seconds. Like gorm.DeletedAt it makes
+// Delete set the column instead of removing the row and hides marked rows from queries
+// and updates; use db.Unscoped() to reach them, e.g. for the trash or a purge.
+type DeletedAt sql.NullInt64
+
+func NewDeletedAt(deletedAt *int64) DeletedAt {
+	if deletedAt == nil {
+		return DeletedAt{}
+	}
+
+	return DeletedAt{Int64: *deletedAt, Valid: true}
+}
+
+// Ptr returns the deletion time, or nil when the row is not deleted
+func (d DeletedAt) Ptr() *int64 {
+	if !d.Valid {
+		return nil
+	}
+
+	deletedAt := d.Int64
+	return &deletedAt
+}
+
+// Scan implements the Scanner interface.
+func (d *DeletedAt) Scan(value any) error {
+	return (*sql.NullInt64)(d).Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (d DeletedAt) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+
+	return d.Int64, nil
+}
+
+func (DeletedAt) QueryClauses(f *schema.Field) []clause.Interface {
+	return []clause.Interface{softDeleteQueryClause{field: f}}
+}
+
+func (DeletedAt) UpdateClauses(f *schema.Field) []clause.Interface {
+	return []clause.Interface{softDeleteUpdateClause{field: f}}
+}
+
+func (DeletedAt) DeleteClauses(f *schema.Field) []clause.Interface {
+	return []clause.Interface{softDeleteDeleteClause{field: f}}
+}
+
+type softDeleteQueryClause struct {
+	field *schema.Field
+}
+
+func (sd softDeleteQueryClause) Name() string               { return "" }
+func (sd softDeleteQueryClause) Build(clause.Builder)       {}
+func (sd softDeleteQueryClause) MergeClause(*clause.Clause) {}
+
+// ModifyStatement restricts the statement to rows that are not deleted
+func (sd softDeleteQueryClause) ModifyStatement(stmt *gorm.Statement) {
+	if _, ok := stmt.Clauses["soft_delete_enabled"]; ok || stmt.Unscoped {
+		return
+	}
+
+	// Wrap a single OR condition so the soft-delete filter applies to every branch
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) >= 1 {
+			for _, expr := range where.Exprs {
+				if orCond, ok := expr.(clause.OrConditions); ok && len(orCond.Exprs) == 1 {
+					where.Exprs = []clause.Expression{clause.And(where.Exprs...)}
+					c.Expression = where
+					stmt.Clauses["WHERE"] = c
+					break
+				}
+			}
+		}
+	}
+
+	stmt.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: sd.field.DBName}, Value: nil},
+	}})
+	stmt.Clauses["soft_delete_enabled"] = clause.Clause{}
+}
+
+type softDeleteUpdateClause struct {
+	field *schema.Field
+}
+
+func (sd softDeleteUpdateClause) Name() string               { return "" }
+func (sd softDeleteUpdateClause) Build(clause.Builder)       {}
+func (sd softDeleteUpdateClause) MergeClause(*clause.Clause) {}
+
+// ModifyStatement prevents updates of deleted rows
+func (sd softDeleteUpdateClause) ModifyStatement(stmt *gorm.Statement) {
+	if stmt.SQL.Len() == 0 && !stmt.Unscoped {
+		softDeleteQueryClause(sd).ModifyStatement(stmt)
+	}
+}
+
+type softDeleteDeleteClause struct {
+	field *schema.Field
+}
+
+func (sd softDeleteDeleteClause) Name() string               { return "" }
+func (sd softDeleteDeleteClause) Build(clause.Builder)       {}
+func (sd softDeleteDeleteClause) MergeClause(*clause.Clause) {}
+
+// ModifyStatement turns DELETE into an UPDATE of the deletion time
+func (sd softDeleteDeleteClause) ModifyStatement(stmt *gorm.Statement) {
+	if stmt.SQL.Len() > 0 || stmt.Unscoped {
+		return
+	}
+
+	deletedAt := stmt.DB.NowFunc().Unix()
+	stmt.AddClause(clause.Set{{Column: clause.Column{Name: sd.field.DBName}, Value: deletedAt}})
+	stmt.SetColumn(sd.field.DBName, deletedAt, true)
+
+	softDeleteQueryClause(sd).ModifyStatement(stmt)
+	stmt.AddClauseIfNotExists(clause.Update{})
+	stmt.Build(stmt.DB.Callback().Update().Clauses...)
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"hinsun-backend/internal/domain/subscription"
+
+	"github.com/google/uuid"
+)
+
+type FollowModel struct {
+	ID         uuid.UUID `gorm:"primaryKey;type:uuid;default:uuidv7()"`
+	AccountID  uuid.UUID `gorm:"type:uuid;not null"`
+	TargetType string    `gorm:"type:varchar(16);not null"`
+	TargetID   uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt  int64     `gorm:"autoCreateTime"`
+
+	Account AccountModel `gorm:"foreignKey:AccountID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (FollowModel) TableName() string { return "follows" }
+
+func (m *FollowModel) ToEntity() *subscription.FollowEntity {
+	return &subscription.FollowEntity{
+		ID:         m.ID,
+		AccountID:  m.AccountID,
+		TargetType: subscription.TargetType(m.TargetType),
+		TargetID:   m.TargetID,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+func FromFollowEntity(entity *subscription.FollowEntity) FollowModel {
+	return FollowModel{
+		ID:         entity.ID,
+		AccountID:  entity.AccountID,
+		TargetType: string(entity.TargetType),
+		TargetID:   entity.TargetID,
+		CreatedAt:  entity.CreatedAt,
+	}
+}
+
+type EmailSubscriptionModel struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid;default:uuidv7()"`
+	Email       string    `gorm:"type:varchar(254);not null"`
+	TargetType  string    `gorm:"type:varchar(16);not null"`
+	TargetID    uuid.UUID `gorm:"type:uuid;not null"`
+	Token       string    `gorm:"type:varchar(64);uniqueIndex;not null"`
+	ConfirmedAt *int64    `gorm:"type:bigint"`
+	CreatedAt   int64     `gorm:"autoCreateTime"`
+}
+
+func (EmailSubscriptionModel) TableName() string { return "email_subscriptions" }
+
+func (m *EmailSubscriptionModel) ToEntity() *subscription.EmailSubscriptionEntity {
+	return &subscription.EmailSubscriptionEntity{
+		ID:          m.ID,
+		Email:       m.Email,
+		TargetType:  subscription.TargetType(m.TargetType),
+		TargetID:    m.TargetID,
+		Token:       m.Token,
+		ConfirmedAt: m.ConfirmedAt,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+func FromEmailSubscriptionEntity(entity *subscription.EmailSubscriptionEntity) EmailSubscriptionModel {
+	return EmailSubscriptionModel{
+		ID:          entity.ID,
+		Email:       entity.Email,
+		TargetType:  string(entity.TargetType),
+		TargetID:    entity.TargetID,
+		Token:       entity.Token,
+		ConfirmedAt: entity.ConfirmedAt,
+		CreatedAt:   entity.CreatedAt,
+	}
+}
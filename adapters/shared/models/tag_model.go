@@ -0,0 +1,42 @@
+package models
+
+import (
+	"hinsun-backend/internal/domain/tag"
+
+	"github.com/google/uuid"
+)
+
+type TagModel struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid;default:uuidv7()"`
+	Name        string    `gorm:"type:varchar(50);uniqueIndex;not null"`
+	NumProjects int64     `gorm:"->;-:migration"` // selected as a count over the projects, never written
+	CreatedAt   int64     `gorm:"autoCreateTime"`
+	UpdatedAt   int64     `gorm:"autoUpdateTime"`
+	Version     int64     `gorm:"not null;default:1"`
+	DeletedAt   DeletedAt `gorm:"index"`
+}
+
+func (TagModel) TableName() string { return "tags" }
+
+func (t *TagModel) ToEntity() *tag.TagEntity {
+	return &tag.TagEntity{
+		ID:          t.ID,
+		Name:        t.Name,
+		NumProjects: t.NumProjects,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+		Version:     t.Version,
+		DeletedAt:   t.DeletedAt.Ptr(),
+	}
+}
+
+func FromTagEntity(entity *tag.TagEntity) TagModel {
+	return TagModel{
+		ID:        entity.ID,
+		Name:      entity.Name,
+		CreatedAt: entity.CreatedAt,
+		UpdatedAt: entity.UpdatedAt,
+		Version:   entity.Version,
+		DeletedAt: NewDeletedAt(entity.DeletedAt),
+	}
+}
@@ -0,0 +1,322 @@
+package streaming
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hinsun-backend/internal/core/events"
+	"hinsun-backend/internal/domain/comment"
+	"hinsun-backend/internal/domain/notification"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Stream event names, as seen by EventSource listeners
+const (
+	EventNotification = "notification"
+	EventReply        = "reply"
+	EventComment      = "comment"
+	EventResync       = "resync" // the replay could not cover the gap, clients should refetch
+)
+
+// clientBufferSize is the number of events a client may lag behind before it is dropped
+const clientBufferSize = 64
+
+var (
+	ErrTooManyConnections = errors.New("too many open streams for this account")
+	ErrBrokerClosed       = errors.New("stream broker is closed")
+)
+
+// Event is a message written to the stream. IDs are "<epoch>-<sequence>", the epoch changes on
+// every restart so IDs from a previous process are never mistaken for current ones.
+type Event struct {
+	ID   string
+	Name string
+	Data []byte
+
+	seq       uint64
+	accountID string
+	blogID    string
+}
+
+// Client is one open stream, it receives the events of its account and of the blog it views
+type Client struct {
+	accountID string
+	blogID    string
+	events    chan *Event
+}
+
+// Events is closed when the client is unsubscribed, dropped for lagging or the broker closes
+func (c *Client) Events() <-chan *Event {
+	return c.events
+}
+
+func (c *Client) matches(event *Event) bool {
+	return (event.accountID != "" && event.accountID == c.accountID) ||
+		(event.blogID != "" && event.blogID == c.blogID)
+}
+
+// Broker fans bus events out to open streams and keeps the latest ones for Last-Event-ID resume
+type Broker struct {
+	mu               sync.Mutex
+	epoch            string
+	seq              uint64
+	replay           []*Event // ring buffer of the latest events
+	next             int      // ring position of the next event
+	clients          map[*Client]struct{}
+	accountStreams   map[string]int
+	maxPerAccount    int
+	closed           bool
+	interestedEvents map[string]bool
+}
+
+func NewBroker(maxPerAccount, replaySize int) *Broker {
+	return &Broker{
+		epoch:          strconv.FormatInt(time.Now().UnixMilli(), 36),
+		replay:         make([]*Event, replaySize),
+		clients:        make(map[*Client]struct{}),
+		accountStreams: make(map[string]int),
+		maxPerAccount:  maxPerAccount,
+		interestedEvents: map[string]bool{
+			notification.EventNotificationCreated: true,
+			comment.EventCommentAdded:             true,
+		},
+	}
+}
+
+// Subscribe opens a stream for the account, blogID is optional. When lastEventID is set the
+// missed events still buffered are returned to be written first; if some were lost, the backlog
+// starts with a resync event instead.
+func (b *Broker) Subscribe(accountID, blogID, lastEventID string) (*Client, []*Event, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, nil, ErrBrokerClosed
+	}
+
+	if b.accountStreams[accountID] >= b.maxPerAccount {
+		return nil, nil, ErrTooManyConnections
+	}
+
+	client := &Client{
+		accountID: accountID,
+		blogID:    blogID,
+		events:    make(chan *Event, clientBufferSize),
+	}
+
+	b.clients[client] = struct{}{}
+	b.accountStreams[accountID]++
+
+	return client, b.backlog(client, lastEventID), nil
+}
+
+// Unsubscribe closes the client stream, it is safe to call more than once
+func (b *Broker) Unsubscribe(client *Client) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.remove(client)
+}
+
+// Publish records the event and delivers it to the matching clients without blocking, a client
+// whose buffer is full is dropped and resumes with Last-Event-ID when it reconnects
+func (b *Broker) Publish(name, accountID, blogID string, data any) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode stream event: %w", err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrBrokerClosed
+	}
+
+	b.seq++
+	event := &Event{
+		ID:        b.eventID(b.seq),
+		Name:      name,
+		Data:      encoded,
+		seq:       b.seq,
+		accountID: accountID,
+		blogID:    blogID,
+	}
+
+	if len(b.replay) > 0 {
+		b.replay[b.next] = event
+		b.next = (b.next + 1) % len(b.replay)
+	}
+
+	for client := range b.clients {
+		if !client.matches(event) {
+			continue
+		}
+
+		select {
+		case client.events <- event:
+		default:
+			b.remove(client)
+		}
+	}
+
+	return nil
+}
+
+// Close ends every open stream and refuses new ones, so server shutdown is not held by them
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for client := range b.clients {
+		b.remove(client)
+	}
+}
+
+// ===== EventHandler =====
+
+func (b *Broker) InterestedIn(eventName string) bool {
+	return b.interestedEvents[eventName]
+}
+
+func (b *Broker) HandleEvent(ctx context.Context, event events.Event) error {
+	switch e := event.(type) {
+	case *notification.NotificationCreatedEvent:
+		payload, ok := e.Payload.(notification.NotificationCreatedPayload)
+		if !ok {
+			return fmt.Errorf("unexpected payload for event: %s", event.EventName())
+		}
+
+		return b.Publish(EventNotification, payload.AccountID, "", &notificationData{
+			ID:        payload.NotificationID,
+			AccountID: payload.AccountID,
+			Type:      payload.Type,
+			Title:     payload.Title,
+			Content:   payload.Content,
+			Data:      payload.Data,
+		})
+	case *comment.CommentAddedEvent:
+		payload, ok := e.Payload.(comment.CommentAddedPayload)
+		if !ok {
+			return fmt.Errorf("unexpected payload for event: %s", event.EventName())
+		}
+
+		data := &commentData{
+			ID:         payload.CommentID,
+			BlogID:     payload.BlogID,
+			AuthorID:   payload.AuthorID,
+			AuthorName: payload.AuthorName,
+			ParentID:   payload.ParentID,
+			Content:    payload.Content,
+			CreatedAt:  payload.CreatedAt,
+		}
+
+		// Viewers of the blog see the comment, the parent author also hears about the reply
+		// wherever they are
+		err := b.Publish(EventComment, "", payload.BlogID, data)
+		if payload.ParentAuthorID != "" && payload.ParentAuthorID != payload.AuthorID {
+			err = errors.Join(err, b.Publish(EventReply, payload.ParentAuthorID, "", data))
+		}
+
+		return err
+	default:
+		return fmt.Errorf("unhandled event: %s", event.EventName())
+	}
+}
+
+type notificationData struct {
+	ID        string                 `json:"id"`
+	AccountID string                 `json:"accountId"`
+	Type      notification.EventType `json:"type"`
+	Title     string                 `json:"title"`
+	Content   string                 `json:"content"`
+	Data      map[string]string      `json:"data,omitempty"`
+}
+
+type commentData struct {
+	ID         string `json:"id"`
+	BlogID     string `json:"blogId"`
+	AuthorID   string `json:"authorId"`
+	AuthorName string `json:"authorName"`
+	ParentID   string `json:"parentId,omitempty"`
+	Content    string `json:"content"`
+	CreatedAt  int64  `json:"createdAt"`
+}
+
+// backlog returns the buffered events after lastEventID that the client would have received
+func (b *Broker) backlog(client *Client, lastEventID string) []*Event {
+	if lastEventID == "" {
+		return nil
+	}
+
+	seq, ok := b.parseEventID(lastEventID)
+	if !ok || seq > b.seq {
+		return []*Event{b.resyncEvent()}
+	}
+
+	// Events after seq are only all buffered if the oldest buffered one directly follows it
+	oldest := b.seq + 1
+	buffered := make([]*Event, 0)
+	for i := range len(b.replay) {
+		event := b.replay[(b.next+i)%len(b.replay)]
+		if event == nil {
+			continue
+		}
+
+		oldest = min(oldest, event.seq)
+		if event.seq > seq && client.matches(event) {
+			buffered = append(buffered, event)
+		}
+	}
+
+	if oldest > seq+1 {
+		return append([]*Event{b.resyncEvent()}, buffered...)
+	}
+
+	return buffered
+}
+
+// resyncEvent carries the current ID, so the client resumes from here once it has refetched
+func (b *Broker) resyncEvent() *Event {
+	return &Event{
+		ID:   b.eventID(b.seq),
+		Name: EventResync,
+		Data: []byte("{}"),
+		seq:  b.seq,
+	}
+}
+
+func (b *Broker) eventID(seq uint64) string {
+	return b.epoch + "-" + strconv.FormatUint(seq, 10)
+}
+
+// parseEventID returns the sequence of an ID issued by this process
+func (b *Broker) parseEventID(id string) (uint64, bool) {
+	epoch, seq, found := strings.Cut(id, "-")
+	if !found || epoch != b.epoch {
+		return 0, false
+	}
+
+	value, err := strconv.ParseUint(seq, 10, 64)
+	return value, err == nil
+}
+
+// remove must be called with the lock held
+func (b *Broker) remove(client *Client) {
+	if _, ok := b.clients[client]; !ok {
+		return
+	}
+
+	delete(b.clients, client)
+	close(client.events)
+
+	b.accountStreams[client.accountID]--
+	if b.accountStreams[client.accountID] <= 0 {
+		delete(b.accountStreams, client.accountID)
+	}
+}
@@ -0,0 +1,90 @@
+package tracing
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const gormSpanKey = "tracing:span"
+
+type gormPlugin struct {
+	tracer trace.Tracer
+}
+
+// NewGormPlugin creates a client span for every statement GORM executes, as a child of the
+// span found in the statement context
+func NewGormPlugin() gorm.Plugin {
+	return &gormPlugin{tracer: otel.Tracer(instrumentationName)}
+}
+
+func (p *gormPlugin) Name() string {
+	return "tracing"
+}
+
+func (p *gormPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	return errors.Join(
+		callback.Create().Before("gorm:create").Register("tracing:before_create", p.start("create")),
+		callback.Create().After("gorm:create").Register("tracing:after_create", p.end),
+		callback.Query().Before("gorm:query").Register("tracing:before_query", p.start("query")),
+		callback.Query().After("gorm:query").Register("tracing:after_query", p.end),
+		callback.Update().Before("gorm:update").Register("tracing:before_update", p.start("update")),
+		callback.Update().After("gorm:update").Register("tracing:after_update", p.end),
+		callback.Delete().Before("gorm:delete").Register("tracing:before_delete", p.start("delete")),
+		callback.Delete().After("gorm:delete").Register("tracing:after_delete", p.end),
+		callback.Row().Before("gorm:row").Register("tracing:before_row", p.start("row")),
+		callback.Row().After("gorm:row").Register("tracing:after_row", p.end),
+		callback.Raw().Before("gorm:raw").Register("tracing:before_raw", p.start("raw")),
+		callback.Raw().After("gorm:raw").Register("tracing:after_raw", p.end),
+	)
+}
+
+func (p *gormPlugin) start(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if ctx == nil || !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+			// Statements outside of a traced request (migrations, the purge job) are not recorded
+			return
+		}
+
+		_, span := p.tracer.Start(ctx, "db."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemPostgreSQL,
+				semconv.DBOperationName(operation),
+			),
+		)
+
+		db.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func (p *gormPlugin) end(db *gorm.DB) {
+	value, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+
+	if db.Statement.Table != "" {
+		span.SetAttributes(semconv.DBCollectionName(db.Statement.Table))
+	}
+
+	// The SQL text holds placeholders only, the bound values are never recorded
+	span.SetAttributes(semconv.DBQueryText(db.Statement.SQL.String()))
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+
+	span.End()
+}
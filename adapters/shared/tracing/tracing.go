@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"hinsun-backend/configs"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// instrumentationName identifies the spans created by this service's own instrumentation
+const instrumentationName = "hinsun-backend"
+
+// NewTracerProvider builds a tracer provider exporting to the configured exporter and installs
+// it, with W3C trace context propagation, as the global provider used by every tracer
+func NewTracerProvider(ctx context.Context) (*sdktrace.TracerProvider, error) {
+	tracingConfig := configs.GlobalConfig.Tracing
+
+	exporter, err := newExporter(ctx, tracingConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceResource, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(configs.GlobalConfig.App.Name),
+		semconv.ServiceVersion(configs.GlobalConfig.App.Version),
+		semconv.DeploymentEnvironment(string(configs.GlobalConfig.Env)),
+	))
+
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(serviceResource),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(tracingConfig.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return provider, nil
+}
+
+func newExporter(ctx context.Context, tracingConfig configs.TracingConfig) (sdktrace.SpanExporter, error) {
+	switch tracingConfig.Exporter {
+	case "otlp":
+		options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(tracingConfig.OtlpEndpoint)}
+		if tracingConfig.OtlpInsecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+
+		return otlptracehttp.New(ctx, options...)
+	case "stdout":
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("unsupported tracing exporter %q, expected otlp or stdout", tracingConfig.Exporter)
+	}
+}
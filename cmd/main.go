@@ -3,9 +3,16 @@ package main
 import (
 	"context"
 	"fmt"
+	healthRoutes "hinsun-backend/adapters/primary/health"
 	v1 "hinsun-backend/adapters/primary/v1"
 	v2 "hinsun-backend/adapters/primary/v2"
+	"hinsun-backend/adapters/primary/wellknown"
 	"hinsun-backend/adapters/shared/di"
+	"hinsun-backend/adapters/shared/health"
+	"hinsun-backend/adapters/shared/metrics"
+	"hinsun-backend/adapters/shared/middlewares"
+	"hinsun-backend/adapters/shared/streaming"
+	"hinsun-backend/adapters/shared/tracing"
 	"hinsun-backend/configs"
 	_ "hinsun-backend/docs"
 	"hinsun-backend/internal/core/log"
@@ -20,8 +27,9 @@ import (
 )
 
 type HTTPServer struct {
-	server  *http.Server
-	address string
+	server    *http.Server
+	address   string
+	readiness *health.Readiness
 }
 
 // ServerParams contains all dependencies for HTTP server
@@ -31,6 +39,13 @@ type ServerParams struct {
 	// V1 Handlers
 	V1Routes *v1.V1Routes
 	V2Routes *v2.V2Routes
+
+	WellKnownRoutes *wellknown.WellKnownRoutes
+	HealthRoutes    *healthRoutes.HealthRoutes
+
+	Metrics      *metrics.Metrics
+	Readiness    *health.Readiness
+	StreamBroker *streaming.Broker
 }
 
 // ProvideHTTPServer creates and configures the HTTP server
@@ -49,22 +64,25 @@ func NewHTTPServer(address string, params ServerParams) *HTTPServer {
 		AllowedOrigins:   corsConfig.AllowedOrigins,
 		AllowedMethods:   corsConfig.AllowedMethods,
 		AllowedHeaders:   corsConfig.AllowedHeaders,
+		ExposedHeaders:   []string{"ETag"}, // lets browser clients send it back in If-Match
 		AllowCredentials: corsConfig.AllowCredentials,
 		MaxAge:           corsConfig.MaxAge,
 	}))
 
 	// Global middlewares
+	r.Use(params.Metrics.Middleware)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.RequestID)
+	r.Use(tracing.Middleware)
+	r.Use(middlewares.RequestLogger)
 	r.Use(middleware.RealIP)
-	r.Use(middleware.Timeout(60 * time.Second))
+	r.Use(middlewares.Timeout(60*time.Second, "/api/v1/stream"))
 
-	// Health check
-	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("Hi there! I'm healthy 🐳"))
-	})
+	// Probes, /health is kept for existing monitors and behaves like /livez
+	r.Get("/livez", params.HealthRoutes.Livez)
+	r.Get("/readyz", params.HealthRoutes.Readyz)
+	r.Get("/health", params.HealthRoutes.Livez)
 
 	// Swagger
 	r.Mount("/swagger/", httpSwagger.WrapHandler)
@@ -73,6 +91,9 @@ func NewHTTPServer(address string, params ServerParams) *HTTPServer {
 	r.Mount("/api/v1", params.V1Routes.RegisterRoutes())
 	r.Mount("/api/v2", params.V2Routes.RegisterRoutes())
 
+	// Discovery documents (JWKS)
+	r.Mount("/.well-known", params.WellKnownRoutes.RegisterRoutes())
+
 	server := &http.Server{
 		Addr:         address,
 		Handler:      r,
@@ -81,16 +102,20 @@ func NewHTTPServer(address string, params ServerParams) *HTTPServer {
 		IdleTimeout:  time.Duration(configs.GlobalConfig.Server.IdleTimeout) * time.Second,
 	}
 
+	// Shutdown waits for active requests, open streams would hold it until the deadline
+	server.RegisterOnShutdown(params.StreamBroker.Close)
+
 	return &HTTPServer{
-		server:  server,
-		address: address,
+		server:    server,
+		address:   address,
+		readiness: params.Readiness,
 	}
 }
 
 func (s *HTTPServer) Start() error {
 	log.Logger.Info("🚀 Server starting on address " + s.address)
 	log.Logger.Info("📚 Swagger UI: http://" + s.address + "/swagger/index.html")
-	log.Logger.Info("💚 Health check: http://" + s.address + "/health")
+	log.Logger.Info("💚 Readiness probe: http://" + s.address + "/readyz")
 
 	if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		return fmt.Errorf("failed to start server: %w", err)
@@ -100,6 +125,17 @@ func (s *HTTPServer) Start() error {
 }
 
 func (s *HTTPServer) Stop(ctx context.Context) error {
+	// Fail readiness first and keep serving for a while, so load balancers drain the instance
+	// before the listener closes
+	s.readiness.Drain()
+	drainDelay := time.Duration(configs.GlobalConfig.Server.ShutdownDrainDelay) * time.Second
+	log.Logger.Info(fmt.Sprintf("🚰 Draining traffic for %s", drainDelay))
+
+	select {
+	case <-time.After(drainDelay):
+	case <-ctx.Done():
+	}
+
 	log.Logger.Info("🛑 Shutting down server")
 	return s.server.Shutdown(ctx)
 }
@@ -153,8 +189,13 @@ func main() {
 	app := fx.New(
 		// Core modules
 		di.CoreModule,
+		di.MetricsModule,
+		di.TracingModule,
+		di.HealthModule,
 		di.RepositoryModule,
 		di.ServiceModule,
+		di.NotifierModule,
+		di.StreamModule,
 		di.ApplicationModule,
 
 		// HTTP modules
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"hinsun-backend/adapters/shared/databases"
+	"hinsun-backend/configs"
+	"hinsun-backend/internal/core/log"
+	"os"
+	"strconv"
+	"time"
+)
+
+const usage = `Usage: migrate <command>
+
+Commands:
+  up                  apply all pending migrations
+  down [n]            revert the last n applied migrations (default 1)
+  status              list migrations and whether they are applied
+  baseline <version>  mark migrations up to version as applied without running them,
+                      for databases created by AutoMigrate before migrations existed`
+
+func main() {
+	// Ensure configurations are initialized
+	configs.Init()
+	log.Init()
+
+	if len(os.Args) < 2 {
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	if err := run(context.Background(), os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, command string, args []string) error {
+	client, err := databases.NewPostgresClient()
+	if err != nil {
+		return err
+	}
+
+	sqlDB, err := client.DB.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	migrator, err := databases.NewMigrator(sqlDB)
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			fmt.Printf("⬆️  %06d_%s\n", migration.Version, migration.Name)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if len(applied) == 0 {
+			fmt.Println("Schema is up to date")
+		}
+
+	case "down":
+		steps := 1
+		if len(args) > 0 {
+			steps, err = strconv.Atoi(args[0])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps %q", args[0])
+			}
+		}
+
+		reverted, err := migrator.Down(ctx, steps)
+		for _, migration := range reverted {
+			fmt.Printf("⬇️  %06d_%s\n", migration.Version, migration.Name)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if len(reverted) == 0 {
+			fmt.Println("No migrations to revert")
+		}
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.Applied {
+				appliedAt = "applied " + time.Unix(*status.AppliedAt, 0).Format(time.RFC3339)
+			}
+
+			fmt.Printf("%06d_%-40s %s\n", status.Version, status.Name, appliedAt)
+		}
+
+	case "baseline":
+		if len(args) == 0 {
+			return fmt.Errorf("baseline needs the version the schema is already at")
+		}
+
+		version, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil || version < 1 {
+			return fmt.Errorf("invalid version %q", args[0])
+		}
+
+		recorded, err := migrator.Baseline(ctx, version)
+		for _, migration := range recorded {
+			fmt.Printf("✅ %06d_%s\n", migration.Version, migration.Name)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if len(recorded) == 0 {
+			fmt.Println("Nothing to record")
+		}
+
+	default:
+		fmt.Println(usage)
+		return fmt.Errorf("unknown command %q", command)
+	}
+
+	return nil
+}
@@ -54,15 +54,27 @@ func getEnv(key, defaultValue string) string {
 // loadConfig loads all configuration from environment variables
 func loadConfig(env string) *Config {
 	return &Config{
-		Env:      Env(env),
-		App:      loadAppConfig(),
-		Server:   loadServerConfig(),
-		Cors:     loadCorsConfig(),
-		Metrics:  loadMetricsConfig(),
-		Log:      loadLogConfig(),
-		Database: loadDatabaseConfig(),
-		Caching:  loadCachingConfig(),
-		Jwt:      loadJWTConfig(),
+		Env:          Env(env),
+		App:          loadAppConfig(),
+		Server:       loadServerConfig(),
+		Cors:         loadCorsConfig(),
+		Metrics:      loadMetricsConfig(),
+		Tracing:      loadTracingConfig(),
+		Log:          loadLogConfig(),
+		Database:     loadDatabaseConfig(),
+		Caching:      loadCachingConfig(),
+		HttpCache:    loadHttpCacheConfig(),
+		Jwt:          loadJWTConfig(),
+		TwoFactor:    loadTwoFactorConfig(),
+		Trash:        loadTrashConfig(),
+		Firebase:     loadFirebaseConfig(),
+		Mail:         loadMailConfig(),
+		Notifier:     loadNotifierConfig(),
+		Stream:       loadStreamConfig(),
+		Subscription: loadSubscriptionConfig(),
+		Storage:      loadStorageConfig(),
+		Media:        loadMediaConfig(),
+		Github:       loadGithubConfig(),
 	}
 }
 
@@ -78,10 +90,12 @@ func loadAppConfig() AppConfig {
 // loadServerConfig loads server configuration
 func loadServerConfig() ServerConfig {
 	return ServerConfig{
-		Address:      getEnv("SERVER_ADDRESS", ":8080"),
-		ReadTimeout:  getEnvAsInt("SERVER_READ_TIMEOUT", 15),
-		WriteTimeout: getEnvAsInt("SERVER_WRITE_TIMEOUT", 15),
-		IdleTimeout:  getEnvAsInt("SERVER_IDLE_TIMEOUT", 60),
+		Address:            getEnv("SERVER_ADDRESS", ":8080"),
+		ReadTimeout:        getEnvAsInt("SERVER_READ_TIMEOUT", 15),
+		WriteTimeout:       getEnvAsInt("SERVER_WRITE_TIMEOUT", 15),
+		IdleTimeout:        getEnvAsInt("SERVER_IDLE_TIMEOUT", 60),
+		ShutdownDrainDelay: getEnvAsInt("SERVER_SHUTDOWN_DRAIN_DELAY", 5),
+		ReadinessTimeout:   getEnvAsInt("SERVER_READINESS_TIMEOUT", 2000),
 	}
 }
 
@@ -89,8 +103,8 @@ func loadServerConfig() ServerConfig {
 func loadCorsConfig() CorsConfig {
 	return CorsConfig{
 		AllowedOrigins:   getEnvAsSlice("CORS_ALLOWED_ORIGINS", []string{}, ","),
-		AllowedMethods:   getEnvAsSlice("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}, ","),
-		AllowedHeaders:   getEnvAsSlice("CORS_ALLOWED_HEADERS", []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"}, ","),
+		AllowedMethods:   getEnvAsSlice("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}, ","),
+		AllowedHeaders:   getEnvAsSlice("CORS_ALLOWED_HEADERS", []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "If-Match", "If-None-Match", "If-Modified-Since"}, ","),
 		AllowCredentials: getEnvAsBool("CORS_ALLOW_CREDENTIALS", false),
 		MaxAge:           getEnvAsInt("CORS_MAX_AGE", 300),
 	}
@@ -126,6 +140,17 @@ func loadMetricsConfig() MetricsConfig {
 	}
 }
 
+// loadTracingConfig loads OpenTelemetry tracing configuration
+func loadTracingConfig() TracingConfig {
+	return TracingConfig{
+		Enabled:      getEnvAsBool("TRACING_ENABLED", false),
+		Exporter:     getEnv("TRACING_EXPORTER", "otlp"),
+		OtlpEndpoint: getEnv("TRACING_OTLP_ENDPOINT", "localhost:4318"),
+		OtlpInsecure: getEnvAsBool("TRACING_OTLP_INSECURE", true),
+		SampleRatio:  getEnvAsFloat("TRACING_SAMPLE_RATIO", 1),
+	}
+}
+
 // loadLogConfig loads logging configuration
 func loadLogConfig() LogConfig {
 	return LogConfig{
@@ -159,12 +184,16 @@ func loadDatabaseConfig() DatabaseConfig {
 		IdleTimeout:     getEnvAsInt("DATABASE_IDLE_TIMEOUT", 60),
 		ConnectTimeout:  getEnvAsInt("DATABASE_CONNECT_TIMEOUT", 10),
 		TimeZone:        getEnv("DATABASE_TIMEZONE", "Asia/Ho_Chi_Minh"),
+		AutoMigrate:     getEnvAsBool("DATABASE_AUTO_MIGRATE", false),
 	}
 }
 
 // loadCachingConfig loads caching (Redis) configuration
 func loadCachingConfig() CachingConfig {
 	return CachingConfig{
+		Driver:       getEnv("CACHE_DRIVER", "memory"),
+		TTL:          getEnvAsInt("CACHE_TTL", 300),
+		MaxEntries:   getEnvAsInt("CACHE_MAX_ENTRIES", 1000),
 		Host:         getEnv("REDIS_HOST", "localhost"),
 		Port:         getEnvAsInt("REDIS_PORT", 6379),
 		Password:     getEnv("REDIS_PASSWORD", ""),
@@ -175,16 +204,140 @@ func loadCachingConfig() CachingConfig {
 	}
 }
 
+// loadHttpCacheConfig loads the Cache-Control policies of the public routes
+func loadHttpCacheConfig() HttpCacheConfig {
+	return HttpCacheConfig{
+		BlogsPolicy:       getEnv("HTTP_CACHE_BLOGS_POLICY", "public, max-age=60, stale-while-revalidate=300"),
+		ProjectsPolicy:    getEnv("HTTP_CACHE_PROJECTS_POLICY", "public, max-age=300, stale-while-revalidate=3600"),
+		ExperiencesPolicy: getEnv("HTTP_CACHE_EXPERIENCES_POLICY", "public, max-age=300, stale-while-revalidate=3600"),
+		CategoriesPolicy:  getEnv("HTTP_CACHE_CATEGORIES_POLICY", "public, max-age=300, stale-while-revalidate=3600"),
+		TagsPolicy:        getEnv("HTTP_CACHE_TAGS_POLICY", "public, max-age=300, stale-while-revalidate=3600"),
+	}
+}
+
 // loadJWTConfig loads JWT configuration
 func loadJWTConfig() JwtConfig {
 	return JwtConfig{
 		Algorithm:          getEnv("JWT_ALGORITHM", "RS256"),
 		KeySize:            getEnvAsInt("JWT_KEYSIZE", 2048),
+		KeysPath:           getEnv("JWT_KEYS_PATH", "./keys"),
+		MaxRetiredKeys:     getEnvAsInt("JWT_MAX_RETIRED_KEYS", 2),
+		AccessSecret:       getEnvOrFile("JWT_ACCESS_SECRET"),
+		RefreshSecret:      getEnvOrFile("JWT_REFRESH_SECRET"),
 		AccessTokenExpiry:  getEnvAsInt("JWT_ACCESS_TOKEN_EXPIRY", 900),
 		RefreshTokenExpiry: getEnvAsInt("JWT_REFRESH_TOKEN_EXPIRY", 604800),
 	}
 }
 
+// loadTwoFactorConfig loads two-factor authentication configuration
+func loadTwoFactorConfig() TwoFactorConfig {
+	return TwoFactorConfig{
+		Issuer:               getEnv("TWO_FACTOR_ISSUER", "Hinsun"),
+		RequiredForAdmin:     getEnvAsBool("TWO_FACTOR_REQUIRED_FOR_ADMIN", false),
+		ChallengeTokenExpiry: getEnvAsInt("TWO_FACTOR_CHALLENGE_TOKEN_EXPIRY", 300),
+		RecoveryCodeCount:    getEnvAsInt("TWO_FACTOR_RECOVERY_CODE_COUNT", 10),
+		MaxAttempts:          getEnvAsInt("TWO_FACTOR_MAX_ATTEMPTS", 5),
+		LockoutDuration:      getEnvAsInt("TWO_FACTOR_LOCKOUT_DURATION", 900),
+		EncryptionKey:        getEnvOrFile("TWO_FACTOR_ENCRYPTION_KEY"),
+	}
+}
+
+// loadTrashConfig loads soft-delete retention configuration
+func loadTrashConfig() TrashConfig {
+	return TrashConfig{
+		RetentionDays: getEnvAsInt("TRASH_RETENTION_DAYS", 30),
+		PurgeInterval: getEnvAsInt("TRASH_PURGE_INTERVAL", 3600),
+	}
+}
+
+// loadFirebaseConfig loads Firebase Cloud Messaging configuration
+func loadFirebaseConfig() FirebaseConfig {
+	return FirebaseConfig{
+		Enabled:         getEnvAsBool("FIREBASE_ENABLED", false),
+		CredentialsFile: getEnv("FIREBASE_CREDENTIALS_FILE", "firebase-adminsdk.json"),
+	}
+}
+
+// loadMailConfig loads SMTP configuration
+func loadMailConfig() MailConfig {
+	return MailConfig{
+		Enabled:  getEnvAsBool("MAIL_ENABLED", false),
+		Host:     getEnv("MAIL_HOST", "localhost"),
+		Port:     getEnvAsInt("MAIL_PORT", 587),
+		Username: getEnv("MAIL_USERNAME", ""),
+		Password: getEnvOrFile("MAIL_PASSWORD"),
+		From:     getEnv("MAIL_FROM", "Hinsun <no-reply@localhost>"),
+	}
+}
+
+// loadNotifierConfig loads notification delivery configuration
+func loadNotifierConfig() NotifierConfig {
+	return NotifierConfig{
+		WebhookTimeout: getEnvAsInt("NOTIFIER_WEBHOOK_TIMEOUT", 5),
+	}
+}
+
+// loadStreamConfig loads Server-Sent Events stream configuration
+func loadStreamConfig() StreamConfig {
+	return StreamConfig{
+		MaxConnectionsPerAccount: getEnvAsInt("STREAM_MAX_CONNECTIONS_PER_ACCOUNT", 5),
+		ReplayBufferSize:         getEnvAsInt("STREAM_REPLAY_BUFFER_SIZE", 1000),
+		HeartbeatInterval:        getEnvAsInt("STREAM_HEARTBEAT_INTERVAL", 25),
+	}
+}
+
+// loadSubscriptionConfig loads the links sent to email subscribers
+func loadSubscriptionConfig() SubscriptionConfig {
+	return SubscriptionConfig{
+		ConfirmURL:     getEnv("SUBSCRIPTION_CONFIRM_URL", "http://localhost:3000/subscriptions/confirm"),
+		UnsubscribeURL: getEnv("SUBSCRIPTION_UNSUBSCRIBE_URL", "http://localhost:3000/subscriptions/unsubscribe"),
+	}
+}
+
+// loadStorageConfig loads blob storage configuration
+func loadStorageConfig() StorageConfig {
+	driver := getEnv("STORAGE_DRIVER", "local")
+
+	// Local files are served by the media endpoint, buckets serve their own files
+	defaultPublicURL := ""
+	if driver == "local" {
+		defaultPublicURL = "http://localhost:8080/api/v1/media/files"
+	}
+
+	return StorageConfig{
+		Driver:         driver,
+		LocalRoot:      getEnv("STORAGE_LOCAL_ROOT", "uploads"),
+		PublicURL:      getEnv("STORAGE_PUBLIC_URL", defaultPublicURL),
+		S3Endpoint:     getEnv("STORAGE_S3_ENDPOINT", "http://localhost:9000"),
+		S3Region:       getEnv("STORAGE_S3_REGION", "us-east-1"),
+		S3Bucket:       getEnv("STORAGE_S3_BUCKET", "hinsun"),
+		S3AccessKey:    getEnv("STORAGE_S3_ACCESS_KEY", ""),
+		S3SecretKey:    getEnvOrFile("STORAGE_S3_SECRET_KEY"),
+		S3UsePathStyle: getEnvAsBool("STORAGE_S3_USE_PATH_STYLE", true),
+	}
+}
+
+// loadMediaConfig loads upload limits, garbage collection and image processing configuration
+func loadMediaConfig() MediaConfig {
+	return MediaConfig{
+		MaxUploadSize:       getEnvAsInt("MEDIA_MAX_UPLOAD_SIZE", 10),
+		GCInterval:          getEnvAsInt("MEDIA_GC_INTERVAL", 3600),
+		GCGracePeriod:       getEnvAsInt("MEDIA_GC_GRACE_PERIOD", 24),
+		ProcessingWorkers:   getEnvAsInt("MEDIA_PROCESSING_WORKERS", 2),
+		ProcessingQueueSize: getEnvAsInt("MEDIA_PROCESSING_QUEUE_SIZE", 100),
+	}
+}
+
+// loadGithubConfig loads the GitHub API client and repository sync configuration
+func loadGithubConfig() GithubConfig {
+	return GithubConfig{
+		APIURL:       getEnv("GITHUB_API_URL", "https://api.github.com"),
+		Token:        getEnvOrFile("GITHUB_TOKEN"),
+		Timeout:      getEnvAsInt("GITHUB_TIMEOUT", 10),
+		SyncInterval: getEnvAsInt("GITHUB_SYNC_INTERVAL", 21600),
+	}
+}
+
 // getEnvAsInt gets an integer environment variable or returns a default value
 func getEnvAsInt(key string, defaultValue int) int {
 	valueStr := os.Getenv(key)
@@ -201,6 +354,41 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return value
 }
 
+// getEnvAsFloat gets a float environment variable or returns a default value
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		log.Printf("Warning: Invalid float value for %s: %s, using default: %g", key, valueStr, defaultValue)
+		return defaultValue
+	}
+
+	return value
+}
+
+// getEnvOrFile gets a secret from KEY, or from the file named by KEY_FILE (e.g. Docker secrets)
+func getEnvOrFile(key string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	path := os.Getenv(key + "_FILE")
+	if path == "" {
+		return ""
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalf("Error reading %s_FILE %s: %v", key, path, err)
+	}
+
+	return strings.TrimSpace(string(data))
+}
+
 // getEnvAsBool gets a boolean environment variable or returns a default value
 func getEnvAsBool(key string, defaultValue bool) bool {
 	valueStr := os.Getenv(key)
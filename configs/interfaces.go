@@ -1,15 +1,27 @@
 package configs
 
 type Config struct {
-	Env      Env
-	App      AppConfig
-	Server   ServerConfig
-	Cors     CorsConfig
-	Metrics  MetricsConfig
-	Log      LogConfig
-	Database DatabaseConfig
-	Caching  CachingConfig
-	Jwt      JwtConfig
+	Env          Env
+	App          AppConfig
+	Server       ServerConfig
+	Cors         CorsConfig
+	Metrics      MetricsConfig
+	Tracing      TracingConfig
+	Log          LogConfig
+	Database     DatabaseConfig
+	Caching      CachingConfig
+	HttpCache    HttpCacheConfig
+	Jwt          JwtConfig
+	TwoFactor    TwoFactorConfig
+	Trash        TrashConfig
+	Firebase     FirebaseConfig
+	Mail         MailConfig
+	Notifier     NotifierConfig
+	Stream       StreamConfig
+	Subscription SubscriptionConfig
+	Storage      StorageConfig
+	Media        MediaConfig
+	Github       GithubConfig
 }
 
 type AppConfig struct {
@@ -19,10 +31,12 @@ type AppConfig struct {
 }
 
 type ServerConfig struct {
-	Address      string
-	ReadTimeout  int
-	WriteTimeout int
-	IdleTimeout  int
+	Address            string
+	ReadTimeout        int
+	WriteTimeout       int
+	IdleTimeout        int
+	ShutdownDrainDelay int // seconds /readyz reports not-ready before the listener closes
+	ReadinessTimeout   int // milliseconds each readiness check may take
 }
 
 type CorsConfig struct {
@@ -38,6 +52,14 @@ type MetricsConfig struct {
 	Port    int
 }
 
+type TracingConfig struct {
+	Enabled      bool
+	Exporter     string // otlp or stdout
+	OtlpEndpoint string // host:port of an OTLP/HTTP collector
+	OtlpInsecure bool
+	SampleRatio  float64
+}
+
 type LogConfig struct {
 	SavePath          string
 	FileName          string
@@ -66,9 +88,13 @@ type DatabaseConfig struct {
 	IdleTimeout     int
 	ConnectTimeout  int
 	TimeZone        string
+	AutoMigrate     bool // apply pending migrations on startup instead of refusing to start
 }
 
 type CachingConfig struct {
+	Driver       string // memory or redis
+	TTL          int    // seconds a cached read stays valid
+	MaxEntries   int    // capacity of the in-memory LRU
 	Host         string
 	Port         int
 	Password     string
@@ -78,9 +104,94 @@ type CachingConfig struct {
 	MinIdleConns int
 }
 
+// HttpCacheConfig holds the Cache-Control policy sent on anonymous GETs of each public route group
+type HttpCacheConfig struct {
+	BlogsPolicy       string
+	ProjectsPolicy    string
+	ExperiencesPolicy string
+	CategoriesPolicy  string
+	TagsPolicy        string
+}
+
 type JwtConfig struct {
 	Algorithm          string
 	KeySize            int
+	KeysPath           string
+	MaxRetiredKeys     int
+	AccessSecret       string
+	RefreshSecret      string
 	AccessTokenExpiry  int
 	RefreshTokenExpiry int
 }
+
+type TwoFactorConfig struct {
+	Issuer               string
+	RequiredForAdmin     bool
+	ChallengeTokenExpiry int
+	RecoveryCodeCount    int
+	MaxAttempts          int    // failed codes in a row before the second factor is locked
+	LockoutDuration      int    // seconds the second factor stays locked
+	EncryptionKey        string // base64 AES-256 key sealing TOTP secrets, generated under the JWT keys path when empty
+}
+
+type TrashConfig struct {
+	RetentionDays int // soft-deleted rows older than this are purged
+	PurgeInterval int // seconds between purge runs, 0 disables the job
+}
+
+type FirebaseConfig struct {
+	Enabled         bool   // false records pushes in memory instead of sending them
+	CredentialsFile string // service account JSON, relative to the working directory
+}
+
+type MailConfig struct {
+	Enabled  bool // false records emails in memory instead of sending them
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+type NotifierConfig struct {
+	WebhookTimeout int // seconds a webhook delivery may take
+}
+
+type StreamConfig struct {
+	MaxConnectionsPerAccount int
+	ReplayBufferSize         int // events kept for Last-Event-ID resume
+	HeartbeatInterval        int // seconds between heartbeats on idle streams
+}
+
+type SubscriptionConfig struct {
+	ConfirmURL     string // page of the frontend confirming email subscriptions, gets ?token=
+	UnsubscribeURL string // page of the frontend unsubscribing emails, gets ?token=
+}
+
+type StorageConfig struct {
+	Driver         string // local or s3
+	LocalRoot      string // directory of the local driver
+	PublicURL      string // base URL files are served from, defaults to the bucket URL for s3
+	S3Endpoint     string
+	S3Region       string
+	S3Bucket       string
+	S3AccessKey    string
+	S3SecretKey    string
+	S3UsePathStyle bool // required by MinIO
+}
+
+type MediaConfig struct {
+	MaxUploadSize int // megabytes
+	GCInterval    int // seconds between garbage collections, 0 disables the job
+	GCGracePeriod int // hours an unreferenced upload is kept before being collected
+
+	ProcessingWorkers   int // images resized at the same time
+	ProcessingQueueSize int // images waiting for a worker, further uploads wait for the next restart
+}
+
+type GithubConfig struct {
+	APIURL       string // REST API base URL, e.g. a local fake server in tests
+	Token        string // optional, raises the rate limit from 60 to 5000 requests an hour
+	Timeout      int    // seconds per request
+	SyncInterval int    // seconds between syncs of the project repositories, 0 disables the job
+}
@@ -0,0 +1,25 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache is a key/value store for serialized read models. Implementations must be safe for
+// concurrent use. A missing or expired key is reported by ok == false, not by an error.
+type Cache interface {
+	// Get returns the value stored under key
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+
+	// Set stores value under key, a zero ttl keeps it until it is evicted or deleted
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the given keys, missing keys are ignored
+	Delete(ctx context.Context, keys ...string) error
+
+	// DeletePrefix removes every key starting with prefix
+	DeletePrefix(ctx context.Context, prefix string) error
+
+	// Close releases the underlying connections
+	Close() error
+}
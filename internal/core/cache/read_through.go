@@ -0,0 +1,141 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"hinsun-backend/internal/core/log"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// Stats counts lookups of one namespace since startup
+type Stats struct {
+	Namespace string `json:"namespace"`
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+}
+
+type counters struct {
+	hits   atomic.Uint64
+	misses atomic.Uint64
+
+	// generation is bumped by every invalidation, loads that started before it are not stored
+	generation atomic.Uint64
+}
+
+// ReadThrough loads values on a miss and stores them for later reads. Keys are grouped in
+// namespaces (e.g. "blogs") so that a write can drop everything derived from a resource.
+// Concurrent misses on the same key share a single load, which keeps a hot key expiring
+// from sending a burst of identical queries to the database.
+type ReadThrough struct {
+	store    Cache
+	ttl      time.Duration
+	group    singleflight.Group
+	counters sync.Map // namespace -> *counters
+}
+
+// NewReadThrough creates a read-through cache on top of store, entries live for ttl
+func NewReadThrough(store Cache, ttl time.Duration) *ReadThrough {
+	return &ReadThrough{
+		store: store,
+		ttl:   ttl,
+	}
+}
+
+// Fetch returns the cached value of namespace/key, or calls load and caches its result.
+// Errors from the store are logged and treated as a miss, so the cache never fails a read.
+// Values are stored as JSON and every caller decodes its own copy.
+func Fetch[T any](ctx context.Context, c *ReadThrough, namespace string, key string, load func(ctx context.Context) (T, error)) (T, error) {
+	var value T
+	fullKey := namespace + ":" + key
+	stats := c.countersFor(namespace)
+
+	cached, ok, err := c.store.Get(ctx, fullKey)
+	if err != nil {
+		log.FromContext(ctx).Warn("⚠️ Cache read failed", zap.String("key", fullKey), zap.Error(err))
+	}
+
+	if ok && json.Unmarshal(cached, &value) == nil {
+		stats.hits.Add(1)
+		return value, nil
+	}
+
+	stats.misses.Add(1)
+	encoded, err, _ := c.group.Do(fullKey, func() (any, error) {
+		generation := stats.generation.Load()
+		loaded, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(loaded)
+		if err != nil {
+			return nil, err
+		}
+
+		if stats.generation.Load() != generation {
+			return data, nil
+		}
+
+		if err := c.store.Set(ctx, fullKey, data, c.ttl); err != nil {
+			log.FromContext(ctx).Warn("⚠️ Cache write failed", zap.String("key", fullKey), zap.Error(err))
+		}
+
+		return data, nil
+	})
+
+	if err != nil {
+		return value, err
+	}
+
+	if err := json.Unmarshal(encoded.([]byte), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Invalidate drops every entry of the given namespaces. Failures are logged and left to
+// expire with the ttl, since the write that triggered them has already been committed.
+func (c *ReadThrough) Invalidate(ctx context.Context, namespaces ...string) {
+	for _, namespace := range namespaces {
+		c.countersFor(namespace).generation.Add(1)
+		if err := c.store.DeletePrefix(ctx, namespace+":"); err != nil {
+			log.FromContext(ctx).Warn("⚠️ Cache invalidation failed", zap.String("namespace", namespace), zap.Error(err))
+		}
+	}
+}
+
+// Stats returns hit and miss counts per namespace, sorted by namespace
+func (c *ReadThrough) Stats() []Stats {
+	stats := make([]Stats, 0)
+	c.counters.Range(func(key, value any) bool {
+		namespaceCounters := value.(*counters)
+		stats = append(stats, Stats{
+			Namespace: key.(string),
+			Hits:      namespaceCounters.hits.Load(),
+			Misses:    namespaceCounters.misses.Load(),
+		})
+		return true
+	})
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Namespace < stats[j].Namespace
+	})
+
+	return stats
+}
+
+func (c *ReadThrough) countersFor(namespace string) *counters {
+	if existing, ok := c.counters.Load(namespace); ok {
+		return existing.(*counters)
+	}
+
+	actual, _ := c.counters.LoadOrStore(namespace, &counters{})
+	return actual.(*counters)
+}
@@ -2,13 +2,24 @@ package events
 
 import (
 	"context"
+	"hinsun-backend/internal/core/log"
 	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 )
 
 type AsyncEventBus struct {
 	handlers []EventHandler
 	mutex    sync.RWMutex
 	wg       sync.WaitGroup
+
+	pending  atomic.Int64  // handlers started and not yet finished
+	failures atomic.Uint64 // handlers that returned an error
 }
 
 // NewAsyncEventBus creates a new asynchronous event bus
@@ -25,12 +36,23 @@ func (b *AsyncEventBus) Publish(ctx context.Context, event Event) error {
 		if handler.InterestedIn(event.EventName()) {
 			// Increase the wait group counter
 			b.wg.Add(1)
+			b.pending.Add(1)
 
 			// Execute handler asynchronously
 			go func(h EventHandler, e Event) {
 				defer b.wg.Done()
-				// Handle errors in background, you might want to log them
-				_ = h.HandleEvent(ctx, e)
+				defer b.pending.Add(-1)
+
+				handlerCtx, span := startHandlerSpan(ctx, e)
+				defer span.End()
+
+				// Handle errors in background, the publisher has already moved on
+				if err := h.HandleEvent(handlerCtx, e); err != nil {
+					b.failures.Add(1)
+					span.RecordError(err)
+					span.SetStatus(codes.Error, err.Error())
+					log.FromContext(handlerCtx).Error("❌ Event handler failed", zap.String("event", e.EventName()), zap.Error(err))
+				}
 			}(handler, event)
 		}
 	}
@@ -38,6 +60,20 @@ func (b *AsyncEventBus) Publish(ctx context.Context, event Event) error {
 	return nil
 }
 
+// startHandlerSpan detaches the handler from the publishing request, which may finish first. The
+// handler runs in a trace of its own, linked to the span that published the event.
+func startHandlerSpan(ctx context.Context, event Event) (context.Context, trace.Span) {
+	return otel.Tracer("hinsun-backend/events").Start(context.WithoutCancel(ctx), "event "+event.EventName(),
+		trace.WithNewRoot(),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithLinks(trace.LinkFromContext(ctx)),
+		trace.WithAttributes(
+			attribute.String("event.id", event.EventID()),
+			attribute.String("event.aggregate_id", event.AggregateID()),
+		),
+	)
+}
+
 // Subscribe registers an event handler
 func (b *AsyncEventBus) Subscribe(handler EventHandler) {
 	b.mutex.Lock()
@@ -59,6 +95,16 @@ func (b *AsyncEventBus) Unsubscribe(handler EventHandler) {
 	}
 }
 
+// Pending returns the number of handlers that are still running
+func (b *AsyncEventBus) Pending() int64 {
+	return b.pending.Load()
+}
+
+// Failures returns the number of handler calls that returned an error since startup
+func (b *AsyncEventBus) Failures() uint64 {
+	return b.failures.Load()
+}
+
 // Wait waits for all async handlers to complete (useful for testing or graceful shutdown)
 func (b *AsyncEventBus) Wait() {
 	b.wg.Wait()
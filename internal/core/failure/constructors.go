@@ -26,6 +26,15 @@ func NewConflictFailure(message string) *Failure {
 	return NewFailure(ConflictFailure, message)
 }
 
+// CurrentVersionDetail is the failure detail holding the version a stale write lost against
+const CurrentVersionDetail = "currentVersion"
+
+// NewVersionConflictFailure reports an update based on an outdated version of the resource
+func NewVersionConflictFailure(currentVersion int64) *Failure {
+	return NewConflictFailure("The resource was modified since it was read, reload it and try again").
+		WithDetails(CurrentVersionDetail, currentVersion)
+}
+
 func NewInternalFailure(message string, cause error) *Failure {
 	return NewFailure(InternalFailure, message).WithCause(cause)
 }
@@ -33,3 +42,11 @@ func NewInternalFailure(message string, cause error) *Failure {
 func NewAuthenticationFailure(message string) *Failure {
 	return NewFailure(UnauthorizedFailure, message)
 }
+
+func NewForbiddenFailure(message string) *Failure {
+	return NewFailure(ForbiddenFailure, message)
+}
+
+func NewTooManyRequestsFailure(message string) *Failure {
+	return NewFailure(TooManyRequestsFailure, message)
+}
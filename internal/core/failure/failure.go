@@ -37,6 +37,9 @@ const (
 
 	// DatabaseFailure represents database related errors
 	DatabaseFailure FailureCode = "DATABASE_FAILURE"
+
+	// TooManyRequestsFailure represents a caller exceeding a usage limit
+	TooManyRequestsFailure FailureCode = "TOO_MANY_REQUESTS"
 )
 
 type Failure struct {
@@ -71,6 +74,11 @@ func (f *Failure) WithCause(err error) *Failure {
 	return f
 }
 
+// Unwrap exposes the cause to errors.Is and errors.As
+func (f *Failure) Unwrap() error {
+	return f.Cause
+}
+
 // Is check if the error is a Failure
 func Is(err error, code FailureCode) bool {
 	if failure, ok := err.(*Failure); ok {
@@ -0,0 +1,58 @@
+package log
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type logContextKey struct{}
+
+// ContextWithLogContext returns a copy of ctx carrying lc merged over the log context already in
+// ctx, so middlewares can add fields (e.g. the account ID once the token has been validated)
+func ContextWithLogContext(ctx context.Context, lc *LogContext) context.Context {
+	merged := *LogContextFromContext(ctx)
+	if lc.RequestID != "" {
+		merged.RequestID = lc.RequestID
+	}
+
+	if lc.AccountID != "" {
+		merged.AccountID = lc.AccountID
+	}
+
+	if lc.Operation != "" {
+		merged.Operation = lc.Operation
+	}
+
+	if lc.Component != "" {
+		merged.Component = lc.Component
+	}
+
+	return context.WithValue(ctx, logContextKey{}, &merged)
+}
+
+// LogContextFromContext returns the log context stored in ctx, or an empty one.
+// Trace and span IDs always come from the span active in ctx rather than from the stored copy.
+func LogContextFromContext(ctx context.Context) *LogContext {
+	lc := NewLogContext()
+	if stored, ok := ctx.Value(logContextKey{}).(*LogContext); ok {
+		*lc = *stored
+	}
+
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		lc.WithTraceID(spanContext.TraceID().String()).WithSpanID(spanContext.SpanID().String())
+	}
+
+	return lc
+}
+
+// FromContext returns the global logger with the request, account, trace and span IDs of ctx
+func FromContext(ctx context.Context) *zap.Logger {
+	fields := LogContextFromContext(ctx).ToFields()
+	if len(fields) == 0 {
+		return Logger
+	}
+
+	return Logger.With(fields...)
+}
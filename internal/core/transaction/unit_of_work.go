@@ -0,0 +1,29 @@
+package transaction
+
+import (
+	"context"
+)
+
+// IsolationLevel is the transaction isolation requested by a use case
+type IsolationLevel int
+
+const (
+	// DefaultIsolation uses the database default, READ COMMITTED for PostgreSQL
+	DefaultIsolation IsolationLevel = iota
+	ReadCommitted
+	RepeatableRead
+	Serializable
+)
+
+// UnitOfWork runs a use case inside a single transaction. The transaction travels in the
+// context passed to fn, and every repository called with that context joins it. Work is
+// committed when fn returns nil and rolled back otherwise.
+type UnitOfWork interface {
+	// Do runs fn in a transaction with the default isolation level
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+
+	// DoWithIsolation runs fn in a transaction with the given isolation level. Serializable
+	// transactions that lose a conflict are retried, so fn must be safe to run again.
+	// Inside an existing transaction fn runs in a savepoint and the level is ignored.
+	DoWithIsolation(ctx context.Context, isolation IsolationLevel, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,42 @@
+package types
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Page holds the items of one page of a list and how many items the whole list has
+type Page[T any] struct {
+	Items []T   `json:"items"`
+	Total int64 `json:"total"`
+}
+
+// PageMeta describes the page a list response holds, it is sent in the meta of the response
+type PageMeta struct {
+	Page       int   `json:"page"`
+	PageSize   int   `json:"pageSize"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"totalPages"`
+}
+
+// NormalizePage applies the defaults to a requested page, pages start at 1
+func NormalizePage(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+
+	return page, min(pageSize, MaxPageSize)
+}
+
+func NewPageMeta(page, pageSize int, total int64) *PageMeta {
+	return &PageMeta{
+		Page:       page,
+		PageSize:   pageSize,
+		Total:      total,
+		TotalPages: int((total + int64(pageSize) - 1) / int64(pageSize)),
+	}
+}
@@ -0,0 +1,158 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hinsun-backend/internal/core/log"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrQueueFull  = errors.New("worker queue is full")
+	ErrPoolClosed = errors.New("worker pool is closed")
+)
+
+// Job is a unit of background work, ctx is cancelled when the pool stops
+type Job func(ctx context.Context) error
+
+type task struct {
+	name string
+	ctx  context.Context
+	job  Job
+}
+
+// Pool runs jobs on a fixed number of goroutines, so heavy work such as image processing cannot
+// take more than its share of the CPU however many requests queue it
+type Pool struct {
+	workers int
+	queue   chan task
+	mutex   sync.RWMutex
+	closed  bool
+	wg      sync.WaitGroup
+
+	ctx    context.Context // cancelled once the running jobs must give up
+	cancel context.CancelFunc
+
+	pending  atomic.Int64  // jobs queued or running
+	failures atomic.Uint64 // jobs that returned an error or panicked
+}
+
+// NewPool creates a pool of workers goroutines, with room for queueSize jobs waiting for one
+func NewPool(workers, queueSize int) *Pool {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Pool{
+		workers: max(1, workers),
+		queue:   make(chan task, max(0, queueSize)),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Start launches the workers
+func (p *Pool) Start() {
+	for range p.workers {
+		p.wg.Add(1)
+		go p.work()
+	}
+}
+
+// Submit queues a job without blocking. The job keeps the values of ctx, such as the logger and
+// the trace, but not its cancellation since the request submitting it usually ends first.
+func (p *Pool) Submit(ctx context.Context, name string, job Job) error {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	p.pending.Add(1)
+	select {
+	case p.queue <- task{name: name, ctx: context.WithoutCancel(ctx), job: job}:
+		return nil
+	default:
+		p.pending.Add(-1)
+		return ErrQueueFull
+	}
+}
+
+// Stop stops accepting jobs, drops the queued ones and waits for the running ones until ctx is
+// done, then cancels them. Work that was dropped is expected to be resumed on the next start.
+func (p *Pool) Stop(ctx context.Context) error {
+	p.mutex.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+	p.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.cancel()
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		return ctx.Err()
+	}
+}
+
+// Pending returns the number of jobs queued or running
+func (p *Pool) Pending() int64 {
+	return p.pending.Load()
+}
+
+// Failures returns the number of jobs that failed since startup
+func (p *Pool) Failures() uint64 {
+	return p.failures.Load()
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+
+	for t := range p.queue {
+		p.run(t)
+	}
+}
+
+func (p *Pool) run(t task) {
+	defer p.pending.Add(-1)
+
+	// Once stopping, what is still queued is dropped
+	p.mutex.RLock()
+	closed := p.closed
+	p.mutex.RUnlock()
+	if closed {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(t.ctx)
+	defer cancel()
+	stop := context.AfterFunc(p.ctx, cancel)
+	defer stop()
+
+	if err := p.call(ctx, t.job); err != nil {
+		p.failures.Add(1)
+		log.FromContext(ctx).Error("❌ Background job failed", zap.String("job", t.name), zap.Error(err))
+	}
+}
+
+// call runs the job, turning a panic into an error so one bad input cannot kill a worker
+func (p *Pool) call(ctx context.Context, job Job) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("job panicked: %v", recovered)
+		}
+	}()
+
+	return job(ctx)
+}
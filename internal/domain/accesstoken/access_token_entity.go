@@ -0,0 +1,156 @@
+package accesstoken
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/values"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// TokenPrefix marks personal access tokens so they can be told apart from JWTs
+	TokenPrefix = "hs_pat_"
+
+	MaxNameLength     = 100
+	MaxExpiryDays     = 365
+	lookupIDBytes     = 8  // 16 hex characters, stored in plain text to find the token
+	secretBytes       = 32 // stored hashed only
+	lastUsedPrecision = int64(60)
+)
+
+type AccessTokenEntity struct {
+	ID         uuid.UUID           `json:"id"`
+	AccountID  uuid.UUID           `json:"accountId"`
+	Name       string              `json:"name"`
+	LookupID   string              `json:"prefix"` // shown so owners can recognise their tokens
+	SecretHash string              `json:"-"`
+	Scopes     []values.Permission `json:"scopes"`
+	TwoFactor  bool                `json:"twoFactor"` // created from a session that passed a second factor
+	ExpiresAt  *int64              `json:"expiresAt,omitempty"`
+	LastUsedAt *int64              `json:"lastUsedAt,omitempty"`
+	RevokedAt  *int64              `json:"revokedAt,omitempty"`
+	CreatedAt  int64               `json:"createdAt"`
+}
+
+// NewAccessToken creates a token record; expiryDays of 0 means the token never expires
+func NewAccessToken(
+	accountID uuid.UUID,
+	name string,
+	lookupID string,
+	secretHash string,
+	scopes []values.Permission,
+	twoFactor bool,
+	expiryDays int,
+) (*AccessTokenEntity, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > MaxNameLength {
+		return nil, failure.NewValidationFailure("access token name must be between 1 and 100 characters")
+	}
+
+	if expiryDays < 0 || expiryDays > MaxExpiryDays {
+		return nil, failure.NewValidationFailure("access token expiry must be between 1 and 365 days")
+	}
+
+	now := time.Now()
+	token := &AccessTokenEntity{
+		ID:         uuid.New(),
+		AccountID:  accountID,
+		Name:       name,
+		LookupID:   lookupID,
+		SecretHash: secretHash,
+		Scopes:     scopes,
+		TwoFactor:  twoFactor,
+		CreatedAt:  now.Unix(),
+	}
+
+	if expiryDays > 0 {
+		expiresAt := now.AddDate(0, 0, expiryDays).Unix()
+		token.ExpiresAt = &expiresAt
+	}
+
+	return token, nil
+}
+
+// IsRevoked reports whether the token was revoked by its owner
+func (t *AccessTokenEntity) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// IsExpired reports whether the token expired at the given time
+func (t *AccessTokenEntity) IsExpired(at time.Time) bool {
+	return t.ExpiresAt != nil && at.Unix() >= *t.ExpiresAt
+}
+
+func (t *AccessTokenEntity) Revoke() error {
+	if t.IsRevoked() {
+		return failure.NewConflictFailure("access token is already revoked")
+	}
+
+	now := time.Now().Unix()
+	t.RevokedAt = &now
+	return nil
+}
+
+// MarkUsed records usage and reports whether it changed; updates are coarsened to a minute
+// so that busy automation does not write to the database on every request
+func (t *AccessTokenEntity) MarkUsed(at time.Time) bool {
+	if t.LastUsedAt != nil && at.Unix()-*t.LastUsedAt < lastUsedPrecision {
+		return false
+	}
+
+	usedAt := at.Unix()
+	t.LastUsedAt = &usedAt
+	return true
+}
+
+// EffectivePermissions limits the token scopes to what the owner currently holds,
+// so removing a role from an account also narrows its tokens
+func (t *AccessTokenEntity) EffectivePermissions(granted []values.Permission) []values.Permission {
+	permissions := make([]values.Permission, 0, len(t.Scopes))
+	for _, scope := range t.Scopes {
+		if slices.Contains(granted, scope) {
+			permissions = append(permissions, scope)
+		}
+	}
+
+	return permissions
+}
+
+// GenerateToken returns a new plain token in the form hs_pat_<lookup id>_<secret> with its parts
+func GenerateToken() (token, lookupID, secret string, err error) {
+	lookup := make([]byte, lookupIDBytes)
+	if _, err := rand.Read(lookup); err != nil {
+		return "", "", "", err
+	}
+
+	random := make([]byte, secretBytes)
+	if _, err := rand.Read(random); err != nil {
+		return "", "", "", err
+	}
+
+	lookupID = hex.EncodeToString(lookup)
+	secret = base64.RawURLEncoding.EncodeToString(random)
+	return TokenPrefix + lookupID + "_" + secret, lookupID, secret, nil
+}
+
+// ParseToken splits a plain token into its lookup id and secret
+func ParseToken(token string) (lookupID, secret string, err error) {
+	rest, ok := strings.CutPrefix(token, TokenPrefix)
+	lookupLength := lookupIDBytes * 2
+	if !ok || len(rest) <= lookupLength+1 || rest[lookupLength] != '_' {
+		return "", "", failure.NewAuthenticationFailure("malformed access token")
+	}
+
+	return rest[:lookupLength], rest[lookupLength+1:], nil
+}
+
+// IsAccessToken reports whether the bearer credential is a personal access token
+func IsAccessToken(token string) bool {
+	return strings.HasPrefix(token, TokenPrefix)
+}
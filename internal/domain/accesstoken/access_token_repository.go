@@ -0,0 +1,13 @@
+package accesstoken
+
+import (
+	"context"
+)
+
+type AccessTokenRepository interface {
+	Create(ctx context.Context, token *AccessTokenEntity) error
+	Update(ctx context.Context, token *AccessTokenEntity) (int, error)
+	FindByID(ctx context.Context, id string) (*AccessTokenEntity, error)
+	FindByLookupID(ctx context.Context, lookupID string) (*AccessTokenEntity, error)
+	FindByAccountID(ctx context.Context, accountID string) ([]*AccessTokenEntity, error)
+}
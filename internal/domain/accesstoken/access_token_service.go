@@ -0,0 +1,134 @@
+package accesstoken
+
+import (
+	"context"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/values"
+	"hinsun-backend/pkg/security"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AccessTokenService interface {
+	IssueToken(ctx context.Context, accountID uuid.UUID, name string, scopes []values.Permission, twoFactor bool, expiryDays int) (*AccessTokenEntity, string, error)
+	AuthenticateToken(ctx context.Context, token string) (*AccessTokenEntity, error)
+	FindAccountTokens(ctx context.Context, accountID string) ([]*AccessTokenEntity, error)
+	RevokeToken(ctx context.Context, accountID, id string) (*AccessTokenEntity, error)
+}
+
+type accessTokenService struct {
+	repository     AccessTokenRepository
+	passwordHasher security.PasswordHasher
+}
+
+func NewAccessTokenService(repository AccessTokenRepository, passwordHasher security.PasswordHasher) AccessTokenService {
+	return &accessTokenService{
+		repository:     repository,
+		passwordHasher: passwordHasher,
+	}
+}
+
+// IssueToken stores a new token and returns it together with the plain token, which is never stored
+func (s *accessTokenService) IssueToken(
+	ctx context.Context,
+	accountID uuid.UUID,
+	name string,
+	scopes []values.Permission,
+	twoFactor bool,
+	expiryDays int,
+) (*AccessTokenEntity, string, error) {
+	// 1. Generate token and hash its secret
+	plainToken, lookupID, secret, err := GenerateToken()
+	if err != nil {
+		return nil, "", failure.NewInternalFailure("failed to generate access token", err)
+	}
+
+	secretHash, err := s.passwordHasher.Hash(secret)
+	if err != nil {
+		return nil, "", failure.NewInternalFailure("failed to hash access token", err)
+	}
+
+	// 2. Create new token entity
+	token, err := NewAccessToken(accountID, name, lookupID, secretHash, scopes, twoFactor, expiryDays)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// 3. Save to repository
+	if err := s.repository.Create(ctx, token); err != nil {
+		return nil, "", err
+	}
+
+	return token, plainToken, nil
+}
+
+// AuthenticateToken verifies a plain token and records its usage
+func (s *accessTokenService) AuthenticateToken(ctx context.Context, plainToken string) (*AccessTokenEntity, error) {
+	// 1. Find the token by its lookup id
+	lookupID, secret, err := ParseToken(plainToken)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.repository.FindByLookupID(ctx, lookupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if token == nil {
+		return nil, failure.NewAuthenticationFailure("invalid access token")
+	}
+
+	// 2. Verify secret against the stored hash
+	valid, err := s.passwordHasher.Verify(secret, token.SecretHash)
+	if err != nil || !valid {
+		return nil, failure.NewAuthenticationFailure("invalid access token")
+	}
+
+	// 3. Reject revoked and expired tokens
+	now := time.Now()
+	if token.IsRevoked() {
+		return nil, failure.NewAuthenticationFailure("access token has been revoked")
+	}
+
+	if token.IsExpired(now) {
+		return nil, failure.NewAuthenticationFailure("access token has expired")
+	}
+
+	// 4. Track last usage
+	if token.MarkUsed(now) {
+		if _, err := s.repository.Update(ctx, token); err != nil {
+			return nil, err
+		}
+	}
+
+	return token, nil
+}
+
+func (s *accessTokenService) FindAccountTokens(ctx context.Context, accountID string) ([]*AccessTokenEntity, error) {
+	return s.repository.FindByAccountID(ctx, accountID)
+}
+
+// RevokeToken revokes a token owned by the given account
+func (s *accessTokenService) RevokeToken(ctx context.Context, accountID, id string) (*AccessTokenEntity, error) {
+	token, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tokens of other accounts are reported as missing to avoid leaking their existence
+	if token == nil || token.AccountID.String() != accountID {
+		return nil, failure.NewNotFoundFailure("Access token with the given ID does not exist")
+	}
+
+	if err := token.Revoke(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repository.Update(ctx, token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
@@ -16,59 +16,78 @@ const (
 )
 
 type AccountEntity struct {
-	ID            uuid.UUID          `json:"id"`
-	Name          string             `json:"name"`
-	Email         *values.Email      `json:"email"`
-	EmailVerified bool               `json:"emailVerified"`
-	Password      string             `json:"-"`
-	Role          values.AccountRole `json:"role"`
-	IsActive      bool               `json:"isActive"`
-	Avatar        string             `json:"avatar"`
-	Bio           string             `json:"bio"`
-	CreatedAt     int64              `json:"createdAt"`
-	UpdatedAt     int64              `json:"updatedAt"`
-	DeletedAt     *int64             `json:"deletedAt,omitempty"`
+	ID             uuid.UUID            `json:"id"`
+	Name           string               `json:"name"`
+	Email          *values.Email        `json:"email"`
+	EmailVerified  bool                 `json:"emailVerified"`
+	Password       string               `json:"-"`
+	Role           values.AccountRole   `json:"role"`
+	IsActive       bool                 `json:"isActive"`
+	Avatar         string               `json:"avatar"`
+	AvatarVariants values.ImageVariants `json:"avatarVariants,omitempty"` // resolved on read, not persisted
+	Bio            string               `json:"bio"`
+	CreatedAt      int64                `json:"createdAt"`
+	UpdatedAt      int64                `json:"updatedAt"`
+	Version        int64                `json:"version"`
+	DeletedAt      *int64               `json:"deletedAt,omitempty"`
+
+	// Two-factor authentication (TOTP). The secret is set during enrolment and
+	// only takes effect once TwoFactorEnabled is true.
+	TwoFactorEnabled bool     `json:"twoFactorEnabled"`
+	TwoFactorSecret  string   `json:"-"` // sealed, see auth.AuthService.SealTwoFactorSecret
+	RecoveryCodes    []string `json:"-"` // hashed one-time recovery codes
+	// TwoFactorLastStep is the TOTP time step of the last accepted code; codes up to it are rejected
+	TwoFactorLastStep int64 `json:"-"`
 }
 
 type PublicJSON struct {
-	ID            uuid.UUID `json:"id"`
-	Name          string    `json:"name"`
-	Email         string    `json:"email"`
-	EmailVerified bool      `json:"emailVerified"`
-	IsActive      bool      `json:"isActive"`
-	Avatar        string    `json:"avatar,omitempty"`
-	Bio           string    `json:"bio,omitempty"`
-	CreatedAt     int64     `json:"createdAt"`
-	UpdatedAt     int64     `json:"updatedAt"`
+	ID             uuid.UUID            `json:"id"`
+	Name           string               `json:"name"`
+	Email          string               `json:"email"`
+	EmailVerified  bool                 `json:"emailVerified"`
+	IsActive       bool                 `json:"isActive"`
+	Avatar         string               `json:"avatar,omitempty"`
+	AvatarVariants values.ImageVariants `json:"avatarVariants,omitempty"`
+	Bio            string               `json:"bio,omitempty"`
+	TwoFactor      bool                 `json:"twoFactorEnabled"`
+	CreatedAt      int64                `json:"createdAt"`
+	UpdatedAt      int64                `json:"updatedAt"`
+	Version        int64                `json:"version"`
 }
 
 // MarshalJSON customizes JSON serialization to exclude password
 func (a AccountEntity) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&PublicJSON{
-		ID:            a.ID,
-		Name:          a.Name,
-		Email:         a.Email.Value(),
-		EmailVerified: a.EmailVerified,
-		IsActive:      a.IsActive,
-		Avatar:        a.Avatar,
-		Bio:           a.Bio,
-		CreatedAt:     a.CreatedAt,
-		UpdatedAt:     a.UpdatedAt,
+		ID:             a.ID,
+		Name:           a.Name,
+		Email:          a.Email.Value(),
+		EmailVerified:  a.EmailVerified,
+		IsActive:       a.IsActive,
+		Avatar:         a.Avatar,
+		AvatarVariants: a.AvatarVariants,
+		Bio:            a.Bio,
+		TwoFactor:      a.TwoFactorEnabled,
+		CreatedAt:      a.CreatedAt,
+		UpdatedAt:      a.UpdatedAt,
+		Version:        a.Version,
 	})
 }
 
 // ToPublicJSON converts AccountEntity to public representation
 func (a *AccountEntity) ToPublicJSON() *PublicJSON {
 	return &PublicJSON{
-		ID:            a.ID,
-		Name:          a.Name,
-		Email:         a.Email.Value(),
-		EmailVerified: a.EmailVerified,
-		IsActive:      a.IsActive,
-		Avatar:        a.Avatar,
-		Bio:           a.Bio,
-		CreatedAt:     a.CreatedAt,
-		UpdatedAt:     a.UpdatedAt,
+		ID:             a.ID,
+		Name:           a.Name,
+		Email:          a.Email.Value(),
+		EmailVerified:  a.EmailVerified,
+		IsActive:       a.IsActive,
+		Avatar:         a.Avatar,
+		AvatarVariants: a.AvatarVariants,
+		Bio:            a.Bio,
+		TwoFactor:      a.TwoFactorEnabled,
+		CreatedAt:      a.CreatedAt,
+		UpdatedAt:      a.UpdatedAt,
+		Version:        a.Version,
 	}
 }
 
@@ -99,6 +118,7 @@ func NewAccount(
 		Bio:           bio,
 		CreatedAt:     now.Unix(),
 		UpdatedAt:     now.Unix(),
+		Version:       1,
 		DeletedAt:     nil,
 	}, nil
 }
@@ -127,6 +147,66 @@ func (a *AccountEntity) Update(
 	return nil
 }
 
+// RequiresTwoFactor reports whether a login for this account needs a second factor
+func (a *AccountEntity) RequiresTwoFactor() bool {
+	return a.TwoFactorEnabled && a.TwoFactorSecret != ""
+}
+
+// StartTwoFactorEnrollment stores a pending sealed secret; it is not enforced until activated
+func (a *AccountEntity) StartTwoFactorEnrollment(sealedSecret string) error {
+	if a.TwoFactorEnabled {
+		return failure.NewConflictFailure("two-factor authentication is already enabled")
+	}
+
+	a.TwoFactorSecret = sealedSecret
+	a.UpdatedAt = time.Now().Unix()
+	return nil
+}
+
+// ActivateTwoFactor enables the pending secret together with hashed recovery codes
+func (a *AccountEntity) ActivateTwoFactor(hashedRecoveryCodes []string) error {
+	if a.TwoFactorEnabled {
+		return failure.NewConflictFailure("two-factor authentication is already enabled")
+	}
+
+	if a.TwoFactorSecret == "" {
+		return failure.NewValidationFailure("two-factor enrollment has not been started")
+	}
+
+	a.TwoFactorEnabled = true
+	a.RecoveryCodes = hashedRecoveryCodes
+	a.UpdatedAt = time.Now().Unix()
+	return nil
+}
+
+// DisableTwoFactor removes the secret and every remaining recovery code
+func (a *AccountEntity) DisableTwoFactor() {
+	a.TwoFactorEnabled = false
+	a.TwoFactorSecret = ""
+	a.RecoveryCodes = []string{}
+	a.UpdatedAt = time.Now().Unix()
+}
+
+// ReplaceRecoveryCodes replaces the hashed recovery codes
+func (a *AccountEntity) ReplaceRecoveryCodes(hashedRecoveryCodes []string) {
+	a.RecoveryCodes = hashedRecoveryCodes
+	a.UpdatedAt = time.Now().Unix()
+}
+
+// ConsumeRecoveryCode removes the recovery code at the given index so it cannot be reused
+func (a *AccountEntity) ConsumeRecoveryCode(index int) {
+	if index < 0 || index >= len(a.RecoveryCodes) {
+		return
+	}
+
+	codes := make([]string, 0, len(a.RecoveryCodes)-1)
+	codes = append(codes, a.RecoveryCodes[:index]...)
+	codes = append(codes, a.RecoveryCodes[index+1:]...)
+
+	a.RecoveryCodes = codes
+	a.UpdatedAt = time.Now().Unix()
+}
+
 func ValidateName(name string) error {
 	if len(name) == 0 {
 		return failure.NewValidationFailure("name cannot be empty")
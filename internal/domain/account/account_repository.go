@@ -9,6 +9,19 @@ type AccountRepository interface {
 	Create(ctx context.Context, account *AccountEntity) error
 	FindByEmail(ctx context.Context, email *values.Email) (*AccountEntity, error)
 	Update(ctx context.Context, account *AccountEntity) (int, error)
+	UpdateTwoFactor(ctx context.Context, account *AccountEntity) (int, error)
+	// AcceptTwoFactorStep stores the step only when it is newer than the stored one
+	AcceptTwoFactorStep(ctx context.Context, id string, step int64) (int, error)
+	// ReserveTwoFactorAttempt counts an attempt and sets lockedUntil once maxAttempts is reached.
+	// It changes no row while the account is locked at now.
+	ReserveTwoFactorAttempt(ctx context.Context, id string, maxAttempts int, now, lockedUntil int64) (int, error)
+	ClearTwoFactorAttempts(ctx context.Context, id string) error
+	// FindWithTwoFactorSecret returns the accounts enrolled or enrolling in two-factor authentication
+	FindWithTwoFactorSecret(ctx context.Context) ([]*AccountEntity, error)
+	// ReplaceTwoFactorSecret stores the new secret only if the stored one is still the old one
+	ReplaceTwoFactorSecret(ctx context.Context, id string, oldSecret, newSecret string) (int, error)
+	// RemoveRecoveryCode removes the hashed code only if it is still stored
+	RemoveRecoveryCode(ctx context.Context, id string, hashedCode string) (int, error)
 	Delete(ctx context.Context, id string) (int, error)
 	DeleteMany(ctx context.Context, ids []string) (int, error)
 	FindByID(ctx context.Context, id string) (*AccountEntity, error)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"hinsun-backend/internal/core/failure"
 	"hinsun-backend/internal/domain/values"
+	"time"
 )
 
 type AccountService interface {
@@ -16,16 +17,29 @@ type AccountService interface {
 
 	FindAccountByID(ctx context.Context, id string) (*AccountEntity, error)
 	DeleteAccount(ctx context.Context, id string) (int, error)
-	UpdateAccount(ctx context.Context, id string, name string, email *values.Email, emailVerified bool, avatar, bio string) (*AccountEntity, error)
+	UpdateAccount(ctx context.Context, id string, name string, email *values.Email, emailVerified bool, avatar, bio string, expectedVersion *int64) (*AccountEntity, error)
+	SaveTwoFactorSettings(ctx context.Context, account *AccountEntity) error
+	AcceptTwoFactorStep(ctx context.Context, account *AccountEntity, step int64) error
+	// ReserveTwoFactorAttempt must precede checking a second factor and fails while the account is locked
+	ReserveTwoFactorAttempt(ctx context.Context, account *AccountEntity) error
+	ClearTwoFactorAttempts(ctx context.Context, account *AccountEntity) error
+	ConsumeRecoveryCode(ctx context.Context, account *AccountEntity, index int) error
+	FindTwoFactorAccounts(ctx context.Context) ([]*AccountEntity, error)
+	// ReplaceTwoFactorSecret reports false when the secret changed since the account was read
+	ReplaceTwoFactorSecret(ctx context.Context, account *AccountEntity, secret string) (bool, error)
 }
 
 type accountService struct {
-	repository AccountRepository
+	repository           AccountRepository
+	maxTwoFactorAttempts int
+	twoFactorLockout     time.Duration
 }
 
-func NewAccountService(repository AccountRepository) AccountService {
+func NewAccountService(repository AccountRepository, maxTwoFactorAttempts int, twoFactorLockout time.Duration) AccountService {
 	return &accountService{
-		repository: repository,
+		repository:           repository,
+		maxTwoFactorAttempts: maxTwoFactorAttempts,
+		twoFactorLockout:     twoFactorLockout,
 	}
 }
 
@@ -91,7 +105,7 @@ func (s *accountService) DeleteMultipleAccounts(ctx context.Context, ids []strin
 	return s.repository.DeleteMany(ctx, ids)
 }
 
-func (s *accountService) UpdateAccount(ctx context.Context, id string, name string, email *values.Email, emailVerified bool, avatar, bio string) (*AccountEntity, error) {
+func (s *accountService) UpdateAccount(ctx context.Context, id string, name string, email *values.Email, emailVerified bool, avatar, bio string, expectedVersion *int64) (*AccountEntity, error) {
 	// 1. Retrieve existing account
 	existingAccount, err := s.repository.FindByID(ctx, id)
 	if err != nil {
@@ -102,6 +116,11 @@ func (s *accountService) UpdateAccount(ctx context.Context, id string, name stri
 		return nil, failure.NewNotFoundFailure("Account with the given ID does not exist")
 	}
 
+	// The update must be based on the version the client last read
+	if err := values.CheckVersion(existingAccount.Version, expectedVersion); err != nil {
+		return nil, err
+	}
+
 	fmt.Println("Existing Account Email:", existingAccount.Email.Value())
 
 	// 2. Check for email conflict if email is being changed
@@ -141,3 +160,98 @@ func (s *accountService) UpdateAccount(ctx context.Context, id string, name stri
 
 	return existingAccount, nil
 }
+
+// SaveTwoFactorSettings persists the two-factor state of the account.
+func (s *accountService) SaveTwoFactorSettings(ctx context.Context, account *AccountEntity) error {
+	rowsAffected, err := s.repository.UpdateTwoFactor(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return failure.NewNotFoundFailure("Account with the given ID does not exist")
+	}
+
+	return nil
+}
+
+// AcceptTwoFactorStep records the time step of an accepted TOTP code. It fails when the step
+// was recorded meanwhile, which means the same code was used by another request.
+func (s *accountService) AcceptTwoFactorStep(ctx context.Context, account *AccountEntity, step int64) error {
+	rowsAffected, err := s.repository.AcceptTwoFactorStep(ctx, account.ID.String(), step)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return failure.NewAuthenticationFailure("two-factor code has already been used")
+	}
+
+	account.TwoFactorLastStep = step
+	return nil
+}
+
+// ReserveTwoFactorAttempt counts the attempt before the code is checked, so parallel guesses
+// cannot all pass the limit before any failure is recorded.
+func (s *accountService) ReserveTwoFactorAttempt(ctx context.Context, account *AccountEntity) error {
+	now := time.Now()
+	rowsAffected, err := s.repository.ReserveTwoFactorAttempt(
+		ctx,
+		account.ID.String(),
+		s.maxTwoFactorAttempts,
+		now.Unix(),
+		now.Add(s.twoFactorLockout).Unix(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return failure.NewTooManyRequestsFailure("too many failed two-factor attempts, try again later")
+	}
+
+	return nil
+}
+
+// ClearTwoFactorAttempts resets the attempt counter after a second factor was accepted.
+func (s *accountService) ClearTwoFactorAttempts(ctx context.Context, account *AccountEntity) error {
+	return s.repository.ClearTwoFactorAttempts(ctx, account.ID.String())
+}
+
+// ConsumeRecoveryCode removes the recovery code at the given index from the stored codes. It
+// fails when the code is gone already, which means another request redeemed it meanwhile.
+func (s *accountService) ConsumeRecoveryCode(ctx context.Context, account *AccountEntity, index int) error {
+	if index < 0 || index >= len(account.RecoveryCodes) {
+		return failure.NewAuthenticationFailure("invalid recovery code")
+	}
+
+	rowsAffected, err := s.repository.RemoveRecoveryCode(ctx, account.ID.String(), account.RecoveryCodes[index])
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return failure.NewAuthenticationFailure("recovery code has already been used")
+	}
+
+	account.ConsumeRecoveryCode(index)
+	return nil
+}
+
+func (s *accountService) FindTwoFactorAccounts(ctx context.Context) ([]*AccountEntity, error) {
+	return s.repository.FindWithTwoFactorSecret(ctx)
+}
+
+func (s *accountService) ReplaceTwoFactorSecret(ctx context.Context, account *AccountEntity, secret string) (bool, error) {
+	rowsAffected, err := s.repository.ReplaceTwoFactorSecret(ctx, account.ID.String(), account.TwoFactorSecret, secret)
+	if err != nil {
+		return false, err
+	}
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	account.TwoFactorSecret = secret
+	return true, nil
+}
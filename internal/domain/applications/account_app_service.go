@@ -2,34 +2,54 @@ package applications
 
 import (
 	"context"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/core/transaction"
 	"hinsun-backend/internal/core/types"
 	"hinsun-backend/internal/domain/account"
 	"hinsun-backend/internal/domain/auth"
+	"hinsun-backend/internal/domain/media"
+	"hinsun-backend/internal/domain/role"
 	"hinsun-backend/internal/domain/usecases"
 	"hinsun-backend/internal/domain/values"
 )
 
 type AccountAppService interface {
 	usecases.ManageAccountUseCase
+	usecases.AssignAccountRoleUseCase
 }
 
 type accountAppService struct {
 	accountService account.AccountService
 	authService    auth.AuthService
+	roleService    role.RoleService
+	mediaService   media.MediaService
+	unitOfWork     transaction.UnitOfWork
 }
 
 func NewAccountAppService(
 	accountService account.AccountService,
 	authService auth.AuthService,
+	roleService role.RoleService,
+	mediaService media.MediaService,
+	unitOfWork transaction.UnitOfWork,
 ) AccountAppService {
 	return &accountAppService{
 		accountService: accountService,
 		authService:    authService,
+		roleService:    roleService,
+		mediaService:   mediaService,
+		unitOfWork:     unitOfWork,
 	}
 }
 
 func (s *accountAppService) SearchAccounts(ctx context.Context, query *usecases.SearchAccountsQuery) ([]*account.AccountEntity, error) {
-	return s.accountService.SearchAccountsByNameAndEmail(ctx, query.Name, query.Email)
+	accounts, err := s.accountService.SearchAccountsByNameAndEmail(ctx, query.Name, query.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	s.attachAvatarVariants(ctx, accounts...)
+	return accounts, nil
 }
 
 func (s *accountAppService) FindAccountByEmail(ctx context.Context, email string) (*account.AccountEntity, error) {
@@ -42,7 +62,13 @@ func (s *accountAppService) FindAccountByEmail(ctx context.Context, email string
 }
 
 func (s *accountAppService) FindAllAccounts(ctx context.Context) ([]*account.AccountEntity, error) {
-	return s.accountService.FindAllAccounts(ctx)
+	accounts, err := s.accountService.FindAllAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.attachAvatarVariants(ctx, accounts...)
+	return accounts, nil
 }
 
 func (s *accountAppService) CreateNewAccount(ctx context.Context, params *usecases.CreateAccountParams) (*account.AccountEntity, error) {
@@ -51,7 +77,7 @@ func (s *accountAppService) CreateNewAccount(ctx context.Context, params *usecas
 		return nil, err
 	}
 
-	role, err := values.RoleFromInt(params.Role)
+	legacyRole, err := values.RoleFromInt(params.Role)
 	if err != nil {
 		return nil, err
 	}
@@ -61,15 +87,41 @@ func (s *accountAppService) CreateNewAccount(ctx context.Context, params *usecas
 		return nil, err
 	}
 
-	return s.accountService.CreateNewAccount(
-		ctx,
-		params.Name,
-		email,
-		hashedPassword,
-		params.Avatar,
-		params.Bio,
-		role,
-	)
+	// The account and its role assignments are created together or not at all
+	var accountEntity *account.AccountEntity
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		accountEntity, err = s.accountService.CreateNewAccount(
+			ctx,
+			params.Name,
+			email,
+			hashedPassword,
+			params.Avatar,
+			params.Bio,
+			legacyRole,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		// Without explicit roles the account resolves to the built-in role matching its legacy role
+		if len(params.Roles) > 0 {
+			if _, err := s.roleService.AssignRolesToAccount(ctx, accountEntity.ID.String(), params.Roles); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	syncMediaReferences(ctx, s.mediaService, media.ReferenceAccountAvatar, accountEntity.ID.String(), accountEntity.Avatar)
+	s.attachAvatarVariants(ctx, accountEntity)
+	return accountEntity, nil
 }
 
 func (s *accountAppService) DeleteMultipleAccounts(ctx context.Context, query *usecases.DeleteAccountsQuery) (*types.DeletedResult, error) {
@@ -87,16 +139,26 @@ func (s *accountAppService) DeleteMultipleAccounts(ctx context.Context, query *u
 }
 
 func (s *accountAppService) FindAccountByID(ctx context.Context, id string) (*account.AccountEntity, error) {
-	return s.accountService.FindAccountByID(ctx, id)
+	accountEntity, err := s.accountService.FindAccountByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if accountEntity == nil {
+		return nil, failure.NewNotFoundFailure("Account with the given ID does not exist")
+	}
+
+	s.attachAvatarVariants(ctx, accountEntity)
+	return accountEntity, nil
 }
 
-func (s *accountAppService) UpdateAccount(ctx context.Context, id string, params *usecases.UpdateAccountParams) (*account.AccountEntity, error) {
+func (s *accountAppService) UpdateAccount(ctx context.Context, id string, expectedVersion *int64, params *usecases.UpdateAccountParams) (*account.AccountEntity, error) {
 	email, err := values.NewEmail(params.Email)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.accountService.UpdateAccount(
+	accountEntity, err := s.accountService.UpdateAccount(
 		ctx,
 		id,
 		params.Name,
@@ -104,7 +166,16 @@ func (s *accountAppService) UpdateAccount(ctx context.Context, id string, params
 		params.EmailVerified,
 		params.Avatar,
 		params.Bio,
+		expectedVersion,
 	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	syncMediaReferences(ctx, s.mediaService, media.ReferenceAccountAvatar, accountEntity.ID.String(), accountEntity.Avatar)
+	s.attachAvatarVariants(ctx, accountEntity)
+	return accountEntity, nil
 }
 
 func (s *accountAppService) DeleteAccount(ctx context.Context, id string) (*types.DeletedResult, error) {
@@ -120,3 +191,68 @@ func (s *accountAppService) DeleteAccount(ctx context.Context, id string) (*type
 
 	return deletedResult, nil
 }
+
+// ================================== AssignAccountRoleUseCase =================================
+
+func (s *accountAppService) FindAccountRoles(ctx context.Context, accountID string) (*usecases.AccountRolesResponse, error) {
+	accountEntity, err := s.findAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := s.roleService.FindAccountRoles(ctx, accountEntity.ID.String(), accountEntity.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	return &usecases.AccountRolesResponse{
+		Roles:       roles,
+		Permissions: role.CollectPermissions(roles),
+	}, nil
+}
+
+func (s *accountAppService) AssignAccountRoles(ctx context.Context, accountID string, params *usecases.AssignAccountRolesParams) (*usecases.AccountRolesResponse, error) {
+	accountEntity, err := s.findAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Changes reach existing sessions when their tokens are refreshed
+	roles, err := s.roleService.AssignRolesToAccount(ctx, accountEntity.ID.String(), params.Roles)
+	if err != nil {
+		return nil, err
+	}
+
+	return &usecases.AccountRolesResponse{
+		Roles:       roles,
+		Permissions: role.CollectPermissions(roles),
+	}, nil
+}
+
+// attachAvatarVariants resolves the variants of the account avatars, in one query for a list
+func (s *accountAppService) attachAvatarVariants(ctx context.Context, accounts ...*account.AccountEntity) {
+	avatars := make([]string, 0, len(accounts))
+	for _, accountEntity := range accounts {
+		avatars = append(avatars, accountEntity.Avatar)
+	}
+
+	variants := findImageVariants(ctx, s.mediaService, avatars...)
+	for _, accountEntity := range accounts {
+		accountEntity.AvatarVariants = variants[accountEntity.Avatar]
+	}
+}
+
+func (s *accountAppService) findAccount(ctx context.Context, accountID string) (*account.AccountEntity, error) {
+	accountEntity, err := s.accountService.FindAccountByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if accountEntity == nil {
+		return nil, failure.NewNotFoundFailure("Account with the given ID does not exist")
+	}
+
+	return accountEntity, nil
+}
+
+// ================================== AssignAccountRoleUseCase =================================
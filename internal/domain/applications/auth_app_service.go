@@ -2,31 +2,49 @@ package applications
 
 import (
 	"context"
-	"fmt"
 	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/core/transaction"
+	"hinsun-backend/internal/domain/accesstoken"
 	"hinsun-backend/internal/domain/account"
 	"hinsun-backend/internal/domain/auth"
+	"hinsun-backend/internal/domain/role"
 	"hinsun-backend/internal/domain/usecases"
 	"hinsun-backend/internal/domain/values"
-	"strconv"
+	"hinsun-backend/pkg/jwt"
+	"slices"
 )
 
 type AuthAppService interface {
 	usecases.ManageSessionAuthUseCase
+	usecases.TwoFactorAuthUseCase
+	usecases.SigningKeyUseCase
+	usecases.AccessTokenUseCase
+	// SealTwoFactorSecrets encrypts the TOTP secrets stored in plain text before encryption was
+	// introduced and returns how many were sealed
+	SealTwoFactorSecrets(ctx context.Context) (int, error)
 }
 
 type authAppService struct {
-	authService    auth.AuthService
-	accountService account.AccountService
+	authService        auth.AuthService
+	accountService     account.AccountService
+	roleService        role.RoleService
+	accessTokenService accesstoken.AccessTokenService
+	unitOfWork         transaction.UnitOfWork
 }
 
 func NewAuthAppService(
 	authService auth.AuthService,
 	accountService account.AccountService,
+	roleService role.RoleService,
+	accessTokenService accesstoken.AccessTokenService,
+	unitOfWork transaction.UnitOfWork,
 ) AuthAppService {
 	return &authAppService{
-		authService:    authService,
-		accountService: accountService,
+		authService:        authService,
+		accountService:     accountService,
+		roleService:        roleService,
+		accessTokenService: accessTokenService,
+		unitOfWork:         unitOfWork,
 	}
 }
 
@@ -37,15 +55,20 @@ func (a *authAppService) AuthWithEmail(ctx context.Context, params *usecases.Aut
 	}
 
 	// At here, accountEntity must be not nil
-	tokenPair, err := a.authService.GenerateTokenPair(accountEntity.ID.String(), accountEntity.Email.Value(), int(accountEntity.Role))
-	if err != nil {
-		return nil, err
+	// Accounts with 2FA enabled only receive a challenge token, which is exchanged in VerifyTwoFactor
+	if accountEntity.RequiresTwoFactor() {
+		challengeToken, err := a.authService.GenerateChallengeToken(accountEntity.ID.String(), accountEntity.Email.Value())
+		if err != nil {
+			return nil, err
+		}
+
+		return &usecases.AuthResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    challengeToken,
+		}, nil
 	}
 
-	return &usecases.AuthResponse{
-		AccessToken:  tokenPair.AccessToken,
-		RefreshToken: tokenPair.RefreshToken,
-	}, nil
+	return a.issueTokenPair(ctx, accountEntity, false)
 }
 
 func (a *authAppService) EnsureAccountExists(ctx context.Context, params *usecases.AuthEmailParams) (*account.AccountEntity, error) {
@@ -57,64 +80,243 @@ func (a *authAppService) EnsureAccountExists(ctx context.Context, params *usecas
 	// accountEntity is global to handle both cases
 	var accountEntity *account.AccountEntity
 
-	accountEntity, err = a.accountService.FindAccountByEmail(ctx, email)
+	// Find-then-create runs serializable, so concurrent sign-ups with the same email cannot
+	// both create an account; the losing attempt is retried and then sees the winner's account
+	err = a.unitOfWork.DoWithIsolation(ctx, transaction.Serializable, func(ctx context.Context) error {
+		var err error
+		accountEntity, err = a.accountService.FindAccountByEmail(ctx, email)
+		if err != nil {
+			return err
+		}
+
+		// At here, there are two cases we need to handle:
+		// 1. accountEntity is nil -> account not found -> need to create account first
+		// 2. accountEntity is not nil -> account found -> proceed to password verification
+		if accountEntity == nil {
+			// create a new account
+			name := email.LocalPart()
+			hashedPassword, err := a.authService.HashPassword(params.Password)
+			if err != nil {
+				return err
+			}
+
+			accountEntity, err = a.accountService.CreateNewAccount(
+				ctx,
+				name,
+				email,
+				hashedPassword,
+				"https://i.pinimg.com/1200x/1d/b1/e6/1db1e66e7b8da532a1271b796621607d.jpg",
+				"Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.",
+				values.NormalRole,
+			)
+
+			if err != nil {
+				return err
+			}
+		} else {
+			// verify password
+			if err := a.authService.VerifyPassword(params.Password, accountEntity.Password); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return accountEntity, nil
+}
+
+func (a *authAppService) RefreshTokens(ctx context.Context, params *usecases.RefreshTokensParams) (*usecases.AuthResponse, error) {
+	claims, err := a.authService.VerifyRefreshToken(params.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reload the account so role and permission changes apply on the next refresh
+	accountEntity, err := a.accountService.FindAccountByID(ctx, claims.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	// At here, there are two cases we need to handle:
-	// 1. accountEntity is nil -> account not found -> need to create account first
-	// 2. accountEntity is not nil -> account found -> proceed to password verification
 	if accountEntity == nil {
-		// create a new account
-		name := email.LocalPart()
-		hashedPassword, err := a.authService.HashPassword(params.Password)
-		if err != nil {
+		return nil, failure.NewAuthenticationFailure("account no longer exists")
+	}
+
+	return a.issueTokenPair(ctx, accountEntity, claims.TwoFactor)
+}
+
+func (a *authAppService) SignOut(ctx context.Context) error {
+	// Currently, there's no server-side session management.
+	// Sign-out is handled on the client side by discarding tokens.
+	return nil
+}
+
+// ================================== TwoFactorAuthUseCase =================================
+
+func (a *authAppService) VerifyTwoFactor(ctx context.Context, params *usecases.VerifyTwoFactorParams) (*usecases.AuthResponse, error) {
+	// 1. Verify challenge token issued by AuthWithEmail
+	claims, err := a.authService.VerifyChallengeToken(params.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	accountEntity, err := a.findAccount(ctx, claims.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !accountEntity.RequiresTwoFactor() {
+		return nil, failure.NewValidationFailure("two-factor authentication is not enabled for this account")
+	}
+
+	// 2. Verify second factor, either a TOTP code or a one-time recovery code
+	if params.Code != "" {
+		if err := a.verifyTwoFactorCode(ctx, accountEntity, params.Code); err != nil {
 			return nil, err
 		}
+	} else {
+		err := a.countTwoFactorAttempt(ctx, accountEntity, func() error {
+			idx, err := a.authService.MatchRecoveryCode(params.RecoveryCode, accountEntity.RecoveryCodes)
+			if err != nil {
+				return err
+			}
 
-		accountEntity, err = a.accountService.CreateNewAccount(
-			ctx,
-			name,
-			email,
-			hashedPassword,
-			"https://i.pinimg.com/1200x/1d/b1/e6/1db1e66e7b8da532a1271b796621607d.jpg",
-			"Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.",
-			values.NormalRole,
-		)
+			return a.accountService.ConsumeRecoveryCode(ctx, accountEntity, idx)
+		})
 
 		if err != nil {
 			return nil, err
 		}
+	}
 
-	} else {
-		// verify password
-		if err := a.authService.VerifyPassword(params.Password, accountEntity.Password); err != nil {
-			return nil, err
-		}
+	// 3. Issue token pair marked as two-factor verified
+	return a.issueTokenPair(ctx, accountEntity, true)
+}
+
+func (a *authAppService) EnrollTwoFactor(ctx context.Context, accountID string) (*usecases.EnrollTwoFactorResponse, error) {
+	accountEntity, err := a.findAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
 	}
 
-	return accountEntity, nil
+	secret, sealedSecret, err := a.authService.GenerateTwoFactorSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := accountEntity.StartTwoFactorEnrollment(sealedSecret); err != nil {
+		return nil, err
+	}
+
+	if err := a.accountService.SaveTwoFactorSettings(ctx, accountEntity); err != nil {
+		return nil, err
+	}
+
+	return &usecases.EnrollTwoFactorResponse{
+		Secret:     secret,
+		OtpauthURI: a.authService.BuildTwoFactorURI(secret, accountEntity.Email.Value()),
+	}, nil
 }
 
-func (a *authAppService) RefreshTokens(ctx context.Context, params *usecases.RefreshTokensParams) (*usecases.AuthResponse, error) {
-	claims, err := a.authService.VerifyRefreshToken(params.RefreshToken)
+func (a *authAppService) ActivateTwoFactor(ctx context.Context, accountID string, params *usecases.TwoFactorCodeParams) (*usecases.RecoveryCodesResponse, error) {
+	accountEntity, err := a.findAccount(ctx, accountID)
 	if err != nil {
 		return nil, err
 	}
 
-	fmt.Println("Claims: ", claims.Role)
+	if accountEntity.TwoFactorSecret == "" {
+		return nil, failure.NewValidationFailure("two-factor enrollment has not been started")
+	}
+
+	// The first valid code proves the authenticator app was set up correctly
+	if err := a.verifyTwoFactorCode(ctx, accountEntity, params.Code); err != nil {
+		return nil, err
+	}
+
+	codes, hashedCodes, err := a.authService.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := accountEntity.ActivateTwoFactor(hashedCodes); err != nil {
+		return nil, err
+	}
+
+	if err := a.accountService.SaveTwoFactorSettings(ctx, accountEntity); err != nil {
+		return nil, err
+	}
+
+	return &usecases.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (a *authAppService) DisableTwoFactor(ctx context.Context, accountID string, params *usecases.TwoFactorCodeParams) error {
+	accountEntity, err := a.findEnabledTwoFactorAccount(ctx, accountID, params.Code)
+	if err != nil {
+		return err
+	}
+
+	accountEntity.DisableTwoFactor()
+	return a.accountService.SaveTwoFactorSettings(ctx, accountEntity)
+}
+
+func (a *authAppService) RegenerateRecoveryCodes(ctx context.Context, accountID string, params *usecases.TwoFactorCodeParams) (*usecases.RecoveryCodesResponse, error) {
+	accountEntity, err := a.findEnabledTwoFactorAccount(ctx, accountID, params.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, hashedCodes, err := a.authService.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	accountEntity.ReplaceRecoveryCodes(hashedCodes)
+	if err := a.accountService.SaveTwoFactorSettings(ctx, accountEntity); err != nil {
+		return nil, err
+	}
+
+	return &usecases.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (a *authAppService) findAccount(ctx context.Context, accountID string) (*account.AccountEntity, error) {
+	accountEntity, err := a.accountService.FindAccountByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if accountEntity == nil {
+		return nil, failure.NewNotFoundFailure("Account with the given ID does not exist")
+	}
+
+	return accountEntity, nil
+}
 
-	// convert claims.Role to int
-	roleInt, err := strconv.Atoi(claims.Role)
+// issueTokenPair resolves the account's roles and issues a token pair carrying their permissions
+func (a *authAppService) issueTokenPair(ctx context.Context, accountEntity *account.AccountEntity, twoFactor bool) (*usecases.AuthResponse, error) {
+	roles, err := a.roleService.FindAccountRoles(ctx, accountEntity.ID.String(), accountEntity.Role)
 	if err != nil {
-		return nil, failure.NewInternalFailure("failed to convert role to int", err)
+		return nil, err
+	}
+
+	permissions := role.CollectPermissions(roles)
+	subject := &jwt.TokenSubject{
+		AccountID:   accountEntity.ID.String(),
+		Email:       accountEntity.Email.Value(),
+		Roles:       role.RoleNames(roles),
+		Permissions: make([]string, 0, len(permissions)),
+	}
+
+	for _, p := range permissions {
+		subject.Permissions = append(subject.Permissions, string(p))
 	}
 
-	// generate new token pair
-	tokenPair, err := a.authService.GenerateTokenPair(claims.AccountID, claims.Email, roleInt)
+	tokenPair, err := a.authService.GenerateTokenPair(subject, twoFactor)
 	if err != nil {
-		return nil, failure.NewInternalFailure("failed to generate new token pair", err)
+		return nil, err
 	}
 
 	return &usecases.AuthResponse{
@@ -123,8 +325,197 @@ func (a *authAppService) RefreshTokens(ctx context.Context, params *usecases.Ref
 	}, nil
 }
 
-func (a *authAppService) SignOut(ctx context.Context) error {
-	// Currently, there's no server-side session management.
-	// Sign-out is handled on the client side by discarding tokens.
-	return nil
+// findEnabledTwoFactorAccount loads the account and verifies a current TOTP code
+func (a *authAppService) findEnabledTwoFactorAccount(ctx context.Context, accountID, code string) (*account.AccountEntity, error) {
+	accountEntity, err := a.findAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !accountEntity.RequiresTwoFactor() {
+		return nil, failure.NewValidationFailure("two-factor authentication is not enabled for this account")
+	}
+
+	if err := a.verifyTwoFactorCode(ctx, accountEntity, code); err != nil {
+		return nil, err
+	}
+
+	return accountEntity, nil
+}
+
+// verifyTwoFactorCode checks a TOTP code and records its time step, so the code is accepted once
+func (a *authAppService) verifyTwoFactorCode(ctx context.Context, accountEntity *account.AccountEntity, code string) error {
+	return a.countTwoFactorAttempt(ctx, accountEntity, func() error {
+		step, err := a.authService.VerifyTwoFactorCode(accountEntity.TwoFactorSecret, code, accountEntity.TwoFactorLastStep)
+		if err != nil {
+			return err
+		}
+
+		return a.accountService.AcceptTwoFactorStep(ctx, accountEntity, step)
+	})
+}
+
+// countTwoFactorAttempt runs check as one attempt against the account's limit; the attempt is
+// reserved before checking and the counter is reset once a check passes
+func (a *authAppService) countTwoFactorAttempt(ctx context.Context, accountEntity *account.AccountEntity, check func() error) error {
+	if err := a.accountService.ReserveTwoFactorAttempt(ctx, accountEntity); err != nil {
+		return err
+	}
+
+	if err := check(); err != nil {
+		return err
+	}
+
+	return a.accountService.ClearTwoFactorAttempts(ctx, accountEntity)
+}
+
+// ================================== TwoFactorAuthUseCase =================================
+
+// ================================== SigningKeyUseCase =================================
+
+func (a *authAppService) RotateSigningKeys(ctx context.Context) (*usecases.RotateSigningKeysResponse, error) {
+	rotated, err := a.authService.RotateSigningKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	return &usecases.RotateSigningKeysResponse{
+		AccessKeyID:  rotated.AccessKeyID,
+		RefreshKeyID: rotated.RefreshKeyID,
+	}, nil
+}
+
+func (a *authAppService) FindPublicKeySet(ctx context.Context) (*jwt.JWKSet, error) {
+	return a.authService.PublicKeySet(), nil
+}
+
+// ================================== SigningKeyUseCase =================================
+
+// ================================== AccessTokenUseCase =================================
+
+func (a *authAppService) FindAccessTokens(ctx context.Context, actor *values.Actor) ([]*accesstoken.AccessTokenEntity, error) {
+	return a.accessTokenService.FindAccountTokens(ctx, actor.AccountID)
+}
+
+func (a *authAppService) CreateAccessToken(ctx context.Context, actor *values.Actor, params *usecases.CreateAccessTokenParams) (*usecases.CreateAccessTokenResponse, error) {
+	// 1. Access tokens can only be managed from an interactive session
+	if actor.AccessTokenID != "" {
+		return nil, failure.NewForbiddenFailure("access tokens cannot be created with an access token")
+	}
+
+	accountEntity, err := a.findAccount(ctx, actor.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Scopes must be a subset of what the owner currently holds
+	scopes, err := values.ConvertStringArrayToPermissions(params.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	granted, err := a.findAccountPermissions(ctx, accountEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, scope := range scopes {
+		if !slices.Contains(granted, scope) {
+			return nil, failure.NewForbiddenFailure("cannot grant scope " + string(scope) + " that the account does not hold")
+		}
+	}
+
+	// 3. Issue token; the plain token is returned only in this response
+	token, plainToken, err := a.accessTokenService.IssueToken(ctx, accountEntity.ID, params.Name, scopes, actor.TwoFactor, params.ExpiresInDays)
+	if err != nil {
+		return nil, err
+	}
+
+	return &usecases.CreateAccessTokenResponse{
+		Token:       plainToken,
+		AccessToken: token,
+	}, nil
+}
+
+func (a *authAppService) RevokeAccessToken(ctx context.Context, actor *values.Actor, id string) (*accesstoken.AccessTokenEntity, error) {
+	return a.accessTokenService.RevokeToken(ctx, actor.AccountID, id)
+}
+
+// AuthenticateAccessToken resolves a personal access token into claims equivalent to an access token's
+func (a *authAppService) AuthenticateAccessToken(ctx context.Context, plainToken string) (*jwt.Claims, error) {
+	token, err := a.accessTokenService.AuthenticateToken(ctx, plainToken)
+	if err != nil {
+		return nil, err
+	}
+
+	accountEntity, err := a.accountService.FindAccountByID(ctx, token.AccountID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if accountEntity == nil {
+		return nil, failure.NewAuthenticationFailure("account no longer exists")
+	}
+
+	// Scopes are checked against the owner's current roles on every request
+	roles, err := a.roleService.FindAccountRoles(ctx, accountEntity.ID.String(), accountEntity.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &jwt.Claims{
+		AccountID: accountEntity.ID.String(),
+		Email:     accountEntity.Email.Value(),
+		JTI:       token.ID.String(),
+		Roles:     role.RoleNames(roles),
+		TwoFactor: token.TwoFactor,
+		Purpose:   jwt.AccessTokenPurpose,
+	}
+
+	for _, p := range token.EffectivePermissions(role.CollectPermissions(roles)) {
+		claims.Permissions = append(claims.Permissions, string(p))
+	}
+
+	return claims, nil
+}
+
+func (a *authAppService) findAccountPermissions(ctx context.Context, accountEntity *account.AccountEntity) ([]values.Permission, error) {
+	roles, err := a.roleService.FindAccountRoles(ctx, accountEntity.ID.String(), accountEntity.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	return role.CollectPermissions(roles), nil
+}
+
+// ================================== AccessTokenUseCase =================================
+
+func (a *authAppService) SealTwoFactorSecrets(ctx context.Context) (int, error) {
+	accountEntities, err := a.accountService.FindTwoFactorAccounts(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sealed := 0
+	for _, accountEntity := range accountEntities {
+		if a.authService.IsTwoFactorSecretSealed(accountEntity.TwoFactorSecret) {
+			continue
+		}
+
+		sealedSecret, err := a.authService.SealTwoFactorSecret(accountEntity.TwoFactorSecret)
+		if err != nil {
+			return sealed, err
+		}
+
+		replaced, err := a.accountService.ReplaceTwoFactorSecret(ctx, accountEntity, sealedSecret)
+		if err != nil {
+			return sealed, err
+		}
+
+		if replaced {
+			sealed++
+		}
+	}
+
+	return sealed, nil
 }
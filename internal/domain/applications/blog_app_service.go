@@ -3,13 +3,24 @@ package applications
 import (
 	"context"
 	"fmt"
+	"hinsun-backend/internal/core/cache"
+	"hinsun-backend/internal/core/events"
 	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/core/log"
+	"hinsun-backend/internal/core/transaction"
 	"hinsun-backend/internal/core/types"
 	"hinsun-backend/internal/domain/account"
 	"hinsun-backend/internal/domain/blog"
+	"hinsun-backend/internal/domain/category"
 	"hinsun-backend/internal/domain/comment"
+	"hinsun-backend/internal/domain/media"
+	"hinsun-backend/internal/domain/notification"
+	"hinsun-backend/internal/domain/subscription"
 	"hinsun-backend/internal/domain/usecases"
 	"hinsun-backend/internal/domain/values"
+	"strings"
+
+	"go.uber.org/zap"
 )
 
 type BlogAppService interface {
@@ -18,30 +29,65 @@ type BlogAppService interface {
 }
 
 type blogAppService struct {
-	blogService    blog.BlogService
-	commentService comment.CommentService
-	accountService account.AccountService
+	blogService         blog.BlogService
+	commentService      comment.CommentService
+	accountService      account.AccountService
+	categoryService     category.CategoryService
+	subscriptionService subscription.SubscriptionService
+	mediaService        media.MediaService
+	unitOfWork          transaction.UnitOfWork
+	cache               *cache.ReadThrough
+	asyncEventBus       *events.AsyncEventBus
 }
 
 func NewBlogAppService(
 	blogService blog.BlogService,
 	commentService comment.CommentService,
 	accountService account.AccountService,
+	categoryService category.CategoryService,
+	subscriptionService subscription.SubscriptionService,
+	mediaService media.MediaService,
+	unitOfWork transaction.UnitOfWork,
+	cache *cache.ReadThrough,
+	asyncEventBus *events.AsyncEventBus,
 ) BlogAppService {
 	return &blogAppService{
-		blogService:    blogService,
-		commentService: commentService,
-		accountService: accountService,
+		blogService:         blogService,
+		commentService:      commentService,
+		accountService:      accountService,
+		categoryService:     categoryService,
+		subscriptionService: subscriptionService,
+		mediaService:        mediaService,
+		unitOfWork:          unitOfWork,
+		cache:               cache,
+		asyncEventBus:       asyncEventBus,
 	}
 }
 
 // ================================== ManageBlogUseCase =================================
 func (s *blogAppService) FindBlogs(ctx context.Context, query *usecases.FindBlogsQuery) ([]*blog.BlogEntity, error) {
-	return s.blogService.FindBlogs(ctx)
+	return cache.Fetch(ctx, s.cache, blogsCacheNamespace, listCacheKey, func(ctx context.Context) ([]*blog.BlogEntity, error) {
+		blogs, err := s.blogService.FindBlogs(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		s.attachBlogImages(ctx, blogs...)
+		return blogs, nil
+	})
 }
 
-func (s *blogAppService) CreateBlog(ctx context.Context, params *usecases.CreateBlogParams) (*blog.BlogEntity, error) {
-	// 1. Validate and process params
+func (s *blogAppService) CreateBlog(ctx context.Context, actor *values.Actor, params *usecases.CreateBlogParams) (*blog.BlogEntity, error) {
+	// 1. Authorize: writing on behalf of another author needs blog:manage, publishing needs blog:publish
+	if !actor.Owns(params.AuthorID.String()) && !actor.Can(values.BlogManagePermission) {
+		return nil, failure.NewForbiddenFailure("you may only create blogs as yourself")
+	}
+
+	if params.IsPublished && !actor.Can(values.BlogPublishPermission) {
+		return nil, failure.NewForbiddenFailure("missing permission " + string(values.BlogPublishPermission))
+	}
+
+	// 2. Validate and process params
 	author, err := s.accountService.FindAccountByID(ctx, params.AuthorID.String())
 	if err != nil {
 		return nil, err
@@ -56,7 +102,7 @@ func (s *blogAppService) CreateBlog(ctx context.Context, params *usecases.Create
 		return nil, err
 	}
 
-	// 2. Create blog entity
+	// 3. Create blog entity
 	blog, err := s.blogService.CreateBlog(
 		ctx,
 		author.ID,
@@ -73,6 +119,15 @@ func (s *blogAppService) CreateBlog(ctx context.Context, params *usecases.Create
 		return nil, err
 	}
 
+	s.syncBlogMedia(ctx, blog)
+	s.attachBlogImages(ctx, blog)
+	s.cache.Invalidate(ctx, blogsCacheNamespace)
+
+	// 4. Announce it to the followers
+	if blog.IsPublished {
+		s.announcePublished(ctx, blog, author)
+	}
+
 	return blog, nil
 }
 
@@ -82,6 +137,8 @@ func (s *blogAppService) DeleteMultipleBlogs(ctx context.Context, query *usecase
 		return nil, err
 	}
 
+	s.cache.Invalidate(ctx, blogsCacheNamespace)
+
 	return &types.DeletedResult{
 		RowsAffected: rowsAffected,
 		Payload:      query.IDs,
@@ -89,10 +146,54 @@ func (s *blogAppService) DeleteMultipleBlogs(ctx context.Context, query *usecase
 }
 
 func (s *blogAppService) FindBlog(ctx context.Context, id string) (*blog.BlogEntity, error) {
-	return s.blogService.FindBlog(ctx, id)
+	blogEntity, err := cache.Fetch(ctx, s.cache, blogsCacheNamespace, id, func(ctx context.Context) (*blog.BlogEntity, error) {
+		blogEntity, err := s.blogService.FindBlog(ctx, id)
+		if err != nil || blogEntity == nil {
+			return blogEntity, err
+		}
+
+		s.attachBlogImages(ctx, blogEntity)
+		return blogEntity, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if blogEntity == nil {
+		return nil, failure.NewNotFoundFailure("Blog with the given ID does not exist")
+	}
+
+	return blogEntity, nil
 }
 
-func (s *blogAppService) UpdateBlog(ctx context.Context, id string, params *usecases.UpdateBlogParams) (*blog.BlogEntity, error) {
+func (s *blogAppService) FindBlogForUpdate(ctx context.Context, id string) (*blog.BlogEntity, error) {
+	blogEntity, err := s.blogService.FindBlog(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if blogEntity == nil {
+		return nil, failure.NewNotFoundFailure("Blog with the given ID does not exist")
+	}
+
+	return blogEntity, nil
+}
+
+func (s *blogAppService) UpdateBlog(ctx context.Context, actor *values.Actor, id string, expectedVersion *int64, params *usecases.UpdateBlogParams) (*blog.BlogEntity, error) {
+	existingBlog, err := s.findAuthorizedBlog(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingBlog.AuthorID != params.AuthorID && !actor.Can(values.BlogManagePermission) {
+		return nil, failure.NewForbiddenFailure("you may not reassign the author of this blog")
+	}
+
+	if existingBlog.IsPublished != params.IsPublished && !actor.Can(values.BlogPublishPermission) {
+		return nil, failure.NewForbiddenFailure("missing permission " + string(values.BlogPublishPermission))
+	}
+
 	author, err := s.accountService.FindAccountByID(ctx, params.AuthorID.String())
 	if err != nil {
 		return nil, err
@@ -118,84 +219,228 @@ func (s *blogAppService) UpdateBlog(ctx context.Context, id string, params *usec
 		params.Markdowns,
 		params.IsPublished,
 		params.EstimatedReadTimeSeconds,
+		expectedVersion,
 	)
 
 	if err != nil {
 		return nil, err
 	}
 
+	s.syncBlogMedia(ctx, updatedBlog)
+	s.attachBlogImages(ctx, updatedBlog)
+	s.cache.Invalidate(ctx, blogsCacheNamespace)
+
+	if updatedBlog.IsPublished && !existingBlog.IsPublished {
+		s.announcePublished(ctx, updatedBlog, author)
+	}
+
 	return updatedBlog, nil
 }
 
-func (s *blogAppService) DeleteBlog(ctx context.Context, id string) (*types.DeletedResult, error) {
+func (s *blogAppService) DeleteBlog(ctx context.Context, actor *values.Actor, id string) (*types.DeletedResult, error) {
+	if _, err := s.findAuthorizedBlog(ctx, actor, id); err != nil {
+		return nil, err
+	}
+
 	rowsAffected, err := s.blogService.DeleteBlog(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	s.cache.Invalidate(ctx, blogsCacheNamespace)
+
 	return &types.DeletedResult{
 		RowsAffected: rowsAffected,
 		Payload:      id,
 	}, nil
 }
 
-// ================================== ManageBlogUseCase =================================
-
-// ================================== CommentBlogUseCase =================================
-func (a *blogAppService) FindAllCommentsOnBlog(ctx context.Context, blogId string) ([]*comment.CommentEntity, error) {
-	return a.commentService.FindCommentsByBlogID(ctx, blogId)
-}
-
-func (a *blogAppService) AddCommentToBlog(ctx context.Context, blogId string, accountId string, params *usecases.AddCommentToBlogParams) (*comment.CommentEntity, error) {
-	// 1. Validate blog existence
-	blogEntity, err := a.blogService.FindBlog(ctx, blogId)
+// findAuthorizedBlog loads the blog and checks that the actor is its author or holds blog:manage
+func (s *blogAppService) findAuthorizedBlog(ctx context.Context, actor *values.Actor, id string) (*blog.BlogEntity, error) {
+	blogEntity, err := s.blogService.FindBlog(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
 	if blogEntity == nil {
-		return nil, failure.NewNotFoundFailure(fmt.Sprintf("Blog with ID %s not found", blogId))
+		return nil, failure.NewNotFoundFailure(fmt.Sprintf("Blog with ID %s not found", id))
 	}
 
-	// 2. Validate account existence
-	accountEntity, err := a.accountService.FindAccountByID(ctx, accountId)
-	if err != nil {
-		return nil, err
+	if !actor.Owns(blogEntity.AuthorID.String()) && !actor.Can(values.BlogManagePermission) {
+		return nil, failure.NewForbiddenFailure("you may only modify your own blogs")
 	}
 
-	if accountEntity == nil {
-		return nil, failure.NewNotFoundFailure(fmt.Sprintf("Account with ID %s not found", accountId))
+	return blogEntity, nil
+}
+
+// syncBlogMedia records the uploaded images of the blog markdowns, in every language
+func (s *blogAppService) syncBlogMedia(ctx context.Context, blogEntity *blog.BlogEntity) {
+	markdowns := make([]string, 0, len(blogEntity.Markdowns))
+	for _, markdown := range blogEntity.Markdowns {
+		markdowns = append(markdowns, markdown)
 	}
 
-	// 3. Validate parent comment existence (if provided)
-	if params.ParentID != nil {
-		parentComment, err := a.commentService.FindComment(ctx, params.ParentID.String())
+	syncMediaReferences(ctx, s.mediaService, media.ReferenceBlog, blogEntity.ID.String(), markdowns...)
+}
+
+// attachBlogImages resolves the variants of the uploaded images in the blog markdowns, in one
+// query for a list
+func (s *blogAppService) attachBlogImages(ctx context.Context, blogs ...*blog.BlogEntity) {
+	markdowns := make([]string, 0, len(blogs))
+	for _, blogEntity := range blogs {
+		for _, markdown := range blogEntity.Markdowns {
+			markdowns = append(markdowns, markdown)
+		}
+	}
+
+	variants := findImageVariants(ctx, s.mediaService, markdowns...)
+	for _, blogEntity := range blogs {
+		for url, imageVariants := range variants {
+			for _, markdown := range blogEntity.Markdowns {
+				if strings.Contains(markdown, url) {
+					if blogEntity.Images == nil {
+						blogEntity.Images = make(map[string]values.ImageVariants)
+					}
+
+					blogEntity.Images[url] = imageVariants
+					break
+				}
+			}
+		}
+	}
+}
+
+// announcePublished tells the followers of the author and of the blog's categories about a newly
+// published blog. The blog is saved by then, so failing to resolve them is only logged.
+func (s *blogAppService) announcePublished(ctx context.Context, blogEntity *blog.BlogEntity, author *account.AccountEntity) {
+	// 1. Collect the followed targets, categories are referenced by name on blogs
+	targets := []subscription.Target{{Type: subscription.TargetAuthor, ID: author.ID}}
+	topics := make([]string, 0, len(blogEntity.Categories))
+	for _, name := range blogEntity.Categories {
+		categoryEntity, err := s.categoryService.FindCategoryByName(ctx, name)
 		if err != nil {
-			return nil, err
+			log.FromContext(ctx).Warn("⚠️ Failed to resolve blog category for subscribers", zap.String("category", name), zap.Error(err))
+			continue
 		}
 
-		if parentComment == nil {
-			return nil, failure.NewNotFoundFailure(fmt.Sprintf("Parent comment with ID %s not found", *params.ParentID))
+		if categoryEntity == nil {
+			continue
 		}
+
+		targets = append(targets, subscription.Target{Type: subscription.TargetCategory, ID: categoryEntity.ID})
+		topics = append(topics, subscription.CategoryTopic(categoryEntity.ID))
 	}
 
-	// 4. Create new comment
-	newComment, err := a.commentService.CreateComment(
-		ctx,
-		accountEntity.ID,
-		blogEntity.ID,
-		params.ParentID,
-		params.Content,
-	)
+	// 2. Resolve who follows them
+	subscribers, err := s.subscriptionService.FindSubscribers(ctx, targets)
+	if err != nil {
+		log.FromContext(ctx).Warn("⚠️ Failed to resolve blog subscribers", zap.String("blog_id", blogEntity.ID.String()), zap.Error(err))
+		return
+	}
+
+	subscriberEmails := make([]notification.EmailSubscriber, 0, len(subscribers.Emails))
+	for _, emailSubscription := range subscribers.Emails {
+		subscriberEmails = append(subscriberEmails, notification.EmailSubscriber{
+			Email:            emailSubscription.Email,
+			UnsubscribeToken: emailSubscription.Token,
+		})
+	}
+
+	// 3. Hand them to the notification dispatcher
+	s.asyncEventBus.Publish(ctx, notification.NewBlogPublishedEvent(notification.BlogPublishedPayload{
+		BlogID:           blogEntity.ID.String(),
+		BlogTitle:        notification.LocalizedText(blogEntity.Names, values.English),
+		BlogNames:        blogEntity.Names,
+		AuthorID:         author.ID.String(),
+		AuthorName:       author.Name,
+		SubscriberIDs:    subscribers.AccountIDs,
+		SubscriberEmails: subscriberEmails,
+		Topics:           topics,
+	}))
+}
+
+// ================================== ManageBlogUseCase =================================
+
+// ================================== CommentBlogUseCase =================================
+func (a *blogAppService) FindAllCommentsOnBlog(ctx context.Context, blogId string) ([]*comment.CommentEntity, error) {
+	return a.commentService.FindCommentsByBlogID(ctx, blogId)
+}
+
+func (a *blogAppService) AddCommentToBlog(ctx context.Context, blogId string, accountId string, params *usecases.AddCommentToBlogParams) (*comment.CommentEntity, error) {
+	var newComment *comment.CommentEntity
+	var addedPayload comment.CommentAddedPayload
+
+	// The checks and the insert share one transaction, so a failure leaves nothing behind
+	err := a.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		// 1. Validate blog existence
+		blogEntity, err := a.blogService.FindBlog(ctx, blogId)
+		if err != nil {
+			return err
+		}
+
+		if blogEntity == nil {
+			return failure.NewNotFoundFailure(fmt.Sprintf("Blog with ID %s not found", blogId))
+		}
+
+		// 2. Validate account existence
+		accountEntity, err := a.accountService.FindAccountByID(ctx, accountId)
+		if err != nil {
+			return err
+		}
+
+		if accountEntity == nil {
+			return failure.NewNotFoundFailure(fmt.Sprintf("Account with ID %s not found", accountId))
+		}
+
+		// 3. Validate parent comment existence (if provided)
+		addedPayload = comment.CommentAddedPayload{
+			BlogID:     blogEntity.ID.String(),
+			BlogNames:  blogEntity.Names,
+			AuthorID:   accountEntity.ID.String(),
+			AuthorName: accountEntity.Name,
+		}
+
+		if params.ParentID != nil {
+			parentComment, err := a.commentService.FindComment(ctx, params.ParentID.String())
+			if err != nil {
+				return err
+			}
+
+			if parentComment == nil {
+				return failure.NewNotFoundFailure(fmt.Sprintf("Parent comment with ID %s not found", *params.ParentID))
+			}
+
+			addedPayload.ParentID = parentComment.ID.String()
+			addedPayload.ParentAuthorID = parentComment.AuthorID.String()
+		}
+
+		// 4. Create new comment
+		newComment, err = a.commentService.CreateComment(
+			ctx,
+			accountEntity.ID,
+			blogEntity.ID,
+			params.ParentID,
+			params.Content,
+		)
+
+		return err
+	})
 
 	if err != nil {
 		return nil, err
 	}
 
+	// 5. Notify once committed, replies and mentions are dispatched by the notification handlers
+	addedPayload.CommentID = newComment.ID.String()
+	addedPayload.Content = newComment.Content
+	addedPayload.MentionedIDs = comment.ExtractMentions(newComment.Content)
+	addedPayload.CreatedAt = newComment.CreatedAt
+	a.asyncEventBus.Publish(ctx, comment.NewCommentAddedEvent(addedPayload))
+
 	return newComment, nil
 }
 
-func (a *blogAppService) UpdateCommentOnBlog(ctx context.Context, blogId string, accountId string, commentId string, params *usecases.UpdateCommentOnBlogParams) (*comment.CommentEntity, error) {
+func (a *blogAppService) UpdateCommentOnBlog(ctx context.Context, blogId string, accountId string, commentId string, expectedVersion *int64, params *usecases.UpdateCommentOnBlogParams) (*comment.CommentEntity, error) {
 	// 1. Validate blog existence
 	blogEntity, err := a.blogService.FindBlog(ctx, blogId)
 	if err != nil {
@@ -222,6 +467,7 @@ func (a *blogAppService) UpdateCommentOnBlog(ctx context.Context, blogId string,
 		commentId,
 		params.Content,
 		accountEntity.ID,
+		expectedVersion,
 	)
 
 	if err != nil {
@@ -231,7 +477,7 @@ func (a *blogAppService) UpdateCommentOnBlog(ctx context.Context, blogId string,
 	return updatedComment, nil
 }
 
-func (a *blogAppService) DeleteCommentOnBlog(ctx context.Context, blogId string, accountId string, commentId string) (*types.DeletedResult, error) {
+func (a *blogAppService) DeleteCommentOnBlog(ctx context.Context, blogId string, actor *values.Actor, commentId string) (*types.DeletedResult, error) {
 	// 1. Validate blog existence
 	blogEntity, err := a.blogService.FindBlog(ctx, blogId)
 	if err != nil {
@@ -242,17 +488,22 @@ func (a *blogAppService) DeleteCommentOnBlog(ctx context.Context, blogId string,
 		return nil, failure.NewNotFoundFailure(fmt.Sprintf("Blog with ID %s not found", blogId))
 	}
 
-	// 2. Validate account existence
-	accountEntity, err := a.accountService.FindAccountByID(ctx, accountId)
+	// 2. Validate comment existence
+	commentEntity, err := a.commentService.FindComment(ctx, commentId)
 	if err != nil {
 		return nil, err
 	}
 
-	if accountEntity == nil {
-		return nil, failure.NewNotFoundFailure(fmt.Sprintf("Account with ID %s not found", accountId))
+	if commentEntity == nil || commentEntity.BlogID != blogEntity.ID {
+		return nil, failure.NewNotFoundFailure(fmt.Sprintf("Comment with ID %s not found", commentId))
+	}
+
+	// 3. Authors delete their own comments, moderators delete any comment
+	if !actor.Owns(commentEntity.AuthorID.String()) && !actor.Can(values.CommentModeratePermission) {
+		return nil, failure.NewForbiddenFailure("you may only delete your own comments")
 	}
 
-	// 3. Delete comment
+	// 4. Delete comment
 	rowsAffected, err := a.commentService.DeleteComment(ctx, commentId)
 	if err != nil {
 		return nil, err
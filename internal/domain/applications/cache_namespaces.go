@@ -0,0 +1,15 @@
+package applications
+
+import "hinsun-backend/internal/domain/trash"
+
+// Cache namespaces of the public reads. They share the names of the trash resources,
+// so restoring an item drops the cached reads of its resource.
+const (
+	blogsCacheNamespace       = string(trash.BlogResource)
+	projectsCacheNamespace    = string(trash.ProjectResource)
+	experiencesCacheNamespace = string(trash.ExperienceResource)
+
+	// listCacheKey is the key of the unfiltered list within a namespace, and the prefix of the
+	// keys of the filtered or paginated ones
+	listCacheKey = "list"
+)
@@ -43,8 +43,8 @@ func (s *categoryAppService) CreateCategory(ctx context.Context, params *usecase
 	return s.categoryService.CreateCategory(ctx, params.Name)
 }
 
-func (s *categoryAppService) UpdateCategory(ctx context.Context, id string, params *usecases.UpdateCategoryParams) (*category.CategoryEntity, error) {
-	return s.categoryService.UpdateCategory(ctx, id, params.Name)
+func (s *categoryAppService) UpdateCategory(ctx context.Context, id string, expectedVersion *int64, params *usecases.UpdateCategoryParams) (*category.CategoryEntity, error) {
+	return s.categoryService.UpdateCategory(ctx, id, params.Name, expectedVersion)
 }
 
 func (s *categoryAppService) DeleteCategory(ctx context.Context, id string) (*types.DeletedResult, error) {
@@ -74,8 +74,8 @@ func (s *commentAppService) CreateComment(ctx context.Context, params *usecases.
 	return s.commentService.CreateComment(ctx, params.AuthorID, params.BlogID, params.ParentID, params.Content)
 }
 
-func (s *commentAppService) UpdateComment(ctx context.Context, id string, params *usecases.UpdateCommentParams) (*comment.CommentEntity, error) {
-	return s.commentService.UpdateComment(ctx, id, params.Content)
+func (s *commentAppService) UpdateComment(ctx context.Context, id string, expectedVersion *int64, params *usecases.UpdateCommentParams) (*comment.CommentEntity, error) {
+	return s.commentService.UpdateComment(ctx, id, params.Content, expectedVersion)
 }
 
 func (s *commentAppService) DeleteComment(ctx context.Context, id string) (*types.DeletedResult, error) {
@@ -97,7 +97,16 @@ func (s *commentAppService) DeleteMultipleComments(ctx context.Context, query *u
 }
 
 func (s *commentAppService) FindComment(ctx context.Context, id string) (*comment.CommentEntity, error) {
-	return s.commentService.FindComment(ctx, id)
+	commentEntity, err := s.commentService.FindComment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if commentEntity == nil {
+		return nil, failure.NewNotFoundFailure("Comment with the given ID does not exist")
+	}
+
+	return commentEntity, nil
 }
 
 func (s *commentAppService) FindComments(ctx context.Context) ([]*comment.CommentEntity, error) {
@@ -0,0 +1,46 @@
+package applications
+
+import (
+	"context"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/devicetoken"
+	"hinsun-backend/internal/domain/usecases"
+	"hinsun-backend/internal/domain/values"
+
+	"github.com/google/uuid"
+)
+
+// DeviceAppService manages the push tokens of accounts, pushes themselves go through the
+// notification dispatcher
+type DeviceAppService interface {
+	usecases.ManageDeviceTokenUseCase
+}
+
+type deviceAppService struct {
+	deviceTokenService devicetoken.DeviceTokenService
+}
+
+func NewDeviceAppService(deviceTokenService devicetoken.DeviceTokenService) DeviceAppService {
+	return &deviceAppService{
+		deviceTokenService: deviceTokenService,
+	}
+}
+
+// ===== ManageDeviceTokenUseCase =====
+
+func (s *deviceAppService) FindDeviceTokens(ctx context.Context, actor *values.Actor) ([]*devicetoken.DeviceTokenEntity, error) {
+	return s.deviceTokenService.FindAccountTokens(ctx, actor.AccountID)
+}
+
+func (s *deviceAppService) RegisterDeviceToken(ctx context.Context, actor *values.Actor, params *usecases.RegisterDeviceTokenParams) (*devicetoken.DeviceTokenEntity, error) {
+	accountID, err := uuid.Parse(actor.AccountID)
+	if err != nil {
+		return nil, failure.NewAuthenticationFailure("invalid account in token")
+	}
+
+	return s.deviceTokenService.RegisterToken(ctx, accountID, params.Token, devicetoken.Platform(params.Platform))
+}
+
+func (s *deviceAppService) UnregisterDeviceToken(ctx context.Context, actor *values.Actor, id string) error {
+	return s.deviceTokenService.UnregisterToken(ctx, actor.AccountID, id)
+}
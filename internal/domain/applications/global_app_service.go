@@ -2,13 +2,23 @@ package applications
 
 import (
 	"context"
+	"fmt"
+	"hinsun-backend/internal/core/cache"
 	"hinsun-backend/internal/core/events"
 	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/core/log"
+	"hinsun-backend/internal/core/transaction"
 	"hinsun-backend/internal/core/types"
 	"hinsun-backend/internal/domain/account"
 	"hinsun-backend/internal/domain/experience"
+	"hinsun-backend/internal/domain/media"
 	"hinsun-backend/internal/domain/project"
+	"hinsun-backend/internal/domain/tag"
 	"hinsun-backend/internal/domain/usecases"
+	"hinsun-backend/internal/domain/values"
+	"time"
+
+	"go.uber.org/zap"
 )
 
 // Application Service layer orchestrates multiple domain services to fulfill use cases.
@@ -21,29 +31,61 @@ import (
 type GlobalAppService interface {
 	usecases.ManageExperienceUseCase
 	usecases.ManageProjectUseCase
+	// SyncRepositories refreshes the repository metadata of the projects not synced for maxAge
+	// and returns how many were synced. It stops at the first rate limit of the host.
+	SyncRepositories(ctx context.Context, maxAge time.Duration) (int, error)
 }
 
 type globalAppService struct {
 	experienceService experience.ExperienceService
 	projectService    project.ProjectService
+	tagService        tag.TagService
 	accountService    account.AccountService
+	mediaService      media.MediaService
 	asyncEventBus     *events.AsyncEventBus
+	cache             *cache.ReadThrough
+	unitOfWork        transaction.UnitOfWork
 }
 
 // NewGlobalAppService creates a new instance of GlobalAppService
 func NewGlobalAppService(
 	experienceService experience.ExperienceService,
 	projectService project.ProjectService,
+	tagService tag.TagService,
+	mediaService media.MediaService,
 	asyncEventBus *events.AsyncEventBus,
+	cache *cache.ReadThrough,
+	unitOfWork transaction.UnitOfWork,
 ) GlobalAppService {
 	return &globalAppService{
 		experienceService: experienceService,
 		projectService:    projectService,
+		tagService:        tagService,
+		mediaService:      mediaService,
 		asyncEventBus:     asyncEventBus,
+		cache:             cache,
+		unitOfWork:        unitOfWork,
 	}
 }
 
 func (g *globalAppService) FindExperience(ctx context.Context, id string) (*experience.ExperienceEntity, error) {
+	experience, err := cache.Fetch(ctx, g.cache, experiencesCacheNamespace, id, func(ctx context.Context) (*experience.ExperienceEntity, error) {
+		return g.experienceService.FindExperienceByID(ctx, id)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if experience == nil {
+		return nil, failure.NewNotFoundFailure("Experience with the given ID does not exist")
+	}
+
+	describeExperiences(experience)
+	return experience, nil
+}
+
+func (g *globalAppService) FindExperienceForUpdate(ctx context.Context, id string) (*experience.ExperienceEntity, error) {
 	experience, err := g.experienceService.FindExperienceByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -57,34 +99,99 @@ func (g *globalAppService) FindExperience(ctx context.Context, id string) (*expe
 }
 
 func (g *globalAppService) FindExperiences(ctx context.Context) ([]*experience.ExperienceEntity, error) {
-	return g.experienceService.FindAllExperiences(ctx)
+	experiences, err := cache.Fetch(ctx, g.cache, experiencesCacheNamespace, listCacheKey, g.experienceService.FindAllExperiences)
+	if err != nil {
+		return nil, err
+	}
+
+	describeExperiences(experiences...)
+	return experiences, nil
 }
 
 func (g *globalAppService) CreateExperience(ctx context.Context, params *usecases.CreateExperienceParams) (*experience.ExperienceEntity, error) {
-	return g.experienceService.CreateExperience(
+	languages, err := values.ConvertStringArrayToMarkdownLanguageCodes(params.Languages)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate, endDate, err := parseExperienceDates(params.StartDate, params.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	experienceEntity, err := g.experienceService.CreateExperience(
 		ctx,
 		params.OrderIdx,
-		params.Position,
-		params.Company,
+		languages,
+		params.Positions,
+		params.Companies,
 		params.Location,
 		params.Technologies,
 		params.Responsibilities,
-		params.Period,
+		startDate,
+		endDate,
 	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	g.cache.Invalidate(ctx, experiencesCacheNamespace)
+	describeExperiences(experienceEntity)
+	return experienceEntity, nil
 }
 
-func (g *globalAppService) UpdateExperience(ctx context.Context, id string, params *usecases.UpdateExperienceParams) (*experience.ExperienceEntity, error) {
-	return g.experienceService.UpdateExperience(
+func (g *globalAppService) UpdateExperience(ctx context.Context, id string, expectedVersion *int64, params *usecases.UpdateExperienceParams) (*experience.ExperienceEntity, error) {
+	languages, err := values.ConvertStringArrayToMarkdownLanguageCodes(params.Languages)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate, endDate, err := parseExperienceDates(params.StartDate, params.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	experienceEntity, err := g.experienceService.UpdateExperience(
 		ctx,
 		id,
 		params.OrderIdx,
-		params.Position,
-		params.Company,
+		languages,
+		params.Positions,
+		params.Companies,
 		params.Location,
 		params.Technologies,
 		params.Responsibilities,
-		params.Period,
+		startDate,
+		endDate,
+		expectedVersion,
 	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	g.cache.Invalidate(ctx, experiencesCacheNamespace)
+	describeExperiences(experienceEntity)
+	return experienceEntity, nil
+}
+
+func (g *globalAppService) ReorderExperiences(ctx context.Context, params *usecases.ReorderExperiencesParams) ([]*experience.ExperienceEntity, error) {
+	// Serializable, so an experience created meanwhile cannot be left out of the new order
+	var experiences []*experience.ExperienceEntity
+	err := g.unitOfWork.DoWithIsolation(ctx, transaction.Serializable, func(ctx context.Context) error {
+		var err error
+		experiences, err = g.experienceService.ReorderExperiences(ctx, params.IDs)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	g.cache.Invalidate(ctx, experiencesCacheNamespace)
+	describeExperiences(experiences...)
+	return experiences, nil
 }
 
 func (g *globalAppService) DeleteExperience(ctx context.Context, id string) (*types.DeletedResult, error) {
@@ -93,6 +200,8 @@ func (g *globalAppService) DeleteExperience(ctx context.Context, id string) (*ty
 		return nil, err
 	}
 
+	g.cache.Invalidate(ctx, experiencesCacheNamespace)
+
 	deletedResult := &types.DeletedResult{
 		RowsAffected: rowsAffected,
 		Payload:      id,
@@ -107,6 +216,8 @@ func (g *globalAppService) DeleteMultipleExperiences(ctx context.Context, query
 		return nil, err
 	}
 
+	g.cache.Invalidate(ctx, experiencesCacheNamespace)
+
 	deletedResult := &types.DeletedResult{
 		RowsAffected: rowsAffected,
 		Payload:      query.IDs,
@@ -116,6 +227,28 @@ func (g *globalAppService) DeleteMultipleExperiences(ctx context.Context, query
 }
 
 func (g *globalAppService) FindProject(ctx context.Context, id string) (*project.ProjectEntity, error) {
+	project, err := cache.Fetch(ctx, g.cache, projectsCacheNamespace, id, func(ctx context.Context) (*project.ProjectEntity, error) {
+		projectEntity, err := g.projectService.FindProjectByID(ctx, id)
+		if err != nil || projectEntity == nil {
+			return projectEntity, err
+		}
+
+		g.attachProjectVariants(ctx, projectEntity)
+		return projectEntity, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if project == nil {
+		return nil, failure.NewNotFoundFailure("Project with the given ID does not exist")
+	}
+
+	return project, nil
+}
+
+func (g *globalAppService) FindProjectForUpdate(ctx context.Context, id string) (*project.ProjectEntity, error) {
 	project, err := g.projectService.FindProjectByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -128,33 +261,116 @@ func (g *globalAppService) FindProject(ctx context.Context, id string) (*project
 	return project, nil
 }
 
-func (g *globalAppService) FindProjects(ctx context.Context) ([]*project.ProjectEntity, error) {
-	return g.projectService.FindAllProjects(ctx)
+func (g *globalAppService) FindProjects(ctx context.Context, query *usecases.FindProjectsQuery) ([]*project.ProjectEntity, *types.PageMeta, error) {
+	page, pageSize := types.NormalizePage(query.Page, query.PageSize)
+	filter := project.ProjectFilter{
+		Tag:          tag.NormalizeName(query.Tag),
+		FeaturedOnly: query.Featured,
+		Offset:       (page - 1) * pageSize,
+		Limit:        pageSize,
+	}
+
+	key := fmt.Sprintf("%s:tag=%s:featured=%t:page=%d:size=%d", listCacheKey, filter.Tag, filter.FeaturedOnly, page, pageSize)
+	projects, err := cache.Fetch(ctx, g.cache, projectsCacheNamespace, key, func(ctx context.Context) (*types.Page[*project.ProjectEntity], error) {
+		projects, total, err := g.projectService.FindProjects(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		g.attachProjectVariants(ctx, projects...)
+		return &types.Page[*project.ProjectEntity]{Items: projects, Total: total}, nil
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return projects.Items, types.NewPageMeta(page, pageSize, projects.Total), nil
 }
 
 func (g *globalAppService) CreateProject(ctx context.Context, params *usecases.CreateProjectParams) (*project.ProjectEntity, error) {
-	return g.projectService.CreateProject(
+	languages, err := values.ConvertStringArrayToMarkdownLanguageCodes(params.Languages)
+	if err != nil {
+		return nil, err
+	}
+
+	// Projects reference the tags of the vocabulary by their normalized name
+	tags, err := g.tagService.ResolveTags(ctx, params.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	projectEntity, err := g.projectService.CreateProject(
 		ctx,
-		params.Name,
-		params.Description,
+		languages,
+		params.Names,
+		params.Descriptions,
 		params.Github,
 		params.Cover,
-		params.Tags,
-		params.Markdown,
+		tags,
+		params.Markdowns,
 	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	g.syncProjectMedia(ctx, projectEntity)
+	g.attachProjectVariants(ctx, projectEntity)
+	g.cache.Invalidate(ctx, projectsCacheNamespace)
+	return projectEntity, nil
 }
 
-func (g *globalAppService) UpdateProject(ctx context.Context, id string, params *usecases.UpdateProjectParams) (*project.ProjectEntity, error) {
-	return g.projectService.UpdateProject(
+func (g *globalAppService) UpdateProject(ctx context.Context, id string, expectedVersion *int64, params *usecases.UpdateProjectParams) (*project.ProjectEntity, error) {
+	languages, err := values.ConvertStringArrayToMarkdownLanguageCodes(params.Languages)
+	if err != nil {
+		return nil, err
+	}
+
+	// Projects reference the tags of the vocabulary by their normalized name
+	tags, err := g.tagService.ResolveTags(ctx, params.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	projectEntity, err := g.projectService.UpdateProject(
 		ctx,
 		id,
-		params.Name,
-		params.Description,
+		languages,
+		params.Names,
+		params.Descriptions,
 		params.Github,
 		params.Cover,
-		params.Tags,
-		params.Markdown,
+		tags,
+		params.Markdowns,
+		expectedVersion,
 	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	g.syncProjectMedia(ctx, projectEntity)
+	g.attachProjectVariants(ctx, projectEntity)
+	g.cache.Invalidate(ctx, projectsCacheNamespace)
+	return projectEntity, nil
+}
+
+func (g *globalAppService) FeatureProjects(ctx context.Context, params *usecases.FeatureProjectsParams) ([]*project.ProjectEntity, error) {
+	var projects []*project.ProjectEntity
+	err := g.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		projects, err = g.projectService.FeatureProjects(ctx, params.IDs)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	g.attachProjectVariants(ctx, projects...)
+	g.cache.Invalidate(ctx, projectsCacheNamespace)
+	return projects, nil
 }
 
 func (g *globalAppService) DeleteProject(ctx context.Context, id string) (*types.DeletedResult, error) {
@@ -163,6 +379,8 @@ func (g *globalAppService) DeleteProject(ctx context.Context, id string) (*types
 		return nil, err
 	}
 
+	g.cache.Invalidate(ctx, projectsCacheNamespace)
+
 	deletedResult := &types.DeletedResult{
 		RowsAffected: rowsAffected,
 		Payload:      id,
@@ -177,6 +395,8 @@ func (g *globalAppService) DeleteMultipleProjects(ctx context.Context, query *us
 		return nil, err
 	}
 
+	g.cache.Invalidate(ctx, projectsCacheNamespace)
+
 	deletedResult := &types.DeletedResult{
 		RowsAffected: rowsAffected,
 		Payload:      query.IDs,
@@ -184,3 +404,122 @@ func (g *globalAppService) DeleteMultipleProjects(ctx context.Context, query *us
 
 	return deletedResult, nil
 }
+
+func (g *globalAppService) RefreshProjectRepository(ctx context.Context, id string, query *usecases.RefreshProjectRepositoryQuery) (*project.ProjectEntity, error) {
+	var readmeLanguage *values.MarkdownLanguageCode
+	if query.Readme != "" {
+		language, err := values.FromStringToMarkdownLanguageCode(query.Readme)
+		if err != nil {
+			return nil, err
+		}
+
+		readmeLanguage = &language
+	}
+
+	projectEntity, err := g.projectService.SyncRepository(ctx, id, readmeLanguage)
+	if err != nil {
+		return nil, err
+	}
+
+	if readmeLanguage != nil {
+		g.syncProjectMedia(ctx, projectEntity)
+	}
+
+	g.attachProjectVariants(ctx, projectEntity)
+	g.cache.Invalidate(ctx, projectsCacheNamespace)
+	return projectEntity, nil
+}
+
+func (g *globalAppService) SyncRepositories(ctx context.Context, maxAge time.Duration) (int, error) {
+	projects, err := g.projectService.FindAllProjects(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	synced := 0
+	staleBefore := time.Now().Add(-maxAge).Unix()
+	for _, projectEntity := range projects {
+		metadata := projectEntity.Repository
+		if projectEntity.Github == "" || (metadata != nil && metadata.URL == projectEntity.Github && metadata.SyncedAt > staleBefore) {
+			continue
+		}
+
+		_, err = g.projectService.SyncRepository(ctx, projectEntity.ID.String(), nil)
+		if failure.Is(err, failure.TooManyRequestsFailure) {
+			break
+		}
+
+		// A broken link must not keep the other projects from syncing
+		if err != nil {
+			log.FromContext(ctx).Warn("⚠️ Failed to sync project repository",
+				zap.String("projectId", projectEntity.ID.String()),
+				zap.String("github", projectEntity.Github),
+				zap.Error(err),
+			)
+
+			err = nil
+			continue
+		}
+
+		synced++
+	}
+
+	if synced > 0 {
+		g.cache.Invalidate(ctx, projectsCacheNamespace)
+	}
+
+	return synced, err
+}
+
+// syncProjectMedia records the uploaded cover and markdown images of the project, in every language
+func (g *globalAppService) syncProjectMedia(ctx context.Context, projectEntity *project.ProjectEntity) {
+	id := projectEntity.ID.String()
+	syncMediaReferences(ctx, g.mediaService, media.ReferenceProjectCover, id, projectEntity.Cover)
+	markdowns := make([]string, 0, len(projectEntity.Markdowns))
+	for _, markdown := range projectEntity.Markdowns {
+		markdowns = append(markdowns, markdown)
+	}
+
+	syncMediaReferences(ctx, g.mediaService, media.ReferenceProject, id, markdowns...)
+}
+
+// attachProjectVariants resolves the variants of the project covers, in one query for a list
+func (g *globalAppService) attachProjectVariants(ctx context.Context, projects ...*project.ProjectEntity) {
+	covers := make([]string, 0, len(projects))
+	for _, projectEntity := range projects {
+		covers = append(covers, projectEntity.Cover)
+	}
+
+	variants := findImageVariants(ctx, g.mediaService, covers...)
+	for _, projectEntity := range projects {
+		projectEntity.CoverVariants = variants[projectEntity.Cover]
+	}
+}
+
+// describeExperiences computes the durations and display periods as of today, after the cache
+// since every read decodes its own copy
+func describeExperiences(experiences ...*experience.ExperienceEntity) {
+	today := values.Today()
+	for _, experienceEntity := range experiences {
+		experienceEntity.DescribePeriod(today)
+	}
+}
+
+// parseExperienceDates parses the dates of the params, a nil end date is kept for a current experience
+func parseExperienceDates(start string, end *string) (values.Date, *values.Date, error) {
+	startDate, err := values.ParseDate(start)
+	if err != nil {
+		return values.Date{}, nil, err
+	}
+
+	if end == nil {
+		return startDate, nil, nil
+	}
+
+	endDate, err := values.ParseDate(*end)
+	if err != nil {
+		return values.Date{}, nil, err
+	}
+
+	return startDate, &endDate, nil
+}
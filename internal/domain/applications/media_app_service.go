@@ -0,0 +1,144 @@
+package applications
+
+import (
+	"context"
+	"hinsun-backend/internal/core/cache"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/core/log"
+	"hinsun-backend/internal/core/worker"
+	"hinsun-backend/internal/domain/media"
+	"hinsun-backend/internal/domain/usecases"
+	"hinsun-backend/internal/domain/values"
+	"io"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// resumeBatchSize bounds the pending media queued at startup, the queue would reject more anyway
+const resumeBatchSize = 100
+
+type MediaAppService interface {
+	usecases.ManageMediaUseCase
+	// ResumeProcessing queues the images whose variants are still pending, e.g. after a restart
+	// dropped the queue, and returns how many were queued
+	ResumeProcessing(ctx context.Context) (int, error)
+}
+
+type mediaAppService struct {
+	mediaService media.MediaService
+	workerPool   *worker.Pool
+	cache        *cache.ReadThrough
+}
+
+func NewMediaAppService(mediaService media.MediaService, workerPool *worker.Pool, cache *cache.ReadThrough) MediaAppService {
+	return &mediaAppService{
+		mediaService: mediaService,
+		workerPool:   workerPool,
+		cache:        cache,
+	}
+}
+
+// ===== ManageMediaUseCase =====
+
+func (s *mediaAppService) FindMedia(ctx context.Context, actor *values.Actor) ([]*media.MediaEntity, error) {
+	return s.mediaService.FindOwnerMedia(ctx, actor.AccountID)
+}
+
+func (s *mediaAppService) UploadMedia(ctx context.Context, actor *values.Actor, content []byte) (*media.MediaEntity, bool, error) {
+	ownerID, err := uuid.Parse(actor.AccountID)
+	if err != nil {
+		return nil, false, failure.NewAuthenticationFailure("invalid account in token")
+	}
+
+	mediaEntity, created, err := s.mediaService.Upload(ctx, ownerID, content)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !mediaEntity.IsProcessed() {
+		s.scheduleProcessing(ctx, mediaEntity)
+	}
+
+	return mediaEntity, created, nil
+}
+
+func (s *mediaAppService) DeleteMedia(ctx context.Context, actor *values.Actor, id string) error {
+	mediaEntity, err := s.mediaService.FindMedia(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Files of other accounts are reported as missing to avoid leaking their existence
+	if mediaEntity == nil || !actor.Owns(mediaEntity.OwnerID.String()) {
+		return failure.NewNotFoundFailure("Media with the given ID does not exist")
+	}
+
+	return s.mediaService.DeleteMedia(ctx, mediaEntity)
+}
+
+func (s *mediaAppService) OpenMediaFile(ctx context.Context, key string) (io.ReadCloser, error) {
+	return s.mediaService.OpenFile(ctx, key)
+}
+
+func (s *mediaAppService) ResumeProcessing(ctx context.Context) (int, error) {
+	medias, err := s.mediaService.FindPendingVariants(ctx, resumeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	queued := 0
+	for _, mediaEntity := range medias {
+		if s.scheduleProcessing(ctx, mediaEntity) {
+			queued++
+		}
+	}
+
+	return queued, nil
+}
+
+// scheduleProcessing generates the variants in the background. When the queue is full the image
+// stays pending and is picked up by the next ResumeProcessing.
+func (s *mediaAppService) scheduleProcessing(ctx context.Context, mediaEntity *media.MediaEntity) bool {
+	err := s.workerPool.Submit(ctx, "media variants", func(ctx context.Context) error {
+		if err := s.mediaService.ProcessVariants(ctx, mediaEntity); err != nil {
+			return err
+		}
+
+		// Reads cached while the image was pending lack its variants
+		s.cache.Invalidate(ctx, projectsCacheNamespace, blogsCacheNamespace)
+		return nil
+	})
+
+	if err != nil {
+		log.FromContext(ctx).Warn("⚠️ Image variants not queued",
+			zap.String("media_id", mediaEntity.ID.String()),
+			zap.Error(err))
+		return false
+	}
+
+	return true
+}
+
+// findImageVariants resolves the variants of the uploaded images the texts point at, keyed by
+// image URL. Variants only enhance a payload, so a failure is logged and nothing is returned.
+func findImageVariants(ctx context.Context, mediaService media.MediaService, texts ...string) map[string]values.ImageVariants {
+	variants, err := mediaService.FindVariants(ctx, texts...)
+	if err != nil {
+		log.FromContext(ctx).Error("❌ Failed to find image variants", zap.Error(err))
+		return nil
+	}
+
+	return variants
+}
+
+// syncMediaReferences records the uploaded files a saved resource points at. The resource is
+// already saved, so a failure is logged rather than returned.
+func syncMediaReferences(ctx context.Context, mediaService media.MediaService, referenceType media.ReferenceType, referenceID string, texts ...string) {
+	if err := mediaService.SyncReferences(ctx, referenceType, referenceID, texts...); err != nil {
+		log.FromContext(ctx).Error("❌ Failed to record media references",
+			zap.String("reference_type", string(referenceType)),
+			zap.String("reference_id", referenceID),
+			zap.Error(err))
+	}
+}
@@ -2,12 +2,28 @@ package applications
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"hinsun-backend/internal/core/events"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/core/log"
+	"hinsun-backend/internal/domain/account"
+	"hinsun-backend/internal/domain/comment"
 	"hinsun-backend/internal/domain/notification"
 	"hinsun-backend/internal/domain/usecases"
+	"hinsun-backend/internal/domain/values"
+	"net/url"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
+// maxExcerptLength is the number of characters of a comment quoted in notifications
+const maxExcerptLength = 140
+
 type NotificationAppService interface {
 	usecases.ManageNotificationUseCase
 
@@ -18,36 +34,351 @@ type NotificationAppService interface {
 
 type notificationAppService struct {
 	notificationService notification.NotificationService
+	accountService      account.AccountService
+	channels            map[notification.Channel]notification.NotificationChannel
+	topicChannel        notification.TopicChannel
+	unsubscribeURL      string // page email subscribers unsubscribe from, the token is appended
 	interestedEvents    map[string]bool
 }
 
-func NewNotificationAppService(notificationService notification.NotificationService) NotificationAppService {
+func NewNotificationAppService(
+	notificationService notification.NotificationService,
+	accountService account.AccountService,
+	channels []notification.NotificationChannel,
+	topicChannel notification.TopicChannel,
+	unsubscribeURL string,
+) NotificationAppService {
 	service := &notificationAppService{
 		notificationService: notificationService,
+		accountService:      accountService,
+		channels:            make(map[notification.Channel]notification.NotificationChannel, len(channels)),
+		topicChannel:        topicChannel,
+		unsubscribeURL:      unsubscribeURL,
 		interestedEvents: map[string]bool{
-			notification.EventBlogPublished:       true,
-			notification.EventNotificationCreated: true,
+			notification.EventBlogPublished: true,
+			comment.EventCommentAdded:       true,
 		},
 	}
 
+	for _, channel := range channels {
+		service.channels[channel.Channel()] = channel
+	}
+
 	return service
 }
 
+// notice is an event turned into something to tell a set of accounts
+type notice struct {
+	eventType    notification.EventType
+	recipientIDs []string
+	actorName    string
+	blogNames    values.MultiLangText
+	blogTitle    string // used when blogNames has no usable text
+	excerpt      string
+	data         map[string]string
+}
+
+// ===== EventHandler =====
+
 func (s *notificationAppService) InterestedIn(eventName string) bool {
 	return s.interestedEvents[eventName]
 }
 
 func (s *notificationAppService) HandleEvent(ctx context.Context, event events.Event) error {
-	switch event.EventName() {
-	case notification.EventBlogPublished:
-		return nil
-	case notification.EventNotificationCreated:
-		return nil
+	switch e := event.(type) {
+	case *notification.BlogPublishedEvent:
+		payload, ok := e.Payload.(notification.BlogPublishedPayload)
+		if !ok {
+			return fmt.Errorf("unexpected payload for event: %s", event.EventName())
+		}
+
+		n := &notice{
+			eventType:    notification.EventTypeNewBlog,
+			recipientIDs: excludeIDs(payload.SubscriberIDs, payload.AuthorID),
+			actorName:    payload.AuthorName,
+			blogNames:    payload.BlogNames,
+			blogTitle:    payload.BlogTitle,
+			data:         map[string]string{"blogId": payload.BlogID},
+		}
+
+		return errors.Join(
+			s.dispatch(ctx, n),
+			s.dispatchEmailSubscribers(ctx, n, payload.SubscriberEmails),
+			s.broadcast(ctx, n, payload.Topics),
+		)
+	case *comment.CommentAddedEvent:
+		payload, ok := e.Payload.(comment.CommentAddedPayload)
+		if !ok {
+			return fmt.Errorf("unexpected payload for event: %s", event.EventName())
+		}
+
+		return s.dispatchComment(ctx, payload)
 	default:
 		return fmt.Errorf("unhandled event: %s", event.EventName())
 	}
 }
 
-func (s *notificationAppService) FindNotificationsByAccountID(accountID string) ([]*notification.NotificationEntity, error) {
-	return nil, nil
+// dispatchComment tells the parent author about the reply and the mentioned accounts about the
+// mention, an account that is both only hears about the reply
+func (s *notificationAppService) dispatchComment(ctx context.Context, payload comment.CommentAddedPayload) error {
+	base := notice{
+		actorName: payload.AuthorName,
+		blogNames: payload.BlogNames,
+		excerpt:   excerpt(comment.ReplaceMentions(payload.Content)),
+		data: map[string]string{
+			"blogId":    payload.BlogID,
+			"commentId": payload.CommentID,
+		},
+	}
+
+	var errs []error
+	if payload.ParentAuthorID != "" && payload.ParentAuthorID != payload.AuthorID {
+		reply := base
+		reply.eventType = notification.EventTypeCommentReply
+		reply.recipientIDs = []string{payload.ParentAuthorID}
+		errs = append(errs, s.dispatch(ctx, &reply))
+	}
+
+	if mentioned := excludeIDs(payload.MentionedIDs, payload.AuthorID, payload.ParentAuthorID); len(mentioned) > 0 {
+		mention := base
+		mention.eventType = notification.EventTypeMention
+		mention.recipientIDs = mentioned
+		errs = append(errs, s.dispatch(ctx, &mention))
+	}
+
+	return errors.Join(errs...)
+}
+
+// dispatch delivers the notice to every recipient on the channels they chose, rendered in their
+// language. A failing channel does not stop the others; failures are returned together.
+func (s *notificationAppService) dispatch(ctx context.Context, n *notice) error {
+	var errs []error
+	now := time.Now()
+
+	for _, recipientID := range n.recipientIDs {
+		// 1. Resolve the recipient, accounts deleted or deactivated since the event are skipped
+		recipient, err := s.accountService.FindAccountByID(ctx, recipientID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if recipient == nil || !recipient.IsActive {
+			continue
+		}
+
+		preference, err := s.notificationService.FindPreference(ctx, recipient.ID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		// 2. Render in the recipient's language
+		blogTitle := notification.LocalizedText(n.blogNames, preference.Language)
+		if blogTitle == "" {
+			blogTitle = n.blogTitle
+		}
+
+		title, body, err := notification.Render(n.eventType, preference.Language, notification.TemplateData{
+			ActorName: n.actorName,
+			BlogTitle: blogTitle,
+			Excerpt:   n.excerpt,
+		})
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		message := &notification.Message{
+			Recipient: notification.Recipient{
+				AccountID: recipient.ID.String(),
+				Name:      recipient.Name,
+				Email:     recipient.Email.Value(),
+			},
+			Preference: preference,
+			Type:       n.eventType,
+			Title:      title,
+			Body:       body,
+			Data:       n.data,
+		}
+
+		// 3. Deliver on each chosen channel, interrupting ones wait out quiet hours
+		quiet := preference.IsQuietAt(now)
+		for _, channelName := range notification.AllChannels {
+			channel, ok := s.channels[channelName]
+			if !ok || !preference.Allows(n.eventType, channelName) || (quiet && channelName.Interrupts()) {
+				continue
+			}
+
+			if err := channel.Deliver(ctx, message); err != nil {
+				log.FromContext(ctx).Warn("⚠️ Failed to deliver notification",
+					zap.String("channel", string(channelName)),
+					zap.String("type", string(n.eventType)),
+					zap.String("account_id", message.Recipient.AccountID),
+					zap.Error(err))
+
+				errs = append(errs, fmt.Errorf("%s: %w", channelName, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// dispatchEmailSubscribers mails the notice in the default language to subscribers without an
+// account, each with the link that unsubscribes them
+func (s *notificationAppService) dispatchEmailSubscribers(ctx context.Context, n *notice, subscribers []notification.EmailSubscriber) error {
+	channel, ok := s.channels[notification.ChannelEmail]
+	if !ok || len(subscribers) == 0 {
+		return nil
+	}
+
+	title, body, err := s.renderDefault(n)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, subscriber := range subscribers {
+		unsubscribeLink := s.unsubscribeURL + "?token=" + url.QueryEscape(subscriber.UnsubscribeToken)
+		message := &notification.Message{
+			Recipient: notification.Recipient{Email: subscriber.Email},
+			Type:      n.eventType,
+			Title:     title,
+			Body:      body + "\n\nTo stop receiving these emails, visit " + unsubscribeLink,
+			Data:      n.data,
+		}
+
+		if err := channel.Deliver(ctx, message); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", notification.ChannelEmail, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// broadcast pushes the notice in the default language to the devices subscribed to the topics
+func (s *notificationAppService) broadcast(ctx context.Context, n *notice, topics []string) error {
+	if s.topicChannel == nil || len(topics) == 0 {
+		return nil
+	}
+
+	title, body, err := s.renderDefault(n)
+	if err != nil {
+		return err
+	}
+
+	message := &notification.Message{
+		Type:  n.eventType,
+		Title: title,
+		Body:  body,
+		Data:  n.data,
+	}
+
+	var errs []error
+	for _, topic := range topics {
+		if err := s.topicChannel.Broadcast(ctx, topic, message); err != nil {
+			errs = append(errs, fmt.Errorf("topic %s: %w", topic, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// renderDefault renders the notice for audiences without a language preference
+func (s *notificationAppService) renderDefault(n *notice) (string, string, error) {
+	blogTitle := notification.LocalizedText(n.blogNames, values.English)
+	if blogTitle == "" {
+		blogTitle = n.blogTitle
+	}
+
+	return notification.Render(n.eventType, values.English, notification.TemplateData{
+		ActorName: n.actorName,
+		BlogTitle: blogTitle,
+		Excerpt:   n.excerpt,
+	})
+}
+
+// ===== ManageNotificationUseCase =====
+
+func (s *notificationAppService) FindNotifications(ctx context.Context, actor *values.Actor) ([]*notification.NotificationEntity, error) {
+	return s.notificationService.FindAccountNotifications(ctx, actor.AccountID)
+}
+
+func (s *notificationAppService) MarkNotificationRead(ctx context.Context, actor *values.Actor, id string) (*notification.NotificationEntity, error) {
+	return s.notificationService.MarkNotificationRead(ctx, actor.AccountID, id)
+}
+
+func (s *notificationAppService) FindNotificationPreference(ctx context.Context, actor *values.Actor) (*notification.PreferenceEntity, error) {
+	accountID, err := uuid.Parse(actor.AccountID)
+	if err != nil {
+		return nil, failure.NewAuthenticationFailure("invalid account in token")
+	}
+
+	return s.notificationService.FindPreference(ctx, accountID)
+}
+
+func (s *notificationAppService) UpdateNotificationPreference(
+	ctx context.Context,
+	actor *values.Actor,
+	params *usecases.UpdateNotificationPreferenceParams,
+) (*notification.PreferenceEntity, error) {
+	accountID, err := uuid.Parse(actor.AccountID)
+	if err != nil {
+		return nil, failure.NewAuthenticationFailure("invalid account in token")
+	}
+
+	// 1. Validate and convert params
+	language, err := values.FromStringToMarkdownLanguageCode(params.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	channels := make(map[notification.EventType][]notification.Channel, len(params.Channels))
+	for eventTypeName, channelNames := range params.Channels {
+		eventType, err := notification.EventTypeFromString(eventTypeName)
+		if err != nil {
+			return nil, err
+		}
+
+		eventChannels := make([]notification.Channel, 0, len(channelNames))
+		for _, channelName := range channelNames {
+			channel, err := notification.ChannelFromString(channelName)
+			if err != nil {
+				return nil, err
+			}
+
+			eventChannels = append(eventChannels, channel)
+		}
+
+		channels[eventType] = eventChannels
+	}
+
+	// 2. Save preferences
+	return s.notificationService.UpdatePreference(ctx, accountID, language, channels, params.QuietHours, params.WebhookURL)
+}
+
+// excludeIDs returns the IDs without the excluded ones and without duplicates
+func excludeIDs(ids []string, excluded ...string) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || slices.Contains(excluded, id) || slices.Contains(result, id) {
+			continue
+		}
+
+		result = append(result, id)
+	}
+
+	return result
+}
+
+// excerpt shortens text to maxExcerptLength characters
+func excerpt(text string) string {
+	if utf8.RuneCountInString(text) <= maxExcerptLength {
+		return text
+	}
+
+	runes := []rune(text)
+	return string(runes[:maxExcerptLength-1]) + "…"
 }
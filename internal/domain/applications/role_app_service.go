@@ -0,0 +1,75 @@
+package applications
+
+import (
+	"context"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/core/types"
+	"hinsun-backend/internal/domain/role"
+	"hinsun-backend/internal/domain/usecases"
+	"hinsun-backend/internal/domain/values"
+)
+
+type RoleAppService interface {
+	usecases.ManageRoleUseCase
+}
+
+type roleAppService struct {
+	roleService role.RoleService
+}
+
+func NewRoleAppService(roleService role.RoleService) RoleAppService {
+	return &roleAppService{
+		roleService: roleService,
+	}
+}
+
+func (s *roleAppService) FindRoles(ctx context.Context) ([]*role.RoleEntity, error) {
+	return s.roleService.FindAllRoles(ctx)
+}
+
+func (s *roleAppService) FindPermissions(ctx context.Context) ([]values.Permission, error) {
+	return values.AllPermissions(), nil
+}
+
+func (s *roleAppService) FindRole(ctx context.Context, id string) (*role.RoleEntity, error) {
+	roleEntity, err := s.roleService.FindRoleByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if roleEntity == nil {
+		return nil, failure.NewNotFoundFailure("Role with the given ID does not exist")
+	}
+
+	return roleEntity, nil
+}
+
+func (s *roleAppService) CreateRole(ctx context.Context, params *usecases.CreateRoleParams) (*role.RoleEntity, error) {
+	permissions, err := values.ConvertStringArrayToPermissions(params.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.roleService.CreateRole(ctx, params.Name, params.Description, permissions)
+}
+
+func (s *roleAppService) UpdateRole(ctx context.Context, id string, params *usecases.UpdateRoleParams) (*role.RoleEntity, error) {
+	permissions, err := values.ConvertStringArrayToPermissions(params.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.roleService.UpdateRole(ctx, id, params.Description, permissions)
+}
+
+func (s *roleAppService) DeleteRole(ctx context.Context, id string) (*types.DeletedResult, error) {
+	rowsAffected, err := s.roleService.DeleteRole(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.DeletedResult{
+		RowsAffected: rowsAffected,
+		Payload:      id,
+	}, nil
+}
@@ -0,0 +1,145 @@
+package applications
+
+import (
+	"context"
+	"fmt"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/account"
+	"hinsun-backend/internal/domain/category"
+	"hinsun-backend/internal/domain/subscription"
+	"hinsun-backend/internal/domain/usecases"
+	"hinsun-backend/internal/domain/values"
+	"hinsun-backend/pkg/mailer"
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+// SubscriptionAppService manages who follows categories and authors, new blogs are announced to
+// them by the blog and notification app services
+type SubscriptionAppService interface {
+	usecases.ManageFollowUseCase
+	usecases.EmailSubscriptionUseCase
+}
+
+type subscriptionAppService struct {
+	subscriptionService subscription.SubscriptionService
+	categoryService     category.CategoryService
+	accountService      account.AccountService
+	mailer              mailer.Mailer
+	confirmURL          string // page confirming email subscriptions, the token is appended
+}
+
+func NewSubscriptionAppService(
+	subscriptionService subscription.SubscriptionService,
+	categoryService category.CategoryService,
+	accountService account.AccountService,
+	mailer mailer.Mailer,
+	confirmURL string,
+) SubscriptionAppService {
+	return &subscriptionAppService{
+		subscriptionService: subscriptionService,
+		categoryService:     categoryService,
+		accountService:      accountService,
+		mailer:              mailer,
+		confirmURL:          confirmURL,
+	}
+}
+
+// ===== ManageFollowUseCase =====
+
+func (s *subscriptionAppService) FindFollows(ctx context.Context, actor *values.Actor) ([]*subscription.FollowEntity, error) {
+	return s.subscriptionService.FindAccountFollows(ctx, actor.AccountID)
+}
+
+func (s *subscriptionAppService) Follow(ctx context.Context, actor *values.Actor, params *usecases.FollowParams) (*subscription.FollowEntity, error) {
+	accountID, err := uuid.Parse(actor.AccountID)
+	if err != nil {
+		return nil, failure.NewAuthenticationFailure("invalid account in token")
+	}
+
+	target, err := s.resolveTarget(ctx, params.TargetType, params.TargetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.subscriptionService.Follow(ctx, accountID, target)
+}
+
+func (s *subscriptionAppService) Unfollow(ctx context.Context, actor *values.Actor, id string) error {
+	return s.subscriptionService.Unfollow(ctx, actor.AccountID, id)
+}
+
+// ===== EmailSubscriptionUseCase =====
+
+// SubscribeByEmail sends the confirmation link of a new or still pending subscription. It
+// reports success either way, so the endpoint does not reveal who is subscribed.
+func (s *subscriptionAppService) SubscribeByEmail(ctx context.Context, params *usecases.SubscribeByEmailParams) error {
+	// 1. Validate the target
+	target, err := s.resolveTarget(ctx, params.TargetType, params.TargetID)
+	if err != nil {
+		return err
+	}
+
+	// 2. Record the pending subscription
+	emailSubscription, err := s.subscriptionService.SubscribeEmail(ctx, params.Email, target)
+	if err != nil {
+		return err
+	}
+
+	if emailSubscription.IsConfirmed() {
+		return nil
+	}
+
+	// 3. Ask the address owner to confirm
+	confirmLink := s.confirmURL + "?token=" + url.QueryEscape(emailSubscription.Token)
+	return s.mailer.Send(ctx, &mailer.Message{
+		To:      emailSubscription.Email,
+		Subject: "Confirm your subscription",
+		Body: fmt.Sprintf(
+			"Someone, hopefully you, asked to receive new blogs by email at this address.\n\n"+
+				"Confirm your subscription by visiting %s\n\n"+
+				"If this was not you, ignore this email and nothing will be sent.",
+			confirmLink,
+		),
+	})
+}
+
+func (s *subscriptionAppService) ConfirmEmailSubscription(ctx context.Context, params *usecases.SubscriptionTokenParams) (*subscription.EmailSubscriptionEntity, error) {
+	return s.subscriptionService.ConfirmEmail(ctx, params.Token)
+}
+
+func (s *subscriptionAppService) UnsubscribeEmail(ctx context.Context, params *usecases.SubscriptionTokenParams) error {
+	return s.subscriptionService.UnsubscribeEmail(ctx, params.Token)
+}
+
+// resolveTarget checks that the followed category or author exists
+func (s *subscriptionAppService) resolveTarget(ctx context.Context, targetTypeName string, targetID uuid.UUID) (subscription.Target, error) {
+	targetType, err := subscription.TargetTypeFromString(targetTypeName)
+	if err != nil {
+		return subscription.Target{}, err
+	}
+
+	switch targetType {
+	case subscription.TargetCategory:
+		categoryEntity, err := s.categoryService.FindCategoryByID(ctx, targetID.String())
+		if err != nil {
+			return subscription.Target{}, err
+		}
+
+		if categoryEntity == nil {
+			return subscription.Target{}, failure.NewNotFoundFailure(fmt.Sprintf("Category with ID %s not found", targetID))
+		}
+	case subscription.TargetAuthor:
+		author, err := s.accountService.FindAccountByID(ctx, targetID.String())
+		if err != nil {
+			return subscription.Target{}, err
+		}
+
+		if author == nil || !author.IsActive {
+			return subscription.Target{}, failure.NewNotFoundFailure(fmt.Sprintf("Author with ID %s not found", targetID))
+		}
+	}
+
+	return subscription.Target{Type: targetType, ID: targetID}, nil
+}
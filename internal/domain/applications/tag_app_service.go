@@ -0,0 +1,140 @@
+package applications
+
+import (
+	"context"
+	"fmt"
+	"hinsun-backend/internal/core/cache"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/core/transaction"
+	"hinsun-backend/internal/core/types"
+	"hinsun-backend/internal/domain/project"
+	"hinsun-backend/internal/domain/tag"
+	"hinsun-backend/internal/domain/usecases"
+)
+
+type TagAppService interface {
+	usecases.ManageTagUseCase
+}
+
+type tagAppService struct {
+	tagService     tag.TagService
+	projectService project.ProjectService
+	unitOfWork     transaction.UnitOfWork
+	cache          *cache.ReadThrough
+}
+
+func NewTagAppService(
+	tagService tag.TagService,
+	projectService project.ProjectService,
+	unitOfWork transaction.UnitOfWork,
+	cache *cache.ReadThrough,
+) TagAppService {
+	return &tagAppService{
+		tagService:     tagService,
+		projectService: projectService,
+		unitOfWork:     unitOfWork,
+		cache:          cache,
+	}
+}
+
+func (s *tagAppService) FindTags(ctx context.Context, query *usecases.FindTagsQuery) ([]*tag.TagEntity, error) {
+	return s.tagService.FindAllTags(ctx, query.Sort == "usage")
+}
+
+func (s *tagAppService) FindTag(ctx context.Context, id string) (*tag.TagEntity, error) {
+	tag, err := s.tagService.FindTagByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if tag == nil {
+		return nil, failure.NewNotFoundFailure("Tag with the given ID does not exist")
+	}
+
+	return tag, nil
+}
+
+func (s *tagAppService) CreateTag(ctx context.Context, params *usecases.CreateTagParams) (*tag.TagEntity, error) {
+	return s.tagService.CreateTag(ctx, params.Name)
+}
+
+func (s *tagAppService) UpdateTag(ctx context.Context, id string, expectedVersion *int64, params *usecases.UpdateTagParams) (*tag.TagEntity, error) {
+	// The projects are renamed along with the tag, or neither is
+	var updatedTag *tag.TagEntity
+	renamed := 0
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var previousName string
+		var err error
+		updatedTag, previousName, err = s.tagService.UpdateTag(ctx, id, params.Name, expectedVersion)
+		if err != nil || previousName == updatedTag.Name {
+			return err
+		}
+
+		renamed, err = s.projectService.RenameTag(ctx, previousName, updatedTag.Name)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if renamed > 0 {
+		s.cache.Invalidate(ctx, projectsCacheNamespace)
+	}
+
+	return updatedTag, nil
+}
+
+func (s *tagAppService) DeleteTag(ctx context.Context, id string) (*types.DeletedResult, error) {
+	if err := s.checkUnused(ctx, id); err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := s.tagService.DeleteTag(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.DeletedResult{
+		RowsAffected: rowsAffected,
+		Payload:      id,
+	}, nil
+}
+
+func (s *tagAppService) DeleteMultipleTags(ctx context.Context, query *usecases.DeleteTagsQuery) (*types.DeletedResult, error) {
+	for _, id := range query.IDs {
+		if err := s.checkUnused(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	rowsAffected, err := s.tagService.DeleteMultipleTags(ctx, query.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.DeletedResult{
+		RowsAffected: rowsAffected,
+		Payload:      query.IDs,
+	}, nil
+}
+
+// checkUnused rejects deleting a tag some project still uses, in the trash too since restoring
+// the project would bring the tag back
+func (s *tagAppService) checkUnused(ctx context.Context, id string) error {
+	existingTag, err := s.tagService.FindTagByID(ctx, id)
+	if err != nil || existingTag == nil {
+		return err
+	}
+
+	numProjects, err := s.projectService.CountTagUsage(ctx, existingTag.Name)
+	if err != nil {
+		return err
+	}
+
+	if numProjects > 0 {
+		return failure.NewConflictFailure(fmt.Sprintf("Tag '%s' is still used by %d projects", existingTag.Name, numProjects))
+	}
+
+	return nil
+}
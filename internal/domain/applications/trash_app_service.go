@@ -0,0 +1,56 @@
+package applications
+
+import (
+	"context"
+	"hinsun-backend/internal/core/cache"
+	"hinsun-backend/internal/domain/trash"
+	"hinsun-backend/internal/domain/usecases"
+)
+
+type TrashAppService interface {
+	usecases.ManageTrashUseCase
+}
+
+type trashAppService struct {
+	trashService trash.TrashService
+	cache        *cache.ReadThrough
+}
+
+func NewTrashAppService(trashService trash.TrashService, cache *cache.ReadThrough) TrashAppService {
+	return &trashAppService{
+		trashService: trashService,
+		cache:        cache,
+	}
+}
+
+func (s *trashAppService) FindTrash(ctx context.Context, query *usecases.FindTrashQuery) ([]*trash.TrashItemEntity, error) {
+	if query.Resource == "" {
+		return s.trashService.FindTrash(ctx, trash.AllResources())
+	}
+
+	resource, err := trash.ResourceFromString(query.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.trashService.FindTrash(ctx, []trash.Resource{resource})
+}
+
+func (s *trashAppService) RestoreItem(ctx context.Context, resource string, id string) (*usecases.RestoredItemResponse, error) {
+	trashResource, err := trash.ResourceFromString(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.trashService.RestoreItem(ctx, trashResource, id); err != nil {
+		return nil, err
+	}
+
+	// The restored item reappears in public reads, see cache_namespaces.go
+	s.cache.Invalidate(ctx, string(trashResource))
+
+	return &usecases.RestoredItemResponse{
+		ID:       id,
+		Resource: trashResource,
+	}, nil
+}
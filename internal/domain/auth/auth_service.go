@@ -1,27 +1,57 @@
 package auth
 
 import (
+	"errors"
 	"hinsun-backend/internal/core/failure"
 	"hinsun-backend/pkg/jwt"
 	"hinsun-backend/pkg/security"
+	"strings"
+	"time"
 )
 
 type AuthService interface {
 	HashPassword(password string) (string, error)
 	VerifyPassword(password, hash string) error
-	GenerateTokenPair(accountID, email string, role int) (*jwt.TokenPair, error)
+	GenerateTokenPair(subject *jwt.TokenSubject, twoFactor bool) (*jwt.TokenPair, error)
 	VerifyRefreshToken(refreshToken string) (*jwt.Claims, error)
+
+	// Two-factor authentication
+	GenerateChallengeToken(accountID, email string) (string, error)
+	VerifyChallengeToken(challengeToken string) (*jwt.Claims, error)
+	GenerateTwoFactorSecret() (secret string, sealedSecret string, err error)
+	SealTwoFactorSecret(secret string) (string, error)
+	IsTwoFactorSecretSealed(secret string) bool
+	BuildTwoFactorURI(secret, accountName string) string
+	VerifyTwoFactorCode(sealedSecret, code string, lastStep int64) (int64, error)
+	GenerateRecoveryCodes() (codes []string, hashedCodes []string, err error)
+	MatchRecoveryCode(code string, hashedCodes []string) (int, error)
+
+	// Signing keys
+	RotateSigningKeys() (*jwt.RotatedKeys, error)
+	PublicKeySet() *jwt.JWKSet
 }
 
 type authService struct {
-	passwordHasher security.PasswordHasher
-	jwtService     jwt.JwtService
+	passwordHasher    security.PasswordHasher
+	jwtService        jwt.JwtService
+	totp              security.TOTP
+	secretBox         security.SecretBox
+	recoveryCodeCount int
 }
 
-func NewAuthService(passwordHasher security.PasswordHasher, jwtService jwt.JwtService) AuthService {
+func NewAuthService(
+	passwordHasher security.PasswordHasher,
+	jwtService jwt.JwtService,
+	totp security.TOTP,
+	secretBox security.SecretBox,
+	recoveryCodeCount int,
+) AuthService {
 	return &authService{
-		passwordHasher: passwordHasher,
-		jwtService:     jwtService,
+		passwordHasher:    passwordHasher,
+		jwtService:        jwtService,
+		totp:              totp,
+		secretBox:         secretBox,
+		recoveryCodeCount: recoveryCodeCount,
 	}
 }
 
@@ -49,9 +79,10 @@ func (s *authService) VerifyPassword(password, hash string) error {
 	return nil
 }
 
-// GenerateTokenPair generates a new JWT token pair for the given account ID and email.
-func (s *authService) GenerateTokenPair(accountID, email string, role int) (*jwt.TokenPair, error) {
-	tokenPair, err := s.jwtService.GenerateTokenPair(accountID, email, role)
+// GenerateTokenPair generates a new JWT token pair carrying the subject's roles and permissions.
+// twoFactor marks the session as having passed a second factor.
+func (s *authService) GenerateTokenPair(subject *jwt.TokenSubject, twoFactor bool) (*jwt.TokenPair, error) {
+	tokenPair, err := s.jwtService.GenerateTokenPair(subject, twoFactor)
 	if err != nil {
 		return nil, failure.NewInternalFailure("failed to generate token pair", err)
 	}
@@ -68,3 +99,135 @@ func (s *authService) VerifyRefreshToken(refreshToken string) (*jwt.Claims, erro
 
 	return claims, nil
 }
+
+// GenerateChallengeToken issues the short-lived token used for the second login step.
+func (s *authService) GenerateChallengeToken(accountID, email string) (string, error) {
+	token, err := s.jwtService.GenerateChallengeToken(accountID, email)
+	if err != nil {
+		return "", failure.NewInternalFailure("failed to generate challenge token", err)
+	}
+
+	return token, nil
+}
+
+// VerifyChallengeToken verifies the challenge token and returns the claims if valid.
+func (s *authService) VerifyChallengeToken(challengeToken string) (*jwt.Claims, error) {
+	claims, err := s.jwtService.ValidateChallengeToken(challengeToken)
+	if err != nil {
+		return nil, failure.NewAuthenticationFailure("invalid or expired challenge token").WithCause(err)
+	}
+
+	return claims, nil
+}
+
+// GenerateTwoFactorSecret generates a new base32 TOTP secret, shown once to the account, and
+// its sealed form, the only one stored.
+func (s *authService) GenerateTwoFactorSecret() (string, string, error) {
+	secret, err := s.totp.GenerateSecret()
+	if err != nil {
+		return "", "", failure.NewInternalFailure("failed to generate two-factor secret", err)
+	}
+
+	sealedSecret, err := s.SealTwoFactorSecret(secret)
+	if err != nil {
+		return "", "", err
+	}
+
+	return secret, sealedSecret, nil
+}
+
+// SealTwoFactorSecret encrypts a TOTP secret for storage.
+func (s *authService) SealTwoFactorSecret(secret string) (string, error) {
+	sealedSecret, err := s.secretBox.Seal(secret)
+	if err != nil {
+		return "", failure.NewInternalFailure("failed to encrypt two-factor secret", err)
+	}
+
+	return sealedSecret, nil
+}
+
+// IsTwoFactorSecretSealed tells sealed secrets from the plain ones stored before encryption.
+func (s *authService) IsTwoFactorSecretSealed(secret string) bool {
+	return security.IsSealed(secret)
+}
+
+// BuildTwoFactorURI builds the otpauth:// URI used by authenticator apps.
+func (s *authService) BuildTwoFactorURI(secret, accountName string) string {
+	return s.totp.ProvisioningURI(secret, accountName)
+}
+
+// VerifyTwoFactorCode checks a TOTP code against the sealed secret, rejecting codes of steps up to lastStep.
+// The secret is decrypted for this check only.
+func (s *authService) VerifyTwoFactorCode(sealedSecret, code string, lastStep int64) (int64, error) {
+	secret, err := s.secretBox.Open(sealedSecret)
+	if err != nil {
+		return 0, failure.NewInternalFailure("failed to decrypt two-factor secret", err)
+	}
+
+	step, isValid, err := s.totp.Validate(secret, code, time.Now(), uint64(max(lastStep, 0)))
+	if err != nil {
+		return 0, failure.NewInternalFailure("failed to verify two-factor code", err)
+	}
+
+	if !isValid {
+		return 0, failure.NewAuthenticationFailure("invalid two-factor code")
+	}
+
+	return int64(step), nil
+}
+
+// GenerateRecoveryCodes returns plain recovery codes (shown once) and their hashes (stored).
+func (s *authService) GenerateRecoveryCodes() ([]string, []string, error) {
+	codes, err := security.GenerateRecoveryCodes(s.recoveryCodeCount)
+	if err != nil {
+		return nil, nil, failure.NewInternalFailure("failed to generate recovery codes", err)
+	}
+
+	hashedCodes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashed, err := s.passwordHasher.Hash(code)
+		if err != nil {
+			return nil, nil, failure.NewInternalFailure("failed to hash recovery code", err)
+		}
+
+		hashedCodes = append(hashedCodes, hashed)
+	}
+
+	return codes, hashedCodes, nil
+}
+
+// MatchRecoveryCode returns the index of the hashed code matching the given code.
+func (s *authService) MatchRecoveryCode(code string, hashedCodes []string) (int, error) {
+	normalized := strings.ToLower(strings.TrimSpace(code))
+	for idx, hashed := range hashedCodes {
+		isValid, err := s.passwordHasher.Verify(normalized, hashed)
+		if err != nil {
+			return -1, failure.NewInternalFailure("failed to verify recovery code", err)
+		}
+
+		if isValid {
+			return idx, nil
+		}
+	}
+
+	return -1, failure.NewAuthenticationFailure("invalid recovery code")
+}
+
+// RotateSigningKeys activates new token signing keys and retires the current ones.
+func (s *authService) RotateSigningKeys() (*jwt.RotatedKeys, error) {
+	rotated, err := s.jwtService.RotateKeys()
+	if errors.Is(err, jwt.ErrStaticKeys) {
+		return nil, failure.NewConflictFailure("signing keys are pinned by configured secrets and cannot be rotated")
+	}
+
+	if err != nil {
+		return nil, failure.NewInternalFailure("failed to rotate signing keys", err)
+	}
+
+	return rotated, nil
+}
+
+// PublicKeySet returns the public keys used to verify access tokens.
+func (s *authService) PublicKeySet() *jwt.JWKSet {
+	return s.jwtService.PublicKeySet()
+}
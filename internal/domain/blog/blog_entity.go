@@ -14,26 +14,26 @@ const (
 	MaxBlogNameLength = 100
 	MaxBlogDescLength = 300
 	MaxCategories     = 5
-	MaxLanguages      = 10
-	MinLanguages      = 1
 )
 
 type BlogEntity struct {
-	ID                       uuid.UUID                     `json:"id"`
-	AuthorID                 uuid.UUID                     `json:"authorId"`
-	Slug                     string                        `json:"slug"`
-	Languages                []values.MarkdownLanguageCode `json:"languages"` // Array of supported language codes
-	Categories               []string                      `json:"categories"`
-	Names                    values.MultiLangText          `json:"names"`        // Map of language code -> name
-	Descriptions             values.MultiLangText          `json:"descriptions"` // Map of language code -> description
-	IsPublished              bool                          `json:"isPublished"`
-	Markdowns                values.MultiLangText          `json:"markdowns"` // Map of language code -> markdown content
-	Favorites                int64                         `json:"favorites"`
-	Views                    int64                         `json:"views"`
-	EstimatedReadTimeSeconds int64                         `json:"estimatedReadTimeSeconds"`
-	CreatedAt                int64                         `json:"createdAt"`
-	UpdatedAt                int64                         `json:"updatedAt"`
-	DeletedAt                *int64                        `json:"deletedAt,omitempty"`
+	ID                       uuid.UUID                       `json:"id"`
+	AuthorID                 uuid.UUID                       `json:"authorId"`
+	Slug                     string                          `json:"slug"`
+	Languages                []values.MarkdownLanguageCode   `json:"languages"` // Array of supported language codes
+	Categories               []string                        `json:"categories"`
+	Names                    values.MultiLangText            `json:"names"`        // Map of language code -> name
+	Descriptions             values.MultiLangText            `json:"descriptions"` // Map of language code -> description
+	IsPublished              bool                            `json:"isPublished"`
+	Markdowns                values.MultiLangText            `json:"markdowns"`        // Map of language code -> markdown content
+	Images                   map[string]values.ImageVariants `json:"images,omitempty"` // Map of image URL in the markdowns -> variants, resolved on read
+	Favorites                int64                           `json:"favorites"`
+	Views                    int64                           `json:"views"`
+	EstimatedReadTimeSeconds int64                           `json:"estimatedReadTimeSeconds"`
+	CreatedAt                int64                           `json:"createdAt"`
+	UpdatedAt                int64                           `json:"updatedAt"`
+	Version                  int64                           `json:"version"`
+	DeletedAt                *int64                          `json:"deletedAt,omitempty"`
 }
 
 func NewBlogEntity(
@@ -45,20 +45,20 @@ func NewBlogEntity(
 	estimatedReadTimeSeconds int64,
 ) (*BlogEntity, error) {
 	// Validate languages
-	if err := ValidateLanguages(languages); err != nil {
+	if err := values.ValidateLanguages(languages); err != nil {
 		return nil, err
 	}
 
 	// Validate that all languages have corresponding content
-	if err := ValidateMultiLangContent(languages, names, "name"); err != nil {
+	if err := values.ValidateMultiLangContent(languages, names, "name"); err != nil {
 		return nil, err
 	}
 
-	if err := ValidateMultiLangContent(languages, descriptions, "description"); err != nil {
+	if err := values.ValidateMultiLangContent(languages, descriptions, "description"); err != nil {
 		return nil, err
 	}
 
-	if err := ValidateMultiLangContent(languages, markdowns, "markdown"); err != nil {
+	if err := values.ValidateMultiLangContent(languages, markdowns, "markdown"); err != nil {
 		return nil, err
 	}
 
@@ -109,60 +109,11 @@ func NewBlogEntity(
 		EstimatedReadTimeSeconds: estimatedReadTimeSeconds,
 		CreatedAt:                now.Unix(),
 		UpdatedAt:                now.Unix(),
+		Version:                  1,
 		DeletedAt:                nil,
 	}, nil
 }
 
-func ValidateLanguages(languages []values.MarkdownLanguageCode) error {
-	if len(languages) < MinLanguages {
-		return failure.NewValidationFailure(
-			fmt.Sprintf("at least %d language is required", MinLanguages),
-		)
-	}
-
-	if len(languages) > MaxLanguages {
-		return failure.NewValidationFailure(
-			fmt.Sprintf("number of languages exceeds maximum of %d", MaxLanguages),
-		)
-	}
-
-	// Check for duplicates
-	seen := make(map[values.MarkdownLanguageCode]bool)
-	for _, lang := range languages {
-		if seen[lang] {
-			return failure.NewValidationFailure(fmt.Sprintf("duplicate language code: %s", lang))
-		}
-
-		seen[lang] = true
-
-		// Validate language code format (should be 2-3 lowercase letters)
-		if len(lang) < 2 || len(lang) > 3 {
-			return failure.NewValidationFailure(
-				fmt.Sprintf("invalid language code '%s': must be 2-3 characters", lang),
-			)
-		}
-	}
-
-	return nil
-}
-
-func ValidateMultiLangContent(languages []values.MarkdownLanguageCode, content values.MultiLangText, fieldName string) error {
-	if content == nil {
-		return failure.NewValidationFailure(fmt.Sprintf("%s cannot be nil", fieldName))
-	}
-
-	// Check that all languages have content
-	for _, lang := range languages {
-		if text, exists := content[lang]; !exists || text == "" {
-			return failure.NewValidationFailure(
-				fmt.Sprintf("%s is missing content for language: %s", fieldName, lang),
-			)
-		}
-	}
-
-	return nil
-}
-
 func ValidateBlogName(name string) error {
 	if len(name) == 0 {
 		return failure.NewValidationFailure("blog name cannot be empty")
@@ -214,18 +165,18 @@ func (b *BlogEntity) Update(
 	estimatedReadTimeSeconds int64,
 ) error {
 	// Validate languages
-	if err := ValidateLanguages(languages); err != nil {
+	if err := values.ValidateLanguages(languages); err != nil {
 		return err
 	}
 
 	// Validate that all languages have corresponding content
-	if err := ValidateMultiLangContent(languages, names, "name"); err != nil {
+	if err := values.ValidateMultiLangContent(languages, names, "name"); err != nil {
 		return err
 	}
-	if err := ValidateMultiLangContent(languages, descriptions, "description"); err != nil {
+	if err := values.ValidateMultiLangContent(languages, descriptions, "description"); err != nil {
 		return err
 	}
-	if err := ValidateMultiLangContent(languages, markdowns, "markdown"); err != nil {
+	if err := values.ValidateMultiLangContent(languages, markdowns, "markdown"); err != nil {
 		return err
 	}
 
@@ -28,6 +28,7 @@ type BlogService interface {
 		name, description, markdown values.MultiLangText,
 		isPublished bool,
 		estimatedReadTimeSeconds int64,
+		expectedVersion *int64,
 	) (*BlogEntity, error)
 
 	FindBlogs(ctx context.Context) ([]*BlogEntity, error)
@@ -90,6 +91,7 @@ func (s *blogService) UpdateBlog(
 	name, description, markdown values.MultiLangText,
 	isPublished bool,
 	estimatedReadTimeSeconds int64,
+	expectedVersion *int64,
 ) (*BlogEntity, error) {
 	// 1. Retrieve existing blog
 	existingBlog, err := s.repository.FindByID(ctx, id)
@@ -101,6 +103,11 @@ func (s *blogService) UpdateBlog(
 		return nil, failure.NewNotFoundFailure("Blog with the given ID does not exist")
 	}
 
+	// The update must be based on the version the client last read
+	if err := values.CheckVersion(existingBlog.Version, expectedVersion); err != nil {
+		return nil, err
+	}
+
 	// 2. Update fields
 	err = existingBlog.Update(
 		authorID,
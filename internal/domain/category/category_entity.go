@@ -19,6 +19,7 @@ type CategoryEntity struct {
 	NumBlogs  int64     `json:"numBlogs"`
 	CreatedAt int64     `json:"createdAt"`
 	UpdatedAt int64     `json:"updatedAt"`
+	Version   int64     `json:"version"`
 	DeletedAt *int64    `json:"deletedAt,omitempty"`
 }
 
@@ -34,6 +35,7 @@ func NewCategory(name string) (*CategoryEntity, error) {
 		NumBlogs:  0,
 		CreatedAt: now.Unix(),
 		UpdatedAt: now.Unix(),
+		Version:   1,
 		DeletedAt: nil,
 	}, nil
 }
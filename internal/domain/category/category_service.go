@@ -3,6 +3,7 @@ package category
 import (
 	"context"
 	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/values"
 )
 
 type CategoryService interface {
@@ -10,7 +11,7 @@ type CategoryService interface {
 	FindCategoryByID(ctx context.Context, id string) (*CategoryEntity, error)
 	FindCategoryByName(ctx context.Context, name string) (*CategoryEntity, error)
 	CreateCategory(ctx context.Context, name string) (*CategoryEntity, error)
-	UpdateCategory(ctx context.Context, id string, name string) (*CategoryEntity, error)
+	UpdateCategory(ctx context.Context, id string, name string, expectedVersion *int64) (*CategoryEntity, error)
 	DeleteCategory(ctx context.Context, id string) (int, error)
 	DeleteMultipleCategories(ctx context.Context, ids []string) (int, error)
 	IncrementBlogCount(ctx context.Context, categoryID string) error
@@ -65,7 +66,7 @@ func (s *categoryService) CreateCategory(ctx context.Context, name string) (*Cat
 	return newCategory, nil
 }
 
-func (s *categoryService) UpdateCategory(ctx context.Context, id string, name string) (*CategoryEntity, error) {
+func (s *categoryService) UpdateCategory(ctx context.Context, id string, name string, expectedVersion *int64) (*CategoryEntity, error) {
 	// Find existing category
 	existingCategory, err := s.repository.FindByID(ctx, id)
 	if err != nil {
@@ -76,6 +77,11 @@ func (s *categoryService) UpdateCategory(ctx context.Context, id string, name st
 		return nil, failure.NewNotFoundFailure("Category with the given ID does not exist")
 	}
 
+	// The update must be based on the version the client last read
+	if err := values.CheckVersion(existingCategory.Version, expectedVersion); err != nil {
+		return nil, err
+	}
+
 	// Check if another category with the same name exists
 	categoryWithSameName, err := s.repository.FindByName(ctx, name)
 	if err != nil {
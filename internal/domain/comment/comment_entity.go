@@ -20,6 +20,7 @@ type CommentEntity struct {
 	Favorites int64      `json:"favorites"`
 	CreatedAt int64      `json:"createdAt"`
 	UpdatedAt int64      `json:"updatedAt"`
+	Version   int64      `json:"version"`
 	DeletedAt *int64     `json:"deletedAt,omitempty"`
 }
 
@@ -39,6 +40,7 @@ func NewCommentEntity(authorID, blogID uuid.UUID, parentID *uuid.UUID, content s
 		Favorites: 0,
 		CreatedAt: now.Unix(),
 		UpdatedAt: now.Unix(),
+		Version:   1,
 		DeletedAt: nil,
 	}, nil
 }
@@ -0,0 +1,39 @@
+package comment
+
+import (
+	"hinsun-backend/internal/core/events"
+	"hinsun-backend/internal/domain/values"
+)
+
+// Event names constants
+const (
+	EventCommentAdded = "comment.added"
+)
+
+type CommentAddedEvent struct {
+	events.BaseEvent
+}
+
+type CommentAddedPayload struct {
+	CommentID      string               `json:"comment_id"`
+	BlogID         string               `json:"blog_id"`
+	BlogNames      values.MultiLangText `json:"blog_names"`
+	AuthorID       string               `json:"author_id"`
+	AuthorName     string               `json:"author_name"`
+	ParentID       string               `json:"parent_id,omitempty"`
+	ParentAuthorID string               `json:"parent_author_id,omitempty"`
+	Content        string               `json:"content"`
+	MentionedIDs   []string             `json:"mentioned_ids,omitempty"`
+	CreatedAt      int64                `json:"created_at"`
+}
+
+// NewCommentAddedEvent creates a new comment added event
+func NewCommentAddedEvent(payload CommentAddedPayload) *CommentAddedEvent {
+	return &CommentAddedEvent{
+		BaseEvent: events.NewBaseEvent(
+			EventCommentAdded,
+			payload.CommentID,
+			payload,
+		),
+	}
+}
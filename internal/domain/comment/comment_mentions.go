@@ -0,0 +1,41 @@
+package comment
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// MaxMentions bounds the accounts notified by a single comment
+const MaxMentions = 10
+
+// mentionPattern matches @[Display Name](account-id), the form inserted by the mention picker
+var mentionPattern = regexp.MustCompile(`@\[[^\]\n]{1,100}\]\(([0-9a-fA-F-]{36})\)`)
+
+// ExtractMentions returns the distinct account IDs mentioned in the content, in order
+func ExtractMentions(content string) []string {
+	mentioned := make([]string, 0)
+	for _, match := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		id, err := uuid.Parse(match[1])
+		if err != nil || slices.Contains(mentioned, id.String()) {
+			continue
+		}
+
+		mentioned = append(mentioned, id.String())
+		if len(mentioned) == MaxMentions {
+			break
+		}
+	}
+
+	return mentioned
+}
+
+// ReplaceMentions turns mentions into plain @Display Name text, e.g. for notification excerpts
+func ReplaceMentions(content string) string {
+	return mentionPattern.ReplaceAllStringFunc(content, func(mention string) string {
+		// @[Name](id) -> @Name
+		return "@" + mention[2:strings.LastIndex(mention, "](")]
+	})
+}
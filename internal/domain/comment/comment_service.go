@@ -3,6 +3,7 @@ package comment
 import (
 	"context"
 	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/values"
 
 	"github.com/google/uuid"
 )
@@ -13,11 +14,11 @@ type CommentService interface {
 	FindComments(ctx context.Context) ([]*CommentEntity, error)
 
 	FindComment(ctx context.Context, id string) (*CommentEntity, error)
-	UpdateComment(ctx context.Context, id string, content string) (*CommentEntity, error)
+	UpdateComment(ctx context.Context, id string, content string, expectedVersion *int64) (*CommentEntity, error)
 	DeleteComment(ctx context.Context, id string) (int, error)
 
 	FindCommentsByBlogID(ctx context.Context, blogId string) ([]*CommentEntity, error)
-	UpdateCommentByOwner(ctx context.Context, id string, content string, ownerId uuid.UUID) (*CommentEntity, error)
+	UpdateCommentByOwner(ctx context.Context, id string, content string, ownerId uuid.UUID, expectedVersion *int64) (*CommentEntity, error)
 	DeleteCommentByOwner(ctx context.Context, id string, ownerId uuid.UUID) (int, error)
 }
 
@@ -64,7 +65,7 @@ func (s *commentService) FindComment(ctx context.Context, id string) (*CommentEn
 	return s.repository.Find(ctx, id)
 }
 
-func (s *commentService) UpdateComment(ctx context.Context, id string, content string) (*CommentEntity, error) {
+func (s *commentService) UpdateComment(ctx context.Context, id string, content string, expectedVersion *int64) (*CommentEntity, error) {
 	// Find existing comment
 	existingComment, err := s.repository.Find(ctx, id)
 	if err != nil {
@@ -75,6 +76,11 @@ func (s *commentService) UpdateComment(ctx context.Context, id string, content s
 		return nil, failure.NewNotFoundFailure("Comment with the given ID does not exist")
 	}
 
+	// The update must be based on the version the client last read
+	if err := values.CheckVersion(existingComment.Version, expectedVersion); err != nil {
+		return nil, err
+	}
+
 	// Update comment
 	err = existingComment.Update(content)
 	if err != nil {
@@ -102,7 +108,7 @@ func (s *commentService) FindCommentsByBlogID(ctx context.Context, blogId string
 	return s.repository.FindByBlogID(ctx, blogId)
 }
 
-func (s *commentService) UpdateCommentByOwner(ctx context.Context, id string, content string, ownerId uuid.UUID) (*CommentEntity, error) {
+func (s *commentService) UpdateCommentByOwner(ctx context.Context, id string, content string, ownerId uuid.UUID, expectedVersion *int64) (*CommentEntity, error) {
 	// 1. Find existing comment
 	existingComment, err := s.repository.Find(ctx, id)
 	if err != nil {
@@ -113,6 +119,11 @@ func (s *commentService) UpdateCommentByOwner(ctx context.Context, id string, co
 		return nil, failure.NewNotFoundFailure("Comment with the given ID does not exist")
 	}
 
+	// The update must be based on the version the client last read
+	if err := values.CheckVersion(existingComment.Version, expectedVersion); err != nil {
+		return nil, err
+	}
+
 	// 2. Verify ownership
 	if existingComment.AuthorID != ownerId {
 		return nil, failure.NewValidationFailure("You are not authorized to update this comment")
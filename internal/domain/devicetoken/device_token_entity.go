@@ -0,0 +1,59 @@
+package devicetoken
+
+import (
+	"hinsun-backend/internal/core/failure"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Platform string
+
+const (
+	PlatformAndroid Platform = "android"
+	PlatformIOS     Platform = "ios"
+	PlatformWeb     Platform = "web"
+)
+
+// MaxTokenLength bounds registration tokens, FCM tokens are currently about 160 characters
+const MaxTokenLength = 4096
+
+func (p Platform) IsValid() bool {
+	switch p {
+	case PlatformAndroid, PlatformIOS, PlatformWeb:
+		return true
+	default:
+		return false
+	}
+}
+
+type DeviceTokenEntity struct {
+	ID         uuid.UUID `json:"id"`
+	AccountID  uuid.UUID `json:"accountId"`
+	Token      string    `json:"token"`
+	Platform   Platform  `json:"platform"`
+	CreatedAt  int64     `json:"createdAt"`
+	LastSeenAt int64     `json:"lastSeenAt"`
+}
+
+func NewDeviceToken(accountID uuid.UUID, token string, platform Platform) (*DeviceTokenEntity, error) {
+	token = strings.TrimSpace(token)
+	if token == "" || len(token) > MaxTokenLength {
+		return nil, failure.NewValidationFailure("device token must be between 1 and 4096 characters")
+	}
+
+	if !platform.IsValid() {
+		return nil, failure.NewValidationFailure("device platform must be one of android, ios or web")
+	}
+
+	now := time.Now().Unix()
+	return &DeviceTokenEntity{
+		ID:         uuid.New(),
+		AccountID:  accountID,
+		Token:      token,
+		Platform:   platform,
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}, nil
+}
@@ -0,0 +1,13 @@
+package devicetoken
+
+import (
+	"context"
+)
+
+type DeviceTokenRepository interface {
+	// Upsert stores the token, or moves an already registered token to the entity's account
+	Upsert(ctx context.Context, token *DeviceTokenEntity) (*DeviceTokenEntity, error)
+	FindByAccountID(ctx context.Context, accountID string) ([]*DeviceTokenEntity, error)
+	DeleteByID(ctx context.Context, accountID, id string) (int, error)
+	DeleteByTokens(ctx context.Context, tokens []string) (int, error)
+}
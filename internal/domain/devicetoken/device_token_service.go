@@ -0,0 +1,68 @@
+package devicetoken
+
+import (
+	"context"
+	"hinsun-backend/internal/core/failure"
+
+	"github.com/google/uuid"
+)
+
+type DeviceTokenService interface {
+	RegisterToken(ctx context.Context, accountID uuid.UUID, token string, platform Platform) (*DeviceTokenEntity, error)
+	FindAccountTokens(ctx context.Context, accountID string) ([]*DeviceTokenEntity, error)
+	UnregisterToken(ctx context.Context, accountID, id string) error
+	PruneTokens(ctx context.Context, tokens []string) (int, error)
+}
+
+type deviceTokenService struct {
+	repository DeviceTokenRepository
+}
+
+func NewDeviceTokenService(repository DeviceTokenRepository) DeviceTokenService {
+	return &deviceTokenService{
+		repository: repository,
+	}
+}
+
+// RegisterToken stores a push token for the account; registering a known token refreshes it and,
+// when the device changed hands, moves it to the new account
+func (s *deviceTokenService) RegisterToken(ctx context.Context, accountID uuid.UUID, token string, platform Platform) (*DeviceTokenEntity, error) {
+	deviceToken, err := NewDeviceToken(accountID, token, platform)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repository.Upsert(ctx, deviceToken)
+}
+
+func (s *deviceTokenService) FindAccountTokens(ctx context.Context, accountID string) ([]*DeviceTokenEntity, error) {
+	return s.repository.FindByAccountID(ctx, accountID)
+}
+
+// UnregisterToken removes a token owned by the given account
+func (s *deviceTokenService) UnregisterToken(ctx context.Context, accountID, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return failure.NewValidationFailure("invalid device token ID")
+	}
+
+	rowsAffected, err := s.repository.DeleteByID(ctx, accountID, id)
+	if err != nil {
+		return err
+	}
+
+	// Tokens of other accounts are reported as missing to avoid leaking their existence
+	if rowsAffected == 0 {
+		return failure.NewNotFoundFailure("Device token with the given ID does not exist")
+	}
+
+	return nil
+}
+
+// PruneTokens removes tokens the push provider no longer accepts
+func (s *deviceTokenService) PruneTokens(ctx context.Context, tokens []string) (int, error) {
+	if len(tokens) == 0 {
+		return 0, nil
+	}
+
+	return s.repository.DeleteByTokens(ctx, tokens)
+}
@@ -3,6 +3,7 @@ package experience
 import (
 	"fmt"
 	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/values"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,31 +18,36 @@ const (
 )
 
 type ExperienceEntity struct {
-	ID               uuid.UUID `json:"id"`
-	OrderIdx         int8      `json:"orderIdx"`
-	Position         string    `json:"position"`
-	Company          string    `json:"company"`
-	Location         string    `json:"location"`
-	Technologies     []string  `json:"technologies"`
-	Responsibilities []string  `json:"responsibilities"`
-	Period           string    `json:"period"`
-	Extra            any       `json:"extra,omitempty"`
-	CreatedAt        int64     `json:"createdAt"`
-	UpdatedAt        int64     `json:"updatedAt"`
-	DeletedAt        *int64    `json:"deletedAt,omitempty"`
+	ID               uuid.UUID                     `json:"id"`
+	OrderIdx         int                           `json:"orderIdx"`
+	Languages        []values.MarkdownLanguageCode `json:"languages"` // Array of supported language codes
+	Positions        values.MultiLangText          `json:"positions"` // Map of language code -> position
+	Companies        values.MultiLangText          `json:"companies"` // Map of language code -> company
+	Location         string                        `json:"location"`
+	Technologies     []string                      `json:"technologies"`
+	Responsibilities values.MultiLangList          `json:"responsibilities"` // Map of language code -> responsibilities
+	StartDate        values.Date                   `json:"startDate"`
+	EndDate          *values.Date                  `json:"endDate"`        // nil while the experience is current
+	DurationMonths   int                           `json:"durationMonths"` // computed on read
+	DisplayPeriods   values.MultiLangText          `json:"displayPeriods"` // Map of language code -> period, computed on read
+	Extra            any                           `json:"extra,omitempty"`
+	CreatedAt        int64                         `json:"createdAt"`
+	UpdatedAt        int64                         `json:"updatedAt"`
+	Version          int64                         `json:"version"`
+	DeletedAt        *int64                        `json:"deletedAt,omitempty"`
 }
 
 func NewExperience(
-	orderIdx int8,
-	position, company, location string,
-	technologies, responsibilities []string,
-	period string,
+	orderIdx int,
+	languages []values.MarkdownLanguageCode,
+	positions, companies values.MultiLangText,
+	location string,
+	technologies []string,
+	responsibilities values.MultiLangList,
+	startDate values.Date,
+	endDate *values.Date,
 ) (*ExperienceEntity, error) {
-	if err := ValidatePosition(position); err != nil {
-		return nil, err
-	}
-
-	if err := ValidateCompany(company); err != nil {
+	if err := ValidateExperienceContent(languages, positions, companies, responsibilities); err != nil {
 		return nil, err
 	}
 
@@ -53,7 +59,7 @@ func NewExperience(
 		return nil, err
 	}
 
-	if err := ValidateResponsibilities(responsibilities); err != nil {
+	if err := ValidatePeriod(startDate, endDate); err != nil {
 		return nil, err
 	}
 
@@ -61,19 +67,65 @@ func NewExperience(
 	return &ExperienceEntity{
 		ID:               uuid.New(),
 		OrderIdx:         orderIdx,
-		Position:         position,
-		Company:          company,
+		Languages:        languages,
+		Positions:        positions,
+		Companies:        companies,
 		Location:         location,
 		Technologies:     technologies,
 		Responsibilities: responsibilities,
-		Period:           period,
+		StartDate:        startDate,
+		EndDate:          endDate,
 		Extra:            nil,
 		CreatedAt:        now.Unix(),
 		UpdatedAt:        now.Unix(),
+		Version:          1,
 		DeletedAt:        nil,
 	}, nil
 }
 
+// ValidateExperienceContent checks that every language has its position, company and responsibilities
+func ValidateExperienceContent(
+	languages []values.MarkdownLanguageCode,
+	positions, companies values.MultiLangText,
+	responsibilities values.MultiLangList,
+) error {
+	if err := values.ValidateLanguages(languages); err != nil {
+		return err
+	}
+
+	if err := values.ValidateMultiLangContent(languages, positions, "position"); err != nil {
+		return err
+	}
+
+	if err := values.ValidateMultiLangContent(languages, companies, "company"); err != nil {
+		return err
+	}
+
+	if err := values.ValidateMultiLangList(languages, responsibilities, "responsibilities"); err != nil {
+		return err
+	}
+
+	for lang, text := range positions {
+		if err := ValidatePosition(text); err != nil {
+			return failure.NewValidationFailure(fmt.Sprintf("position for language '%s': %s", lang, err.Error()))
+		}
+	}
+
+	for lang, text := range companies {
+		if err := ValidateCompany(text); err != nil {
+			return failure.NewValidationFailure(fmt.Sprintf("company for language '%s': %s", lang, err.Error()))
+		}
+	}
+
+	for lang, items := range responsibilities {
+		if err := ValidateResponsibilities(items); err != nil {
+			return failure.NewValidationFailure(fmt.Sprintf("responsibilities for language '%s': %s", lang, err.Error()))
+		}
+	}
+
+	return nil
+}
+
 func ValidatePosition(position string) error {
 	if len(position) > MaxPositionLength {
 		return failure.NewValidationFailure(
@@ -124,17 +176,30 @@ func ValidateResponsibilities(responsibilities []string) error {
 	return nil
 }
 
-func (e *ExperienceEntity) Update(
-	orderIdx int8,
-	position, company, location string,
-	technologies, responsibilities []string,
-	period string,
-) error {
-	if err := ValidatePosition(position); err != nil {
-		return err
+// ValidatePeriod rejects an experience ending before it starts
+func ValidatePeriod(startDate values.Date, endDate *values.Date) error {
+	if startDate.IsZero() {
+		return failure.NewValidationFailure("start date is required")
 	}
 
-	if err := ValidateCompany(company); err != nil {
+	if endDate != nil && endDate.Before(startDate.Time) {
+		return failure.NewValidationFailure("end date cannot be before the start date")
+	}
+
+	return nil
+}
+
+func (e *ExperienceEntity) Update(
+	orderIdx int,
+	languages []values.MarkdownLanguageCode,
+	positions, companies values.MultiLangText,
+	location string,
+	technologies []string,
+	responsibilities values.MultiLangList,
+	startDate values.Date,
+	endDate *values.Date,
+) error {
+	if err := ValidateExperienceContent(languages, positions, companies, responsibilities); err != nil {
 		return err
 	}
 
@@ -146,18 +211,33 @@ func (e *ExperienceEntity) Update(
 		return err
 	}
 
-	if err := ValidateResponsibilities(responsibilities); err != nil {
+	if err := ValidatePeriod(startDate, endDate); err != nil {
 		return err
 	}
 
 	e.OrderIdx = orderIdx
-	e.Position = position
-	e.Company = company
+	e.Languages = languages
+	e.Positions = positions
+	e.Companies = companies
 	e.Location = location
 	e.Technologies = technologies
 	e.Responsibilities = responsibilities
-	e.Period = period
+	e.StartDate = startDate
+	e.EndDate = endDate
 	e.UpdatedAt = time.Now().Unix()
 
 	return nil
 }
+
+// Localized returns a copy of the experience holding only the text of the negotiated language
+func (e *ExperienceEntity) Localized(preferred []values.MarkdownLanguageCode) (*ExperienceEntity, values.MarkdownLanguageCode) {
+	language := values.NegotiateLanguage(preferred, e.Languages)
+
+	localized := *e
+	localized.Languages = []values.MarkdownLanguageCode{language}
+	localized.Positions = e.Positions.Only(language)
+	localized.Companies = e.Companies.Only(language)
+	localized.Responsibilities = e.Responsibilities.Only(language)
+	localized.DisplayPeriods = e.DisplayPeriods.Only(language)
+	return &localized, language
+}
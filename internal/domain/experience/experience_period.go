@@ -0,0 +1,95 @@
+package experience
+
+import (
+	"fmt"
+	"hinsun-backend/internal/domain/values"
+	"strings"
+)
+
+// periodFormat renders the period of an experience in one language
+type periodFormat struct {
+	month    func(date values.Date) string // month of a start or end date
+	present  string                        // end of a current experience
+	duration func(years, months int) string
+}
+
+// fallbackLanguage is used for languages without their own period format
+const fallbackLanguage = values.English
+
+var periodFormats = map[values.MarkdownLanguageCode]periodFormat{
+	values.English: {
+		month: func(date values.Date) string {
+			return date.Format("Jan 2006")
+		},
+		present: "Present",
+		duration: func(years, months int) string {
+			return joinDuration(plural(years, "yr", "yrs"), plural(months, "mo", "mos"))
+		},
+	},
+	values.Vietnamese: {
+		month: func(date values.Date) string {
+			return fmt.Sprintf("Tháng %d/%d", date.Month(), date.Year())
+		},
+		present: "Hiện tại",
+		duration: func(years, months int) string {
+			return joinDuration(plural(years, "năm", "năm"), plural(months, "tháng", "tháng"))
+		},
+	},
+}
+
+// DescribePeriod computes the duration and the display period of the experience in each of its
+// languages, as of today. It runs on every read since current experiences keep getting longer.
+func (e *ExperienceEntity) DescribePeriod(today values.Date) {
+	end := today
+	if e.EndDate != nil {
+		end = *e.EndDate
+	}
+
+	e.DurationMonths = e.StartDate.MonthsUntil(end)
+	e.DisplayPeriods = make(values.MultiLangText, len(e.Languages))
+	for _, language := range e.Languages {
+		e.DisplayPeriods[language] = e.formatPeriod(language)
+	}
+}
+
+// formatPeriod renders e.g. "Jan 2020 – Present · 2 yrs 3 mos"
+func (e *ExperienceEntity) formatPeriod(language values.MarkdownLanguageCode) string {
+	format, ok := periodFormats[language]
+	if !ok {
+		format = periodFormats[fallbackLanguage]
+	}
+
+	end := format.present
+	if e.EndDate != nil {
+		end = format.month(*e.EndDate)
+	}
+
+	period := format.month(e.StartDate) + " – " + end
+	if e.DurationMonths == 0 {
+		return period
+	}
+
+	return period + " · " + format.duration(e.DurationMonths/12, e.DurationMonths%12)
+}
+
+func plural(count int, singular, plural string) string {
+	switch count {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("%d %s", count, singular)
+	default:
+		return fmt.Sprintf("%d %s", count, plural)
+	}
+}
+
+func joinDuration(parts ...string) string {
+	var nonEmpty []string
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+
+	return strings.Join(nonEmpty, " ")
+}
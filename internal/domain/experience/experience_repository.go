@@ -2,6 +2,7 @@ package experience
 
 import (
 	"context"
+	"hinsun-backend/internal/domain/values"
 )
 
 type ExperienceRepository interface {
@@ -10,7 +11,11 @@ type ExperienceRepository interface {
 	Delete(ctx context.Context, id string) (int, error)
 	DeleteMany(ctx context.Context, ids []string) (int, error)
 	FindByID(ctx context.Context, id string) (*ExperienceEntity, error)
+	// FindAll returns the experiences by order, then by start date
 	FindAll(ctx context.Context) ([]*ExperienceEntity, error)
-	FindByOrderIdx(ctx context.Context, orderIdx int8) (*ExperienceEntity, error)
-	FindByCompany(ctx context.Context, company string) (*ExperienceEntity, error)
+	// MaxOrderIdx returns the highest order of the experiences, -1 when there is none
+	MaxOrderIdx(ctx context.Context) (int, error)
+	// UpdateOrder renumbers the experiences from 0 in the order of ids
+	UpdateOrder(ctx context.Context, ids []string) (int, error)
+	FindByCompany(ctx context.Context, language values.MarkdownLanguageCode, company string) (*ExperienceEntity, error)
 }
@@ -2,14 +2,19 @@ package experience
 
 import (
 	"context"
+	"fmt"
 	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/values"
 )
 
 type ExperienceService interface {
 	FindAllExperiences(ctx context.Context) ([]*ExperienceEntity, error)
 	FindExperienceByID(ctx context.Context, id string) (*ExperienceEntity, error)
-	CreateExperience(ctx context.Context, orderIdx int8, position, company, location string, technologies, responsibilities []string, period string) (*ExperienceEntity, error)
-	UpdateExperience(ctx context.Context, id string, orderIdx int8, position, company, location string, technologies, responsibilities []string, period string) (*ExperienceEntity, error)
+	// CreateExperience places the experience at orderIdx, or after the others when it is nil
+	CreateExperience(ctx context.Context, orderIdx *int, languages []values.MarkdownLanguageCode, positions, companies values.MultiLangText, location string, technologies []string, responsibilities values.MultiLangList, startDate values.Date, endDate *values.Date) (*ExperienceEntity, error)
+	UpdateExperience(ctx context.Context, id string, orderIdx int, languages []values.MarkdownLanguageCode, positions, companies values.MultiLangText, location string, technologies []string, responsibilities values.MultiLangList, startDate values.Date, endDate *values.Date, expectedVersion *int64) (*ExperienceEntity, error)
+	// ReorderExperiences renumbers the experiences in the order of ids, which must list each of them once
+	ReorderExperiences(ctx context.Context, ids []string) ([]*ExperienceEntity, error)
 	DeleteExperience(ctx context.Context, id string) (int, error)
 	DeleteMultipleExperiences(ctx context.Context, ids []string) (int, error)
 }
@@ -38,43 +43,42 @@ func (s *experienceService) FindExperienceByID(ctx context.Context, id string) (
 // CreateExperience creates a new experience entity and saves it to the repository
 func (s *experienceService) CreateExperience(
 	ctx context.Context,
-	orderIdx int8,
-	position string,
-	company string,
+	orderIdx *int,
+	languages []values.MarkdownLanguageCode,
+	positions values.MultiLangText,
+	companies values.MultiLangText,
 	location string,
 	technologies []string,
-	responsibilities []string,
-	period string,
+	responsibilities values.MultiLangList,
+	startDate values.Date,
+	endDate *values.Date,
 ) (*ExperienceEntity, error) {
-	// Validate orderIdx that don't conflict with existing experiences
-	existingExperience, err := s.repository.FindByOrderIdx(ctx, orderIdx)
-	if err != nil {
-		return nil, err
-	}
+	// Without an explicit order the experience goes after the others
+	if orderIdx == nil {
+		maxOrderIdx, err := s.repository.MaxOrderIdx(ctx)
+		if err != nil {
+			return nil, err
+		}
 
-	// If an experience with the same orderIdx exists, return conflict error
-	if existingExperience != nil {
-		return nil, failure.NewConflictFailure("Experience with the same orderIdx already exists")
+		next := maxOrderIdx + 1
+		orderIdx = &next
 	}
 
 	// If an experience with the same company exists, return conflict error
-	existingExperience, err = s.repository.FindByCompany(ctx, company)
-	if err != nil {
+	if err := s.checkCompanyConflict(ctx, "", companies); err != nil {
 		return nil, err
 	}
 
-	if existingExperience != nil {
-		return nil, failure.NewConflictFailure("Experience with the same company already exists")
-	}
-
 	newExperience, err := NewExperience(
-		orderIdx,
-		position,
-		company,
+		*orderIdx,
+		languages,
+		positions,
+		companies,
 		location,
 		technologies,
 		responsibilities,
-		period,
+		startDate,
+		endDate,
 	)
 
 	if err != nil {
@@ -94,13 +98,16 @@ func (s *experienceService) CreateExperience(
 func (s *experienceService) UpdateExperience(
 	ctx context.Context,
 	id string,
-	orderIdx int8,
-	position string,
-	company string,
+	orderIdx int,
+	languages []values.MarkdownLanguageCode,
+	positions values.MultiLangText,
+	companies values.MultiLangText,
 	location string,
 	technologies []string,
-	responsibilities []string,
-	period string,
+	responsibilities values.MultiLangList,
+	startDate values.Date,
+	endDate *values.Date,
+	expectedVersion *int64,
 ) (*ExperienceEntity, error) {
 	// 1. Retrieve existing experience
 	existingExperience, err := s.repository.FindByID(ctx, id)
@@ -112,39 +119,27 @@ func (s *experienceService) UpdateExperience(
 		return nil, failure.NewNotFoundFailure("Experience with the given ID does not exist")
 	}
 
-	// 2. Check for orderIdx conflict
-	if existingExperience.OrderIdx != orderIdx {
-		conflictExperience, err := s.repository.FindByOrderIdx(ctx, orderIdx)
-		if err != nil {
-			return nil, err
-		}
-
-		if conflictExperience != nil {
-			return nil, failure.NewConflictFailure("Experience with the same orderIdx already exists")
-		}
+	// The update must be based on the version the client last read
+	if err := values.CheckVersion(existingExperience.Version, expectedVersion); err != nil {
+		return nil, err
 	}
 
-	// 3. Check for company conflict
-	if existingExperience.Company != company {
-		conflictExperience, err := s.repository.FindByCompany(ctx, company)
-		if err != nil {
-			return nil, err
-		}
-
-		if conflictExperience != nil {
-			return nil, failure.NewConflictFailure("Experience with the same company already exists")
-		}
+	// 2. Check for company conflict with the other experiences
+	if err := s.checkCompanyConflict(ctx, existingExperience.ID.String(), companies); err != nil {
+		return nil, err
 	}
 
-	// 4. Update fields
+	// 3. Update fields
 	err = existingExperience.Update(
 		orderIdx,
-		position,
-		company,
+		languages,
+		positions,
+		companies,
 		location,
 		technologies,
 		responsibilities,
-		period,
+		startDate,
+		endDate,
 	)
 
 	if err != nil {
@@ -152,7 +147,7 @@ func (s *experienceService) UpdateExperience(
 		return nil, err
 	}
 
-	// 5. Save updated experience
+	// 4. Save updated experience
 	rowsAffected, err := s.repository.Update(ctx, existingExperience)
 	if err != nil {
 		return nil, err
@@ -165,6 +160,40 @@ func (s *experienceService) UpdateExperience(
 	return existingExperience, nil
 }
 
+func (s *experienceService) ReorderExperiences(ctx context.Context, ids []string) ([]*ExperienceEntity, error) {
+	// 1. The list must hold every experience exactly once, so none is left with a stale order
+	experiences, err := s.repository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	listed := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if listed[id] {
+			return nil, failure.NewValidationFailure(fmt.Sprintf("experience %s is listed more than once", id))
+		}
+
+		listed[id] = true
+	}
+
+	for _, experience := range experiences {
+		if !listed[experience.ID.String()] {
+			return nil, failure.NewValidationFailure(fmt.Sprintf("experience %s is missing from the order", experience.ID))
+		}
+	}
+
+	if len(ids) != len(experiences) {
+		return nil, failure.NewValidationFailure("the order lists experiences that do not exist")
+	}
+
+	// 2. Renumber them
+	if _, err := s.repository.UpdateOrder(ctx, ids); err != nil {
+		return nil, err
+	}
+
+	return s.repository.FindAll(ctx)
+}
+
 func (s *experienceService) DeleteExperience(ctx context.Context, id string) (int, error) {
 	return s.repository.Delete(ctx, id)
 }
@@ -172,3 +201,19 @@ func (s *experienceService) DeleteExperience(ctx context.Context, id string) (in
 func (s *experienceService) DeleteMultipleExperiences(ctx context.Context, ids []string) (int, error) {
 	return s.repository.DeleteMany(ctx, ids)
 }
+
+// checkCompanyConflict rejects companies another experience already uses in the same language
+func (s *experienceService) checkCompanyConflict(ctx context.Context, id string, companies values.MultiLangText) error {
+	for language, company := range companies {
+		conflictExperience, err := s.repository.FindByCompany(ctx, language, company)
+		if err != nil {
+			return err
+		}
+
+		if conflictExperience != nil && conflictExperience.ID.String() != id {
+			return failure.NewConflictFailure(fmt.Sprintf("Experience with the same %s company already exists", language))
+		}
+	}
+
+	return nil
+}
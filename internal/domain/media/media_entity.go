@@ -0,0 +1,127 @@
+package media
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/values"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AllowedTypes maps the accepted content types, as sniffed from the bytes, to file extensions
+var AllowedTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// VariantSize is a resized copy generated for each uploaded image, no wider than Width
+type VariantSize struct {
+	Name  string
+	Width int
+}
+
+// VariantSizes are the copies generated for each uploaded image
+var VariantSizes = []VariantSize{
+	{Name: "thumbnail", Width: 320},
+	{Name: "card", Width: 768},
+	{Name: "full", Width: 1600},
+}
+
+// ReferenceType is the kind of field pointing at a media file
+type ReferenceType string
+
+const (
+	ReferenceProjectCover  ReferenceType = "project_cover"
+	ReferenceAccountAvatar ReferenceType = "account_avatar"
+	ReferenceBlog          ReferenceType = "blog"
+	ReferenceProject       ReferenceType = "project" // images in the project markdown
+)
+
+// MediaEntity is an uploaded file. Files are stored once per content hash, several owners
+// uploading the same bytes get their own entity on the same blob.
+type MediaEntity struct {
+	ID          uuid.UUID            `json:"id"`
+	OwnerID     uuid.UUID            `json:"ownerId"`
+	Hash        string               `json:"hash"` // hex SHA-256 of the content
+	Key         string               `json:"key"`  // storage key, derived from the hash
+	URL         string               `json:"url"`
+	ContentType string               `json:"contentType"`
+	Size        int64                `json:"size"`
+	VariantKeys map[string]string    `json:"-"` // variant name -> storage key
+	Variants    values.ImageVariants `json:"variants,omitempty"`
+	ProcessedAt *int64               `json:"processedAt,omitempty"` // unset while variants are pending
+	CreatedAt   int64                `json:"createdAt"`
+}
+
+// IsProcessed tells whether the variants were generated, or the image found not processable
+func (m *MediaEntity) IsProcessed() bool {
+	return m.ProcessedAt != nil
+}
+
+// VariantKey is the storage key of a variant, stored next to the original
+func (m *MediaEntity) VariantKey(name, extension string) string {
+	return strings.TrimSuffix(m.Key, path.Ext(m.Key)) + "_" + name + extension
+}
+
+// NewMedia checks the content and describes it, the content type is sniffed rather than taken
+// from the client so a renamed file cannot pass as an image
+func NewMedia(ownerID uuid.UUID, content []byte, maxSize int64) (*MediaEntity, error) {
+	size := int64(len(content))
+	if size == 0 {
+		return nil, failure.NewValidationFailure("file is empty")
+	}
+
+	if size > maxSize {
+		return nil, failure.NewValidationFailure(fmt.Sprintf("file exceeds the maximum size of %d bytes", maxSize))
+	}
+
+	contentType := http.DetectContentType(content)
+	extension, ok := AllowedTypes[contentType]
+	if !ok {
+		return nil, failure.NewValidationFailure(fmt.Sprintf("file type %s is not allowed, upload a JPEG, PNG, GIF or WebP image", contentType))
+	}
+
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+
+	return &MediaEntity{
+		ID:          uuid.New(),
+		OwnerID:     ownerID,
+		Hash:        hash,
+		Key:         "media/" + hash[:2] + "/" + hash + extension,
+		ContentType: contentType,
+		Size:        size,
+		CreatedAt:   time.Now().Unix(),
+	}, nil
+}
+
+// keyPattern matches the storage keys NewMedia generates
+var keyPattern = `media/[0-9a-f]{2}/[0-9a-f]{64}\.[a-z]+`
+
+// ExtractKeys finds the media keys of the URLs under publicURL in the texts, e.g. a cover URL or
+// the images of a markdown document
+func ExtractKeys(publicURL string, texts ...string) []string {
+	pattern := regexp.MustCompile(regexp.QuoteMeta(publicURL) + "/(" + keyPattern + ")")
+
+	seen := make(map[string]bool)
+	keys := make([]string, 0)
+	for _, text := range texts {
+		for _, match := range pattern.FindAllStringSubmatch(text, -1) {
+			if !seen[match[1]] {
+				seen[match[1]] = true
+				keys = append(keys, match[1])
+			}
+		}
+	}
+
+	return keys
+}
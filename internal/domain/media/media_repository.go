@@ -0,0 +1,29 @@
+package media
+
+import (
+	"context"
+	"time"
+)
+
+type MediaRepository interface {
+	// Create keeps an existing upload of the same content by the same owner and returns it
+	Create(ctx context.Context, media *MediaEntity) (*MediaEntity, bool, error)
+	FindByID(ctx context.Context, id string) (*MediaEntity, error)
+	FindByOwnerID(ctx context.Context, ownerID string) ([]*MediaEntity, error)
+	Delete(ctx context.Context, id string) (int, error)
+	// CountByHash tells whether other entities still use the blob
+	CountByHash(ctx context.Context, hash string) (int64, error)
+	IsReferenced(ctx context.Context, id string) (bool, error)
+	// ReplaceReferences makes the media with the given keys the only ones the field points at
+	ReplaceReferences(ctx context.Context, referenceType ReferenceType, referenceID string, keys []string) error
+	// PruneReferences drops references of rows that no longer exist, soft-deleted ones are kept
+	PruneReferences(ctx context.Context) (int, error)
+	FindUnreferenced(ctx context.Context, createdBefore time.Time, limit int) ([]*MediaEntity, error)
+	// FindByKeys may return several entities per key, one per owner of the content
+	FindByKeys(ctx context.Context, keys []string) ([]*MediaEntity, error)
+	// FindProcessedByHash returns an entity of the content whose variants are already generated
+	FindProcessedByHash(ctx context.Context, hash string) (*MediaEntity, error)
+	FindUnprocessed(ctx context.Context, limit int) ([]*MediaEntity, error)
+	// SaveVariants records the variants on every entity of the content
+	SaveVariants(ctx context.Context, hash string, variantKeys map[string]string, processedAt int64) error
+}
@@ -0,0 +1,293 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/values"
+	"hinsun-backend/pkg/imaging"
+	"hinsun-backend/pkg/storage"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// gcBatchSize is the number of unreferenced files collected per query
+const gcBatchSize = 100
+
+// variantQuality is the JPEG quality of the variants, transparent ones are PNG
+const variantQuality = 82
+
+type MediaService interface {
+	// Upload stores the content, false means the owner had already uploaded the same bytes
+	Upload(ctx context.Context, ownerID uuid.UUID, content []byte) (*MediaEntity, bool, error)
+	FindMedia(ctx context.Context, id string) (*MediaEntity, error)
+	FindOwnerMedia(ctx context.Context, ownerID string) ([]*MediaEntity, error)
+	DeleteMedia(ctx context.Context, media *MediaEntity) error
+	OpenFile(ctx context.Context, key string) (io.ReadCloser, error)
+	// SyncReferences records which media the field of a resource points at, found by their URLs
+	SyncReferences(ctx context.Context, referenceType ReferenceType, referenceID string, texts ...string) error
+	// CollectGarbage deletes the files nothing points at, uploads younger than gracePeriod are
+	// kept since they are usually about to be saved in a resource
+	CollectGarbage(ctx context.Context, gracePeriod time.Duration) (int, error)
+	// ProcessVariants generates the resized copies of an image for every entity of its content.
+	// Files that cannot be decoded are marked processed without variants so they are not retried.
+	ProcessVariants(ctx context.Context, media *MediaEntity) error
+	FindPendingVariants(ctx context.Context, limit int) ([]*MediaEntity, error)
+	// FindVariants returns the variant URLs of the media the texts point at, keyed by media URL.
+	// Media without variants, and URLs outside the storage, are left out.
+	FindVariants(ctx context.Context, texts ...string) (map[string]values.ImageVariants, error)
+}
+
+type mediaService struct {
+	repository    MediaRepository
+	storage       storage.BlobStorage
+	maxUploadSize int64
+}
+
+func NewMediaService(repository MediaRepository, storage storage.BlobStorage, maxUploadSize int64) MediaService {
+	return &mediaService{
+		repository:    repository,
+		storage:       storage,
+		maxUploadSize: maxUploadSize,
+	}
+}
+
+func (s *mediaService) Upload(ctx context.Context, ownerID uuid.UUID, content []byte) (*MediaEntity, bool, error) {
+	// 1. Validate and describe the content
+	media, err := NewMedia(ownerID, content, s.maxUploadSize)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// 2. Store the blob unless the same content is already there
+	stored, err := s.repository.CountByHash(ctx, media.Hash)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if stored == 0 {
+		if err := s.storage.Put(ctx, media.Key, bytes.NewReader(content), media.Size, media.ContentType); err != nil {
+			return nil, false, failure.NewInternalFailure("failed to store file", err)
+		}
+	} else {
+		// The content may already have its variants, which are shared like the blob
+		processed, err := s.repository.FindProcessedByHash(ctx, media.Hash)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if processed != nil {
+			media.VariantKeys = processed.VariantKeys
+			media.ProcessedAt = processed.ProcessedAt
+		}
+	}
+
+	// 3. Track it
+	saved, created, err := s.repository.Create(ctx, media)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return s.withURL(saved), created, nil
+}
+
+func (s *mediaService) FindMedia(ctx context.Context, id string) (*MediaEntity, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, failure.NewValidationFailure("invalid media ID")
+	}
+
+	media, err := s.repository.FindByID(ctx, id)
+	if err != nil || media == nil {
+		return nil, err
+	}
+
+	return s.withURL(media), nil
+}
+
+func (s *mediaService) FindOwnerMedia(ctx context.Context, ownerID string) ([]*MediaEntity, error) {
+	medias, err := s.repository.FindByOwnerID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, media := range medias {
+		s.withURL(media)
+	}
+
+	return medias, nil
+}
+
+// DeleteMedia removes a file nothing points at, the blob goes once no entity uses it
+func (s *mediaService) DeleteMedia(ctx context.Context, media *MediaEntity) error {
+	referenced, err := s.repository.IsReferenced(ctx, media.ID.String())
+	if err != nil {
+		return err
+	}
+
+	if referenced {
+		return failure.NewConflictFailure("The file is still used, remove it from where it is referenced first")
+	}
+
+	return s.remove(ctx, media)
+}
+
+func (s *mediaService) OpenFile(ctx context.Context, key string) (io.ReadCloser, error) {
+	file, err := s.storage.Get(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, failure.NewNotFoundFailure("File not found")
+	}
+
+	if err != nil {
+		return nil, failure.NewInternalFailure("failed to open file", err)
+	}
+
+	return file, nil
+}
+
+func (s *mediaService) SyncReferences(ctx context.Context, referenceType ReferenceType, referenceID string, texts ...string) error {
+	publicURL := strings.TrimSuffix(s.storage.URL(""), "/")
+	return s.repository.ReplaceReferences(ctx, referenceType, referenceID, ExtractKeys(publicURL, texts...))
+}
+
+func (s *mediaService) CollectGarbage(ctx context.Context, gracePeriod time.Duration) (int, error) {
+	// 1. Forget references of resources deleted for good
+	if _, err := s.repository.PruneReferences(ctx); err != nil {
+		return 0, err
+	}
+
+	// 2. Delete what is left unreferenced, batch by batch
+	collected := 0
+	createdBefore := time.Now().Add(-gracePeriod)
+	for {
+		medias, err := s.repository.FindUnreferenced(ctx, createdBefore, gcBatchSize)
+		if err != nil {
+			return collected, err
+		}
+
+		for _, media := range medias {
+			if err := s.remove(ctx, media); err != nil {
+				return collected, err
+			}
+
+			collected++
+		}
+
+		if len(medias) < gcBatchSize {
+			return collected, nil
+		}
+	}
+}
+
+func (s *mediaService) ProcessVariants(ctx context.Context, media *MediaEntity) error {
+	// 1. Read the original, which may have been deleted since the upload
+	file, err := s.storage.Get(ctx, media.Key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return failure.NewInternalFailure("failed to open file", err)
+	}
+
+	content, err := io.ReadAll(file)
+	file.Close()
+	if err != nil {
+		return failure.NewInternalFailure("failed to read file", err)
+	}
+
+	// 2. Re-encode each size, sizes wider than the image share the copy of the previous one
+	variantKeys := make(map[string]string)
+	if img, err := imaging.Decode(content); err == nil {
+		previousKey, previousWidth := "", 0
+		for _, size := range VariantSizes {
+			variant := imaging.Fit(img, size.Width)
+			if variant.Bounds().Dx() == previousWidth {
+				variantKeys[size.Name] = previousKey
+				continue
+			}
+
+			var buffer bytes.Buffer
+			format, err := imaging.Encode(&buffer, variant, variantQuality)
+			if err != nil {
+				return failure.NewInternalFailure("failed to encode image variant", err)
+			}
+
+			key := media.VariantKey(size.Name, format.Extension)
+			if err := s.storage.Put(ctx, key, &buffer, int64(buffer.Len()), format.ContentType); err != nil {
+				return failure.NewInternalFailure("failed to store image variant", err)
+			}
+
+			variantKeys[size.Name] = key
+			previousKey, previousWidth = key, variant.Bounds().Dx()
+		}
+	}
+
+	// 3. Record them on every entity of the content
+	return s.repository.SaveVariants(ctx, media.Hash, variantKeys, time.Now().Unix())
+}
+
+func (s *mediaService) FindPendingVariants(ctx context.Context, limit int) ([]*MediaEntity, error) {
+	return s.repository.FindUnprocessed(ctx, limit)
+}
+
+func (s *mediaService) FindVariants(ctx context.Context, texts ...string) (map[string]values.ImageVariants, error) {
+	publicURL := strings.TrimSuffix(s.storage.URL(""), "/")
+	medias, err := s.repository.FindByKeys(ctx, ExtractKeys(publicURL, texts...))
+	if err != nil {
+		return nil, err
+	}
+
+	variants := make(map[string]values.ImageVariants)
+	for _, media := range medias {
+		s.withURL(media)
+		if len(media.Variants) > 0 {
+			variants[media.URL] = media.Variants
+		}
+	}
+
+	return variants, nil
+}
+
+func (s *mediaService) remove(ctx context.Context, media *MediaEntity) error {
+	if _, err := s.repository.Delete(ctx, media.ID.String()); err != nil {
+		return err
+	}
+
+	remaining, err := s.repository.CountByHash(ctx, media.Hash)
+	if err != nil {
+		return err
+	}
+
+	if remaining > 0 {
+		return nil
+	}
+
+	if err := s.storage.Delete(ctx, media.Key); err != nil {
+		return failure.NewInternalFailure("failed to delete file", err)
+	}
+
+	// Sizes wider than the image share a key, deleting it twice is harmless
+	for _, key := range media.VariantKeys {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			return failure.NewInternalFailure("failed to delete image variant", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *mediaService) withURL(media *MediaEntity) *MediaEntity {
+	media.URL = s.storage.URL(media.Key)
+	if len(media.VariantKeys) > 0 {
+		media.Variants = make(values.ImageVariants, len(media.VariantKeys))
+		for name, key := range media.VariantKeys {
+			media.Variants[name] = s.storage.URL(key)
+		}
+	}
+
+	return media
+}
@@ -0,0 +1,32 @@
+package notification
+
+import "context"
+
+// Recipient is the account a message is delivered to, email subscribers without an account only
+// have an Email
+type Recipient struct {
+	AccountID string
+	Name      string
+	Email     string
+}
+
+// Message is a rendered notification addressed to one account
+type Message struct {
+	Recipient  Recipient
+	Preference *PreferenceEntity
+	Type       EventType
+	Title      string
+	Body       string
+	Data       map[string]string
+}
+
+// NotificationChannel delivers messages over one medium (in-app, push, email, webhook)
+type NotificationChannel interface {
+	Channel() Channel
+	Deliver(ctx context.Context, message *Message) error
+}
+
+// TopicChannel broadcasts a message to every device subscribed to a push topic
+type TopicChannel interface {
+	Broadcast(ctx context.Context, topic string, message *Message) error
+}
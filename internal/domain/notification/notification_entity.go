@@ -1,37 +1,53 @@
 package notification
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type NotificationEntity struct {
-	ID        uuid.UUID
-	AccountID string
-	Title     string
-	Content   string
-	IsRead    bool
-	CreatedAt int64
-	UpdatedAt int64
-	DeletedAt *int64
+	ID        uuid.UUID         `json:"id"`
+	AccountID string            `json:"accountId"`
+	Type      EventType         `json:"type"`
+	Title     string            `json:"title"`
+	Content   string            `json:"content"`
+	Data      map[string]string `json:"data,omitempty"` // IDs the client needs to open the target, e.g. blogId
+	IsRead    bool              `json:"isRead"`
+	CreatedAt int64             `json:"createdAt"`
+	UpdatedAt int64             `json:"updatedAt"`
 }
 
 func NewNotification(
 	AccountID string,
+	Type EventType,
 	Title string,
 	Content string,
+	Data map[string]string,
 ) (*NotificationEntity, error) {
 	now := time.Now()
 
 	return &NotificationEntity{
 		ID:        uuid.New(),
 		AccountID: AccountID,
-		Title:     Title,
-		Content:   Content,
+		Type:      Type,
+		Title:     strings.TrimSpace(Title),
+		Content:   strings.TrimSpace(Content),
+		Data:      Data,
 		IsRead:    false,
 		CreatedAt: now.Unix(),
 		UpdatedAt: now.Unix(),
-		DeletedAt: nil,
 	}, nil
 }
+
+// MarkRead reports whether the notification was unread
+func (n *NotificationEntity) MarkRead() bool {
+	if n.IsRead {
+		return false
+	}
+
+	n.IsRead = true
+	n.UpdatedAt = time.Now().Unix()
+	return true
+}
@@ -1,6 +1,9 @@
 package notification
 
-import "hinsun-backend/internal/core/events"
+import (
+	"hinsun-backend/internal/core/events"
+	"hinsun-backend/internal/domain/values"
+)
 
 // Event names constants
 const (
@@ -12,8 +15,14 @@ type NotificationCreatedEvent struct {
 	events.BaseEvent
 }
 
+// NotificationCreatedPayload is published once an in-app notification is stored
 type NotificationCreatedPayload struct {
-	NotificationID string `json:"notification_id"`
+	NotificationID string            `json:"notification_id"`
+	AccountID      string            `json:"account_id"`
+	Type           EventType         `json:"type"`
+	Title          string            `json:"title"`
+	Content        string            `json:"content"`
+	Data           map[string]string `json:"data,omitempty"`
 }
 
 func NewNotificationCreatedEvent(payload NotificationCreatedPayload) *NotificationCreatedEvent {
@@ -31,11 +40,20 @@ type BlogPublishedEvent struct {
 }
 
 type BlogPublishedPayload struct {
-	BlogID        string   `json:"blog_id"`
-	BlogTitle     string   `json:"blog_title"`
-	AuthorID      string   `json:"author_id"`
-	AuthorName    string   `json:"author_name"`
-	SubscriberIDs []string `json:"subscriber_ids"`
+	BlogID           string               `json:"blog_id"`
+	BlogTitle        string               `json:"blog_title"`
+	BlogNames        values.MultiLangText `json:"blog_names"` // localized titles, BlogTitle is the fallback
+	AuthorID         string               `json:"author_id"`
+	AuthorName       string               `json:"author_name"`
+	SubscriberIDs    []string             `json:"subscriber_ids"`    // accounts following the author or a category
+	SubscriberEmails []EmailSubscriber    `json:"subscriber_emails"` // confirmed anonymous subscribers
+	Topics           []string             `json:"topics"`            // push topics of the categories
+}
+
+// EmailSubscriber is an address subscribed without an account, its token unsubscribes it
+type EmailSubscriber struct {
+	Email            string `json:"email"`
+	UnsubscribeToken string `json:"-"`
 }
 
 // NewBlogPublishedEvent creates a new blog published event
@@ -0,0 +1,193 @@
+package notification
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/values"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	quietHoursLayout   = "15:04"
+	webhookSecretBytes = 32
+	MaxWebhookURLength = 2048
+)
+
+// QuietHours is a daily window, in the account's time zone, during which only in-app
+// notifications are delivered. Start after End wraps past midnight (e.g. 22:00 to 07:00).
+type QuietHours struct {
+	Start    string `json:"start" example:"22:00"`
+	End      string `json:"end" example:"07:00"`
+	TimeZone string `json:"timeZone" example:"Asia/Ho_Chi_Minh"`
+}
+
+// Validate checks the window and rewrites Start and End zero-padded, e.g. 7:00 to 07:00
+func (q *QuietHours) Validate() error {
+	start, err := time.Parse(quietHoursLayout, q.Start)
+	if err != nil {
+		return failure.NewValidationFailure("quiet hours start must be in HH:MM format")
+	}
+
+	end, err := time.Parse(quietHoursLayout, q.End)
+	if err != nil {
+		return failure.NewValidationFailure("quiet hours end must be in HH:MM format")
+	}
+
+	if start.Equal(end) {
+		return failure.NewValidationFailure("quiet hours start and end must differ")
+	}
+
+	if _, err := time.LoadLocation(q.TimeZone); err != nil {
+		return failure.NewValidationFailure("unknown quiet hours time zone: " + q.TimeZone)
+	}
+
+	q.Start = start.Format(quietHoursLayout)
+	q.End = end.Format(quietHoursLayout)
+	return nil
+}
+
+// Contains reports whether the given instant falls inside the window
+func (q *QuietHours) Contains(at time.Time) bool {
+	location, err := time.LoadLocation(q.TimeZone)
+	if err != nil {
+		return false
+	}
+
+	// Compared in minutes since midnight, windows saved before Validate padded the hours may
+	// still read 7:00
+	start, err := time.Parse(quietHoursLayout, q.Start)
+	if err != nil {
+		return false
+	}
+
+	end, err := time.Parse(quietHoursLayout, q.End)
+	if err != nil {
+		return false
+	}
+
+	local := at.In(location)
+	clock := local.Hour()*60 + local.Minute()
+	from, until := start.Hour()*60+start.Minute(), end.Hour()*60+end.Minute()
+	if from < until {
+		return clock >= from && clock < until
+	}
+
+	return clock >= from || clock < until
+}
+
+type PreferenceEntity struct {
+	AccountID     uuid.UUID                   `json:"accountId"`
+	Language      values.MarkdownLanguageCode `json:"language"`
+	Channels      map[EventType][]Channel     `json:"channels"`
+	QuietHours    *QuietHours                 `json:"quietHours"`
+	WebhookURL    string                      `json:"webhookUrl"`
+	WebhookSecret string                      `json:"webhookSecret,omitempty"` // signs webhook bodies, see X-Hinsun-Signature
+	UpdatedAt     int64                       `json:"updatedAt"`
+}
+
+// DefaultPreference applies to accounts that never saved their preferences
+func DefaultPreference(accountID uuid.UUID) *PreferenceEntity {
+	channels := make(map[EventType][]Channel, len(AllEventTypes))
+	for _, eventType := range AllEventTypes {
+		channels[eventType] = []Channel{ChannelInApp, ChannelPush}
+	}
+
+	return &PreferenceEntity{
+		AccountID: accountID,
+		Language:  values.English,
+		Channels:  channels,
+	}
+}
+
+// Allows reports whether the account wants events of the given type on the channel
+func (p *PreferenceEntity) Allows(eventType EventType, channel Channel) bool {
+	return slices.Contains(p.Channels[eventType], channel)
+}
+
+// IsQuietAt reports whether interrupting channels are held back at the given instant
+func (p *PreferenceEntity) IsQuietAt(at time.Time) bool {
+	return p.QuietHours != nil && p.QuietHours.Contains(at)
+}
+
+// Update replaces the preferences; a new webhook URL gets a new signing secret
+func (p *PreferenceEntity) Update(
+	language values.MarkdownLanguageCode,
+	channels map[EventType][]Channel,
+	quietHours *QuietHours,
+	webhookURL string,
+) error {
+	if quietHours != nil {
+		if err := quietHours.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := validateWebhookURL(webhookURL); err != nil {
+		return err
+	}
+
+	normalized := make(map[EventType][]Channel, len(channels))
+	for eventType, eventChannels := range channels {
+		deduplicated := make([]Channel, 0, len(eventChannels))
+		for _, channel := range eventChannels {
+			if !slices.Contains(deduplicated, channel) {
+				deduplicated = append(deduplicated, channel)
+			}
+		}
+
+		if slices.Contains(deduplicated, ChannelWebhook) && webhookURL == "" {
+			return failure.NewValidationFailure("a webhook URL is required to receive notifications by webhook")
+		}
+
+		normalized[eventType] = deduplicated
+	}
+
+	if webhookURL == "" {
+		p.WebhookSecret = ""
+	} else if webhookURL != p.WebhookURL || p.WebhookSecret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return failure.NewInternalFailure("failed to generate webhook secret", err)
+		}
+
+		p.WebhookSecret = secret
+	}
+
+	p.Language = language
+	p.Channels = normalized
+	p.QuietHours = quietHours
+	p.WebhookURL = webhookURL
+	p.UpdatedAt = time.Now().Unix()
+	return nil
+}
+
+func validateWebhookURL(webhookURL string) error {
+	if webhookURL == "" {
+		return nil
+	}
+
+	if len(webhookURL) > MaxWebhookURLength {
+		return failure.NewValidationFailure("webhook URL is too long")
+	}
+
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return failure.NewValidationFailure("webhook URL must be an absolute https URL")
+	}
+
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,90 @@
+package notification
+
+import (
+	"hinsun-backend/internal/core/failure"
+	"testing"
+	"time"
+)
+
+func TestQuietHoursValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     string
+		end       string
+		wantStart string
+		wantEnd   string
+		wantErr   bool
+	}{
+		{name: "padded", start: "22:00", end: "07:00", wantStart: "22:00", wantEnd: "07:00"},
+		{name: "unpadded hours", start: "9:30", end: "7:05", wantStart: "09:30", wantEnd: "07:05"},
+		{name: "same time written differently", start: "07:00", end: "7:00", wantErr: true},
+		{name: "not a time", start: "late", end: "07:00", wantErr: true},
+		{name: "out of range", start: "24:00", end: "07:00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quietHours := &QuietHours{Start: tt.start, End: tt.end, TimeZone: "UTC"}
+			err := quietHours.Validate()
+			if tt.wantErr {
+				if !failure.Is(err, failure.ValidationFailure) {
+					t.Errorf("error = %v, want a validation failure", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("validate: %v", err)
+			}
+
+			if quietHours.Start != tt.wantStart || quietHours.End != tt.wantEnd {
+				t.Errorf("window = %s-%s, want %s-%s", quietHours.Start, quietHours.End, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestQuietHoursContains(t *testing.T) {
+	tests := []struct {
+		name  string
+		start string
+		end   string
+		quiet map[string]bool
+	}{
+		{
+			name: "overnight", start: "22:00", end: "07:00",
+			quiet: map[string]bool{"21:59": false, "22:00": true, "23:30": true, "00:00": true, "06:59": true, "07:00": false, "10:00": false, "15:00": false},
+		},
+		{
+			// Saved before Validate padded the hours, "10:00" sorts before "7:00" as a string
+			name: "overnight with an unpadded end", start: "22:00", end: "7:00",
+			quiet: map[string]bool{"23:00": true, "06:30": true, "07:00": false, "08:00": false, "10:00": false, "21:59": false},
+		},
+		{
+			name: "daytime", start: "9:00", end: "17:30",
+			quiet: map[string]bool{"08:59": false, "09:00": true, "12:00": true, "17:29": true, "17:30": false, "23:00": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quietHours := &QuietHours{Start: tt.start, End: tt.end, TimeZone: "Asia/Ho_Chi_Minh"}
+			location, err := time.LoadLocation(quietHours.TimeZone)
+			if err != nil {
+				t.Skipf("time zone database unavailable: %v", err)
+			}
+
+			for clock, want := range tt.quiet {
+				at, err := time.ParseInLocation("2006-01-02 15:04", "2026-03-10 "+clock, location)
+				if err != nil {
+					t.Fatalf("parse %s: %v", clock, err)
+				}
+
+				// The instant is given in UTC, the window applies in the account's zone
+				if got := quietHours.Contains(at.UTC()); got != want {
+					t.Errorf("%s: quiet = %v, want %v", clock, got, want)
+				}
+			}
+		})
+	}
+}
@@ -6,4 +6,12 @@ import (
 
 type NotificationRepository interface {
 	Create(ctx context.Context, notification *NotificationEntity) error
+	FindByID(ctx context.Context, id string) (*NotificationEntity, error)
+	FindByAccountID(ctx context.Context, accountID string, limit int) ([]*NotificationEntity, error)
+	MarkRead(ctx context.Context, notification *NotificationEntity) (int, error)
+}
+
+type PreferenceRepository interface {
+	FindByAccountID(ctx context.Context, accountID string) (*PreferenceEntity, error)
+	Save(ctx context.Context, preference *PreferenceEntity) error
 }
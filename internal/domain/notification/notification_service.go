@@ -1,24 +1,39 @@
 package notification
 
-import "context"
+import (
+	"context"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/values"
+
+	"github.com/google/uuid"
+)
+
+// MaxListedNotifications bounds the in-app inbox returned to clients
+const MaxListedNotifications = 100
 
 type NotificationService interface {
-	CreateNotification(ctx context.Context, accountID, title, content string) (*NotificationEntity, error)
+	CreateNotification(ctx context.Context, accountID string, eventType EventType, title, content string, data map[string]string) (*NotificationEntity, error)
+	FindAccountNotifications(ctx context.Context, accountID string) ([]*NotificationEntity, error)
+	MarkNotificationRead(ctx context.Context, accountID, id string) (*NotificationEntity, error)
+	FindPreference(ctx context.Context, accountID uuid.UUID) (*PreferenceEntity, error)
+	UpdatePreference(ctx context.Context, accountID uuid.UUID, language values.MarkdownLanguageCode, channels map[EventType][]Channel, quietHours *QuietHours, webhookURL string) (*PreferenceEntity, error)
 }
 
 type notificationService struct {
-	repository NotificationRepository
+	repository           NotificationRepository
+	preferenceRepository PreferenceRepository
 }
 
 // NewNotificationService creates a new notification service
-func NewNotificationService(repository NotificationRepository) NotificationService {
+func NewNotificationService(repository NotificationRepository, preferenceRepository PreferenceRepository) NotificationService {
 	return &notificationService{
-		repository: repository,
+		repository:           repository,
+		preferenceRepository: preferenceRepository,
 	}
 }
 
-func (s *notificationService) CreateNotification(ctx context.Context, accountID, title, content string) (*NotificationEntity, error) {
-	notification, err := NewNotification(accountID, title, content)
+func (s *notificationService) CreateNotification(ctx context.Context, accountID string, eventType EventType, title, content string, data map[string]string) (*NotificationEntity, error) {
+	notification, err := NewNotification(accountID, eventType, title, content, data)
 	if err != nil {
 		// return validation error
 		return nil, err
@@ -31,3 +46,73 @@ func (s *notificationService) CreateNotification(ctx context.Context, accountID,
 
 	return notification, nil
 }
+
+func (s *notificationService) FindAccountNotifications(ctx context.Context, accountID string) ([]*NotificationEntity, error) {
+	return s.repository.FindByAccountID(ctx, accountID, MaxListedNotifications)
+}
+
+// MarkNotificationRead marks a notification of the given account as read
+func (s *notificationService) MarkNotificationRead(ctx context.Context, accountID, id string) (*NotificationEntity, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, failure.NewValidationFailure("invalid notification ID")
+	}
+
+	notification, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Notifications of other accounts are reported as missing to avoid leaking their existence
+	if notification == nil || notification.AccountID != accountID {
+		return nil, failure.NewNotFoundFailure("Notification with the given ID does not exist")
+	}
+
+	if notification.MarkRead() {
+		if _, err := s.repository.MarkRead(ctx, notification); err != nil {
+			return nil, err
+		}
+	}
+
+	return notification, nil
+}
+
+// FindPreference returns the saved preferences of the account, or the defaults
+func (s *notificationService) FindPreference(ctx context.Context, accountID uuid.UUID) (*PreferenceEntity, error) {
+	preference, err := s.preferenceRepository.FindByAccountID(ctx, accountID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if preference == nil {
+		return DefaultPreference(accountID), nil
+	}
+
+	return preference, nil
+}
+
+func (s *notificationService) UpdatePreference(
+	ctx context.Context,
+	accountID uuid.UUID,
+	language values.MarkdownLanguageCode,
+	channels map[EventType][]Channel,
+	quietHours *QuietHours,
+	webhookURL string,
+) (*PreferenceEntity, error) {
+	// 1. Load current preferences, the webhook secret survives updates that keep the URL
+	preference, err := s.FindPreference(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Apply and validate changes
+	if err := preference.Update(language, channels, quietHours, webhookURL); err != nil {
+		return nil, err
+	}
+
+	// 3. Save to repository
+	if err := s.preferenceRepository.Save(ctx, preference); err != nil {
+		return nil, err
+	}
+
+	return preference, nil
+}
@@ -0,0 +1,106 @@
+package notification
+
+import (
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/values"
+	"strings"
+	"text/template"
+)
+
+// TemplateData is what templates can refer to, fields that do not apply to an event stay empty
+type TemplateData struct {
+	ActorName string // who published or commented
+	BlogTitle string // in the recipient's language when the blog has it
+	Excerpt   string // start of the comment
+}
+
+type messageTemplate struct {
+	title *template.Template
+	body  *template.Template
+}
+
+// fallbackLanguage is used for languages without their own templates
+const fallbackLanguage = values.English
+
+var templates = map[EventType]map[values.MarkdownLanguageCode]messageTemplate{
+	EventTypeNewBlog: {
+		values.English: newMessageTemplate(
+			"New post: {{.BlogTitle}}",
+			"{{.ActorName}} published {{.BlogTitle}}.",
+		),
+		values.Vietnamese: newMessageTemplate(
+			"Bài viết mới: {{.BlogTitle}}",
+			"{{.ActorName}} vừa đăng bài {{.BlogTitle}}.",
+		),
+	},
+	EventTypeCommentReply: {
+		values.English: newMessageTemplate(
+			"{{.ActorName}} replied to your comment",
+			"{{.ActorName}} replied on {{.BlogTitle}}: {{.Excerpt}}",
+		),
+		values.Vietnamese: newMessageTemplate(
+			"{{.ActorName}} đã trả lời bình luận của bạn",
+			"{{.ActorName}} đã trả lời trong {{.BlogTitle}}: {{.Excerpt}}",
+		),
+	},
+	EventTypeMention: {
+		values.English: newMessageTemplate(
+			"{{.ActorName}} mentioned you",
+			"{{.ActorName}} mentioned you on {{.BlogTitle}}: {{.Excerpt}}",
+		),
+		values.Vietnamese: newMessageTemplate(
+			"{{.ActorName}} đã nhắc đến bạn",
+			"{{.ActorName}} đã nhắc đến bạn trong {{.BlogTitle}}: {{.Excerpt}}",
+		),
+	},
+}
+
+func newMessageTemplate(title, body string) messageTemplate {
+	return messageTemplate{
+		title: template.Must(template.New("title").Option("missingkey=error").Parse(title)),
+		body:  template.Must(template.New("body").Option("missingkey=error").Parse(body)),
+	}
+}
+
+// Render returns the localized title and body of an event, falling back to English
+func Render(eventType EventType, language values.MarkdownLanguageCode, data TemplateData) (string, string, error) {
+	byLanguage, ok := templates[eventType]
+	if !ok {
+		return "", "", failure.NewInternalFailure("no template for notification type "+string(eventType), nil)
+	}
+
+	messageTemplate, ok := byLanguage[language]
+	if !ok {
+		messageTemplate = byLanguage[fallbackLanguage]
+	}
+
+	var title, body strings.Builder
+	if err := messageTemplate.title.Execute(&title, data); err != nil {
+		return "", "", failure.NewInternalFailure("failed to render notification title", err)
+	}
+
+	if err := messageTemplate.body.Execute(&body, data); err != nil {
+		return "", "", failure.NewInternalFailure("failed to render notification body", err)
+	}
+
+	return title.String(), body.String(), nil
+}
+
+// LocalizedText picks the text in the given language, then in English, then any
+func LocalizedText(text values.MultiLangText, language values.MarkdownLanguageCode) string {
+	if value, ok := text[language]; ok && value != "" {
+		return value
+	}
+
+	if value, ok := text[fallbackLanguage]; ok && value != "" {
+		return value
+	}
+
+	for _, candidate := range values.SupportedMarkdownLanguages {
+		if value := text[candidate]; value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
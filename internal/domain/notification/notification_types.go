@@ -0,0 +1,61 @@
+package notification
+
+import "hinsun-backend/internal/core/failure"
+
+// EventType is what happened, preferences and templates are keyed by it
+type EventType string
+
+const (
+	EventTypeNewBlog      EventType = "new_blog"
+	EventTypeCommentReply EventType = "comment_reply"
+	EventTypeMention      EventType = "mention"
+)
+
+var AllEventTypes = []EventType{
+	EventTypeNewBlog,
+	EventTypeCommentReply,
+	EventTypeMention,
+}
+
+func EventTypeFromString(value string) (EventType, error) {
+	for _, eventType := range AllEventTypes {
+		if string(eventType) == value {
+			return eventType, nil
+		}
+	}
+
+	return "", failure.NewValidationFailure("Unsupported notification type: " + value)
+}
+
+// Channel is a way of reaching an account
+type Channel string
+
+const (
+	ChannelInApp   Channel = "in_app"
+	ChannelPush    Channel = "push"
+	ChannelEmail   Channel = "email"
+	ChannelWebhook Channel = "webhook"
+)
+
+var AllChannels = []Channel{
+	ChannelInApp,
+	ChannelPush,
+	ChannelEmail,
+	ChannelWebhook,
+}
+
+func ChannelFromString(value string) (Channel, error) {
+	for _, channel := range AllChannels {
+		if string(channel) == value {
+			return channel, nil
+		}
+	}
+
+	return "", failure.NewValidationFailure("Unsupported notification channel: " + value)
+}
+
+// Interrupts reports whether the channel reaches the user outside of the app, those channels are
+// held back during quiet hours
+func (c Channel) Interrupts() bool {
+	return c != ChannelInApp
+}
@@ -3,6 +3,9 @@ package project
 import (
 	"fmt"
 	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/values"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,32 +18,79 @@ const (
 )
 
 type ProjectEntity struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Cover       string    `json:"cover"`
-	Github      string    `json:"github"`
-	Tags        []string  `json:"tags"`
-	Markdown    string    `json:"markdown"`
-	CreatedAt   int64     `json:"createdAt"`
-	UpdatedAt   int64     `json:"updatedAt"`
-	DeletedAt   *int64    `json:"deletedAt,omitempty"`
+	ID            uuid.UUID                     `json:"id"`
+	Languages     []values.MarkdownLanguageCode `json:"languages"`    // Array of supported language codes
+	Names         values.MultiLangText          `json:"names"`        // Map of language code -> name
+	Descriptions  values.MultiLangText          `json:"descriptions"` // Map of language code -> description
+	Cover         string                        `json:"cover"`
+	CoverVariants values.ImageVariants          `json:"coverVariants,omitempty"` // resolved on read, not persisted
+	Github        string                        `json:"github"`
+	Tags          []string                      `json:"tags"`
+	Markdowns     values.MultiLangText          `json:"markdowns"`            // Map of language code -> markdown content
+	Repository    *RepositoryMetadata           `json:"repository,omitempty"` // synced from the github URL, nil until the first sync
+	Featured      bool                          `json:"featured"`
+	FeaturedOrder int                           `json:"featuredOrder"` // position among the featured projects, 0 first
+	CreatedAt     int64                         `json:"createdAt"`
+	UpdatedAt     int64                         `json:"updatedAt"`
+	Version       int64                         `json:"version"`
+	DeletedAt     *int64                        `json:"deletedAt,omitempty"`
 }
 
-func NewProjectEntity(name, description, github, cover string, tags []string, markdown string) *ProjectEntity {
+func NewProjectEntity(
+	languages []values.MarkdownLanguageCode,
+	names, descriptions values.MultiLangText,
+	github, cover string,
+	tags []string,
+	markdowns values.MultiLangText,
+) *ProjectEntity {
 	now := time.Now()
 	return &ProjectEntity{
-		ID:          uuid.New(),
-		Name:        name,
-		Description: description,
-		Cover:       cover,
-		Github:      github,
-		Tags:        tags,
-		Markdown:    markdown,
-		CreatedAt:   now.Unix(),
-		UpdatedAt:   now.Unix(),
-		DeletedAt:   nil,
+		ID:           uuid.New(),
+		Languages:    languages,
+		Names:        names,
+		Descriptions: descriptions,
+		Cover:        cover,
+		Github:       github,
+		Tags:         tags,
+		Markdowns:    markdowns,
+		CreatedAt:    now.Unix(),
+		UpdatedAt:    now.Unix(),
+		Version:      1,
+		DeletedAt:    nil,
+	}
+}
+
+// ValidateProjectContent checks that every language has its name, description and markdown
+func ValidateProjectContent(languages []values.MarkdownLanguageCode, names, descriptions, markdowns values.MultiLangText) error {
+	if err := values.ValidateLanguages(languages); err != nil {
+		return err
+	}
+
+	if err := values.ValidateMultiLangContent(languages, names, "name"); err != nil {
+		return err
+	}
+
+	if err := values.ValidateMultiLangContent(languages, descriptions, "description"); err != nil {
+		return err
+	}
+
+	if err := values.ValidateMultiLangContent(languages, markdowns, "markdown"); err != nil {
+		return err
+	}
+
+	for lang, text := range names {
+		if err := ValidateProjectName(text); err != nil {
+			return failure.NewValidationFailure(fmt.Sprintf("name for language '%s': %s", lang, err.Error()))
+		}
+	}
+
+	for lang, text := range descriptions {
+		if err := ValidateProjectDescription(text); err != nil {
+			return failure.NewValidationFailure(fmt.Sprintf("description for language '%s': %s", lang, err.Error()))
+		}
 	}
+
+	return nil
 }
 
 func ValidateProjectName(name string) error {
@@ -80,29 +130,60 @@ func ValidateProjectTags(tags []string) error {
 }
 
 func (p *ProjectEntity) Update(
-	name, description, github, cover string,
+	languages []values.MarkdownLanguageCode,
+	names, descriptions values.MultiLangText,
+	github, cover string,
 	tags []string,
-	markdown string,
+	markdowns values.MultiLangText,
 ) error {
-	if err := ValidateProjectName(name); err != nil {
+	if err := ValidateProjectContent(languages, names, descriptions, markdowns); err != nil {
 		return err
 	}
 
-	if err := ValidateProjectDescription(description); err != nil {
+	if err := ValidateProjectTags(tags); err != nil {
 		return err
 	}
 
-	if err := ValidateProjectTags(tags); err != nil {
-		return err
+	// The metadata of another repository is dropped until the next sync
+	if p.Github != github {
+		p.Repository = nil
 	}
 
-	p.Name = name
-	p.Description = description
+	p.Languages = languages
+	p.Names = names
+	p.Descriptions = descriptions
 	p.Cover = cover
 	p.Github = github
 	p.Tags = tags
-	p.Markdown = markdown
+	p.Markdowns = markdowns
 	p.UpdatedAt = time.Now().Unix()
 
 	return nil
 }
+
+// ImportReadme replaces the markdown of a language of the project with the README of its repository
+func (p *ProjectEntity) ImportReadme(language values.MarkdownLanguageCode, readme string) error {
+	if !slices.Contains(p.Languages, language) {
+		return failure.NewValidationFailure(fmt.Sprintf("project has no '%s' language to import the README into", language))
+	}
+
+	if strings.TrimSpace(readme) == "" {
+		return failure.NewValidationFailure("repository README is empty")
+	}
+
+	p.Markdowns[language] = readme
+	p.UpdatedAt = time.Now().Unix()
+	return nil
+}
+
+// Localized returns a copy of the project holding only the text of the negotiated language
+func (p *ProjectEntity) Localized(preferred []values.MarkdownLanguageCode) (*ProjectEntity, values.MarkdownLanguageCode) {
+	language := values.NegotiateLanguage(preferred, p.Languages)
+
+	localized := *p
+	localized.Languages = []values.MarkdownLanguageCode{language}
+	localized.Names = p.Names.Only(language)
+	localized.Descriptions = p.Descriptions.Only(language)
+	localized.Markdowns = p.Markdowns.Only(language)
+	return &localized, language
+}
@@ -2,8 +2,18 @@ package project
 
 import (
 	"context"
+	"hinsun-backend/internal/domain/values"
 )
 
+// ProjectFilter selects a page of the projects, featured ones first in their manual order,
+// then the newest. A zero limit reads every matching project.
+type ProjectFilter struct {
+	Tag          string // only the projects with this tag, none for all
+	FeaturedOnly bool
+	Offset       int
+	Limit        int
+}
+
 type ProjectRepository interface {
 	Create(ctx context.Context, project *ProjectEntity) error
 	Update(ctx context.Context, project *ProjectEntity) (int, error)
@@ -11,5 +21,16 @@ type ProjectRepository interface {
 	DeleteMany(ctx context.Context, ids []string) (int, error)
 	FindByID(ctx context.Context, id string) (*ProjectEntity, error)
 	FindAll(ctx context.Context) ([]*ProjectEntity, error)
-	FindByName(ctx context.Context, name string) (*ProjectEntity, error)
+	// FindPage returns the projects matching the filter and how many match in total
+	FindPage(ctx context.Context, filter ProjectFilter) ([]*ProjectEntity, int64, error)
+	FindByName(ctx context.Context, language values.MarkdownLanguageCode, name string) (*ProjectEntity, error)
+	// SaveRepositoryMetadata replaces the synced metadata, nil clears it. It leaves the version
+	// alone unless bumpVersion is set.
+	SaveRepositoryMetadata(ctx context.Context, id string, metadata *RepositoryMetadata, bumpVersion bool) (int, error)
+	// SetFeatured features the projects of ids in their order and unfeatures every other one
+	SetFeatured(ctx context.Context, ids []string) (int, error)
+	// CountByTag counts the projects using a tag, the ones in the trash included
+	CountByTag(ctx context.Context, tag string) (int64, error)
+	// ReplaceTag renames a tag on every project using it, the ones in the trash included
+	ReplaceTag(ctx context.Context, oldTag, newTag string) (int, error)
 }
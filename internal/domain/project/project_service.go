@@ -2,26 +2,40 @@ package project
 
 import (
 	"context"
+	"fmt"
 	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/values"
+	"slices"
 )
 
 type ProjectService interface {
 	FindAllProjects(ctx context.Context) ([]*ProjectEntity, error)
+	// FindProjects returns a page of the projects matching the filter and how many match in total
+	FindProjects(ctx context.Context, filter ProjectFilter) ([]*ProjectEntity, int64, error)
 	FindProjectByID(ctx context.Context, id string) (*ProjectEntity, error)
-	CreateProject(ctx context.Context, name, description, github, cover string, tags []string, markdown string) (*ProjectEntity, error)
-	UpdateProject(ctx context.Context, id string, name, description, github, cover string, tags []string, markdown string) (*ProjectEntity, error)
+	CreateProject(ctx context.Context, languages []values.MarkdownLanguageCode, names, descriptions values.MultiLangText, github, cover string, tags []string, markdowns values.MultiLangText) (*ProjectEntity, error)
+	UpdateProject(ctx context.Context, id string, languages []values.MarkdownLanguageCode, names, descriptions values.MultiLangText, github, cover string, tags []string, markdowns values.MultiLangText, expectedVersion *int64) (*ProjectEntity, error)
 	DeleteProject(ctx context.Context, id string) (int, error)
 	DeleteMultipleProjects(ctx context.Context, ids []string) (int, error)
+	// SyncRepository refreshes the metadata of the project's repository and, with a readme
+	// language, replaces the markdown of that language with the repository README
+	SyncRepository(ctx context.Context, id string, readmeLanguage *values.MarkdownLanguageCode) (*ProjectEntity, error)
+	// FeatureProjects replaces the featured projects with ids, in that order, and returns them
+	FeatureProjects(ctx context.Context, ids []string) ([]*ProjectEntity, error)
+	CountTagUsage(ctx context.Context, tag string) (int64, error)
+	RenameTag(ctx context.Context, oldTag, newTag string) (int, error)
 }
 
 type projectService struct {
-	repository ProjectRepository
+	repository       ProjectRepository
+	metadataProvider RepositoryMetadataProvider
 }
 
 // NewProjectService creates a new instance of ProjectService
-func NewProjectService(repository ProjectRepository) ProjectService {
+func NewProjectService(repository ProjectRepository, metadataProvider RepositoryMetadataProvider) ProjectService {
 	return &projectService{
-		repository: repository,
+		repository:       repository,
+		metadataProvider: metadataProvider,
 	}
 }
 
@@ -30,6 +44,11 @@ func (s *projectService) FindAllProjects(ctx context.Context) ([]*ProjectEntity,
 	return s.repository.FindAll(ctx)
 }
 
+// FindProjects retrieves the projects matching the filter from the repository
+func (s *projectService) FindProjects(ctx context.Context, filter ProjectFilter) ([]*ProjectEntity, int64, error) {
+	return s.repository.FindPage(ctx, filter)
+}
+
 // FindProjectByID retrieves a specific project entity by its ID from the repository
 func (s *projectService) FindProjectByID(ctx context.Context, id string) (*ProjectEntity, error) {
 	return s.repository.FindByID(ctx, id)
@@ -38,17 +57,14 @@ func (s *projectService) FindProjectByID(ctx context.Context, id string) (*Proje
 // CreateProject creates a new project entity and saves it to the repository
 func (s *projectService) CreateProject(
 	ctx context.Context,
-	name, description, github, cover string,
+	languages []values.MarkdownLanguageCode,
+	names, descriptions values.MultiLangText,
+	github, cover string,
 	tags []string,
-	markdown string,
+	markdowns values.MultiLangText,
 ) (*ProjectEntity, error) {
-	// Validate project name
-	if err := ValidateProjectName(name); err != nil {
-		return nil, err
-	}
-
-	// Validate project description
-	if err := ValidateProjectDescription(description); err != nil {
+	// Validate the content of every language
+	if err := ValidateProjectContent(languages, names, descriptions, markdowns); err != nil {
 		return nil, err
 	}
 
@@ -58,27 +74,23 @@ func (s *projectService) CreateProject(
 	}
 
 	// Check if a project with the same name already exists
-	existingProject, err := s.repository.FindByName(ctx, name)
-	if err != nil {
+	if err := s.checkNameConflict(ctx, "", names); err != nil {
 		return nil, err
 	}
 
-	if existingProject != nil {
-		return nil, failure.NewConflictFailure("Project with the same name already exists")
-	}
-
 	// Create new project entity
 	newProject := NewProjectEntity(
-		name,
-		description,
+		languages,
+		names,
+		descriptions,
 		github,
 		cover,
 		tags,
-		markdown,
+		markdowns,
 	)
 
 	// Save to repository
-	err = s.repository.Create(ctx, newProject)
+	err := s.repository.Create(ctx, newProject)
 	if err != nil {
 		return nil, err
 	}
@@ -90,9 +102,12 @@ func (s *projectService) CreateProject(
 func (s *projectService) UpdateProject(
 	ctx context.Context,
 	id string,
-	name, description, github, cover string,
+	languages []values.MarkdownLanguageCode,
+	names, descriptions values.MultiLangText,
+	github, cover string,
 	tags []string,
-	markdown string,
+	markdowns values.MultiLangText,
+	expectedVersion *int64,
 ) (*ProjectEntity, error) {
 	// 1. Retrieve existing project
 	existingProject, err := s.repository.FindByID(ctx, id)
@@ -104,26 +119,26 @@ func (s *projectService) UpdateProject(
 		return nil, failure.NewNotFoundFailure("Project with the given ID does not exist")
 	}
 
-	// 2. Check for name conflict if name is being changed
-	if existingProject.Name != name {
-		conflictProject, err := s.repository.FindByName(ctx, name)
-		if err != nil {
-			return nil, err
-		}
+	// The update must be based on the version the client last read
+	if err := values.CheckVersion(existingProject.Version, expectedVersion); err != nil {
+		return nil, err
+	}
 
-		if conflictProject != nil {
-			return nil, failure.NewConflictFailure("Project with the same name already exists")
-		}
+	// 2. Check for name conflict with the other projects
+	if err := s.checkNameConflict(ctx, existingProject.ID.String(), names); err != nil {
+		return nil, err
 	}
 
 	// 3. Update fields
+	githubChanged := existingProject.Github != github
 	err = existingProject.Update(
-		name,
-		description,
+		languages,
+		names,
+		descriptions,
 		github,
 		cover,
 		tags,
-		markdown,
+		markdowns,
 	)
 
 	if err != nil {
@@ -140,9 +155,129 @@ func (s *projectService) UpdateProject(
 		return nil, failure.NewNotFoundFailure("Project with the given ID does not exist")
 	}
 
+	// 5. The metadata of the previous repository is stale
+	if githubChanged {
+		if _, err := s.repository.SaveRepositoryMetadata(ctx, id, nil, false); err != nil {
+			return nil, err
+		}
+	}
+
+	return existingProject, nil
+}
+
+func (s *projectService) SyncRepository(ctx context.Context, id string, readmeLanguage *values.MarkdownLanguageCode) (*ProjectEntity, error) {
+	// 1. Retrieve the project
+	existingProject, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingProject == nil {
+		return nil, failure.NewNotFoundFailure("Project with the given ID does not exist")
+	}
+
+	if existingProject.Github == "" {
+		return nil, failure.NewValidationFailure("Project has no repository to sync")
+	}
+
+	// 2. Read the repository before writing anything, a rate limited host leaves the project as is
+	metadata, err := s.metadataProvider.FetchMetadata(ctx, existingProject.Github)
+	if err != nil {
+		return nil, err
+	}
+
+	// The version is bumped only when the project changes, a sync that found nothing new
+	// keeps cached reads and the ETag clients hold valid
+	bumpVersion := !metadata.SameAs(existingProject.Repository)
+
+	// 3. Import the README, a versioned write like any edit of the content
+	if readmeLanguage != nil {
+		readme, err := s.metadataProvider.FetchReadme(ctx, existingProject.Github)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := existingProject.ImportReadme(*readmeLanguage, readme); err != nil {
+			return nil, err
+		}
+
+		rowsAffected, err := s.repository.Update(ctx, existingProject)
+		if err != nil {
+			return nil, err
+		}
+
+		if rowsAffected == 0 {
+			return nil, failure.NewNotFoundFailure("Project with the given ID does not exist")
+		}
+
+		bumpVersion = false
+	}
+
+	// 4. Save the metadata
+	if _, err := s.repository.SaveRepositoryMetadata(ctx, id, metadata, bumpVersion); err != nil {
+		return nil, err
+	}
+
+	existingProject.Repository = metadata
+	if bumpVersion {
+		existingProject.Version++
+	}
+
 	return existingProject, nil
 }
 
+func (s *projectService) FeatureProjects(ctx context.Context, ids []string) ([]*ProjectEntity, error) {
+	// 1. Every listed project must exist, once
+	for i, id := range ids {
+		if slices.Contains(ids[:i], id) {
+			return nil, failure.NewValidationFailure(fmt.Sprintf("project %s is listed more than once", id))
+		}
+
+		existingProject, err := s.repository.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if existingProject == nil {
+			return nil, failure.NewNotFoundFailure(fmt.Sprintf("Project with ID %s does not exist", id))
+		}
+	}
+
+	// 2. Save the new featured list
+	if _, err := s.repository.SetFeatured(ctx, ids); err != nil {
+		return nil, err
+	}
+
+	featured, _, err := s.repository.FindPage(ctx, ProjectFilter{FeaturedOnly: true})
+	return featured, err
+}
+
+// CountTagUsage counts the projects using a tag, the ones in the trash included
+func (s *projectService) CountTagUsage(ctx context.Context, tag string) (int64, error) {
+	return s.repository.CountByTag(ctx, tag)
+}
+
+// RenameTag replaces a tag on every project using it
+func (s *projectService) RenameTag(ctx context.Context, oldTag, newTag string) (int, error) {
+	return s.repository.ReplaceTag(ctx, oldTag, newTag)
+}
+
+// checkNameConflict rejects names another project already uses in the same language
+func (s *projectService) checkNameConflict(ctx context.Context, id string, names values.MultiLangText) error {
+	for language, name := range names {
+		conflictProject, err := s.repository.FindByName(ctx, language, name)
+		if err != nil {
+			return err
+		}
+
+		if conflictProject != nil && conflictProject.ID.String() != id {
+			return failure.NewConflictFailure(fmt.Sprintf("Project with the same %s name already exists", language))
+		}
+	}
+
+	return nil
+}
+
 // DeleteProject deletes a project by its ID
 func (s *projectService) DeleteProject(ctx context.Context, id string) (int, error) {
 	return s.repository.Delete(ctx, id)
@@ -0,0 +1,48 @@
+package project
+
+import (
+	"context"
+	"slices"
+)
+
+// RepositoryMetadata describes the source repository of a project, as last synced from its host
+type RepositoryMetadata struct {
+	URL             string   `json:"url"` // the github URL of the project when it was synced
+	FullName        string   `json:"fullName"`
+	Description     string   `json:"description"`
+	PrimaryLanguage string   `json:"primaryLanguage"`
+	Topics          []string `json:"topics"`
+	Stars           int      `json:"stars"`
+	Forks           int      `json:"forks"`
+	OpenIssues      int      `json:"openIssues"`
+	Archived        bool     `json:"archived"`
+	PushedAt        int64    `json:"pushedAt"`
+	SyncedAt        int64    `json:"syncedAt"`
+}
+
+// SameAs tells whether two syncs found the same repository state, the sync time aside
+func (m *RepositoryMetadata) SameAs(other *RepositoryMetadata) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+
+	return m.URL == other.URL &&
+		m.FullName == other.FullName &&
+		m.Description == other.Description &&
+		m.PrimaryLanguage == other.PrimaryLanguage &&
+		slices.Equal(m.Topics, other.Topics) &&
+		m.Stars == other.Stars &&
+		m.Forks == other.Forks &&
+		m.OpenIssues == other.OpenIssues &&
+		m.Archived == other.Archived &&
+		m.PushedAt == other.PushedAt
+}
+
+// RepositoryMetadataProvider reads repositories from the host of their URL, e.g. GitHub.
+// URLs of another host fail with a validation failure, unknown repositories with a not found
+// failure, and a rate limited host with a too many requests failure until the limit resets.
+type RepositoryMetadataProvider interface {
+	FetchMetadata(ctx context.Context, repositoryURL string) (*RepositoryMetadata, error)
+	// FetchReadme returns the README of the default branch as markdown
+	FetchReadme(ctx context.Context, repositoryURL string) (string, error)
+}
@@ -0,0 +1,175 @@
+package role
+
+import (
+	"fmt"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/values"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxRoleNameLength        = 50
+	MinRoleNameLength        = 2
+	MaxRoleDescriptionLength = 255
+)
+
+// Built-in roles. They mirror the former Normal < Admin < God hierarchy and cannot be deleted.
+const (
+	ReaderRoleName = "reader"
+	AdminRoleName  = "admin"
+	GodRoleName    = "god"
+)
+
+var roleNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+type RoleEntity struct {
+	ID          uuid.UUID           `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Permissions []values.Permission `json:"permissions"`
+	IsSystem    bool                `json:"isSystem"`
+	CreatedAt   int64               `json:"createdAt"`
+	UpdatedAt   int64               `json:"updatedAt"`
+}
+
+func NewRole(name, description string, permissions []values.Permission) (*RoleEntity, error) {
+	if err := ValidateRoleName(name); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateRoleDescription(description); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &RoleEntity{
+		ID:          uuid.New(),
+		Name:        name,
+		Description: description,
+		Permissions: permissions,
+		IsSystem:    false,
+		CreatedAt:   now.Unix(),
+		UpdatedAt:   now.Unix(),
+	}, nil
+}
+
+func (r *RoleEntity) Update(description string, permissions []values.Permission) error {
+	if r.Name == GodRoleName {
+		return failure.NewForbiddenFailure("the god role always holds every permission and cannot be modified")
+	}
+
+	if err := ValidateRoleDescription(description); err != nil {
+		return err
+	}
+
+	r.Description = description
+	r.Permissions = permissions
+	r.UpdatedAt = time.Now().Unix()
+	return nil
+}
+
+// HasPermission reports whether the role grants the permission
+func (r *RoleEntity) HasPermission(permission values.Permission) bool {
+	return slices.Contains(r.Permissions, permission)
+}
+
+// SystemRoles returns the built-in roles with their default permissions
+func SystemRoles() []*RoleEntity {
+	now := time.Now().Unix()
+	newSystemRole := func(name, description string, permissions []values.Permission) *RoleEntity {
+		return &RoleEntity{
+			ID:          uuid.New(),
+			Name:        name,
+			Description: description,
+			Permissions: permissions,
+			IsSystem:    true,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+	}
+
+	return []*RoleEntity{
+		newSystemRole(ReaderRoleName, "Registered reader without management permissions", []values.Permission{}),
+		newSystemRole(AdminRoleName, "Content administrator", []values.Permission{
+			values.BlogWritePermission,
+			values.BlogPublishPermission,
+			values.BlogManagePermission,
+			values.CommentModeratePermission,
+			values.CategoryWritePermission,
+			values.ProjectWritePermission,
+			values.ExperienceWritePermission,
+			values.MediaWritePermission,
+		}),
+		newSystemRole(GodRoleName, "Owner with every permission", values.AllPermissions()),
+	}
+}
+
+// SystemRoleNameFromLegacy maps the former linear account role to its built-in role
+func SystemRoleNameFromLegacy(accountRole values.AccountRole) string {
+	switch accountRole {
+	case values.GodRole:
+		return GodRoleName
+	case values.AdminRole:
+		return AdminRoleName
+	default:
+		return ReaderRoleName
+	}
+}
+
+// CollectPermissions returns the union of the permissions granted by the roles
+func CollectPermissions(roles []*RoleEntity) []values.Permission {
+	permissions := []values.Permission{}
+	for _, r := range roles {
+		for _, p := range r.Permissions {
+			if !slices.Contains(permissions, p) {
+				permissions = append(permissions, p)
+			}
+		}
+	}
+
+	return permissions
+}
+
+// RoleNames returns the names of the roles
+func RoleNames(roles []*RoleEntity) []string {
+	names := make([]string, 0, len(roles))
+	for _, r := range roles {
+		names = append(names, r.Name)
+	}
+
+	return names
+}
+
+func ValidateRoleName(name string) error {
+	if len(name) < MinRoleNameLength {
+		return failure.NewValidationFailure(
+			fmt.Sprintf("role name must be at least %d characters", MinRoleNameLength),
+		)
+	}
+
+	if len(name) > MaxRoleNameLength {
+		return failure.NewValidationFailure(
+			fmt.Sprintf("role name exceeds maximum of %d characters", MaxRoleNameLength),
+		)
+	}
+
+	if !roleNamePattern.MatchString(name) {
+		return failure.NewValidationFailure("role name may only contain lowercase letters, digits, '-' and '_'")
+	}
+
+	return nil
+}
+
+func ValidateRoleDescription(description string) error {
+	if len(description) > MaxRoleDescriptionLength {
+		return failure.NewValidationFailure(
+			fmt.Sprintf("role description exceeds maximum of %d characters", MaxRoleDescriptionLength),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package role
+
+import (
+	"context"
+)
+
+type RoleRepository interface {
+	Create(ctx context.Context, role *RoleEntity) error
+	Update(ctx context.Context, role *RoleEntity) (int, error)
+	Delete(ctx context.Context, id string) (int, error)
+	FindByID(ctx context.Context, id string) (*RoleEntity, error)
+	FindByName(ctx context.Context, name string) (*RoleEntity, error)
+	FindByNames(ctx context.Context, names []string) ([]*RoleEntity, error)
+	FindAll(ctx context.Context) ([]*RoleEntity, error)
+
+	// Account assignments
+	FindByAccountID(ctx context.Context, accountID string) ([]*RoleEntity, error)
+	ReplaceAccountRoles(ctx context.Context, accountID string, roleIDs []string) error
+}
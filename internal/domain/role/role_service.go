@@ -0,0 +1,200 @@
+package role
+
+import (
+	"context"
+	"fmt"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/values"
+	"slices"
+)
+
+type RoleService interface {
+	FindAllRoles(ctx context.Context) ([]*RoleEntity, error)
+	FindRoleByID(ctx context.Context, id string) (*RoleEntity, error)
+	CreateRole(ctx context.Context, name, description string, permissions []values.Permission) (*RoleEntity, error)
+	UpdateRole(ctx context.Context, id, description string, permissions []values.Permission) (*RoleEntity, error)
+	DeleteRole(ctx context.Context, id string) (int, error)
+	EnsureSystemRoles(ctx context.Context) error
+
+	// Account assignments
+	FindAccountRoles(ctx context.Context, accountID string, legacyRole values.AccountRole) ([]*RoleEntity, error)
+	AssignRolesToAccount(ctx context.Context, accountID string, roleNames []string) ([]*RoleEntity, error)
+}
+
+type roleService struct {
+	repository RoleRepository
+}
+
+func NewRoleService(repository RoleRepository) RoleService {
+	return &roleService{
+		repository: repository,
+	}
+}
+
+func (s *roleService) FindAllRoles(ctx context.Context) ([]*RoleEntity, error) {
+	return s.repository.FindAll(ctx)
+}
+
+func (s *roleService) FindRoleByID(ctx context.Context, id string) (*RoleEntity, error) {
+	return s.repository.FindByID(ctx, id)
+}
+
+func (s *roleService) CreateRole(ctx context.Context, name, description string, permissions []values.Permission) (*RoleEntity, error) {
+	// 1. Check if role with the same name already exists
+	existingRole, err := s.repository.FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingRole != nil {
+		return nil, failure.NewConflictFailure("Role with the same name already exists")
+	}
+
+	// 2. Create new role entity
+	newRole, err := NewRole(name, description, permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Save to repository
+	if err := s.repository.Create(ctx, newRole); err != nil {
+		return nil, err
+	}
+
+	return newRole, nil
+}
+
+func (s *roleService) UpdateRole(ctx context.Context, id, description string, permissions []values.Permission) (*RoleEntity, error) {
+	// 1. Find existing role
+	existingRole, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingRole == nil {
+		return nil, failure.NewNotFoundFailure("Role with the given ID does not exist")
+	}
+
+	// 2. Update role
+	if err := existingRole.Update(description, permissions); err != nil {
+		return nil, err
+	}
+
+	// 3. Save to repository
+	rowsAffected, err := s.repository.Update(ctx, existingRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, failure.NewNotFoundFailure("Role with the given ID does not exist")
+	}
+
+	return existingRole, nil
+}
+
+func (s *roleService) DeleteRole(ctx context.Context, id string) (int, error) {
+	existingRole, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	if existingRole == nil {
+		return 0, nil
+	}
+
+	if existingRole.IsSystem {
+		return 0, failure.NewForbiddenFailure("system roles cannot be deleted")
+	}
+
+	return s.repository.Delete(ctx, id)
+}
+
+// EnsureSystemRoles creates the built-in roles that are missing, leaving edited ones untouched
+func (s *roleService) EnsureSystemRoles(ctx context.Context) error {
+	for _, systemRole := range SystemRoles() {
+		existingRole, err := s.repository.FindByName(ctx, systemRole.Name)
+		if err != nil {
+			return err
+		}
+
+		// The god role follows the permission catalogue, so newly added permissions reach it
+		if existingRole != nil {
+			if existingRole.Name == GodRoleName && !slices.Equal(existingRole.Permissions, systemRole.Permissions) {
+				existingRole.Permissions = systemRole.Permissions
+				if _, err := s.repository.Update(ctx, existingRole); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		if err := s.repository.Create(ctx, systemRole); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FindAccountRoles returns the roles assigned to the account. Accounts without assignments
+// fall back to the built-in role matching their legacy role, so existing accounts keep their access.
+func (s *roleService) FindAccountRoles(ctx context.Context, accountID string, legacyRole values.AccountRole) ([]*RoleEntity, error) {
+	roles, err := s.repository.FindByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(roles) > 0 {
+		return roles, nil
+	}
+
+	fallbackRole, err := s.repository.FindByName(ctx, SystemRoleNameFromLegacy(legacyRole))
+	if err != nil {
+		return nil, err
+	}
+
+	if fallbackRole == nil {
+		return []*RoleEntity{}, nil
+	}
+
+	return []*RoleEntity{fallbackRole}, nil
+}
+
+// AssignRolesToAccount replaces every role of the account with the named roles
+func (s *roleService) AssignRolesToAccount(ctx context.Context, accountID string, roleNames []string) ([]*RoleEntity, error) {
+	// 1. Resolve role names
+	roles, err := s.repository.FindByNames(ctx, roleNames)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range roleNames {
+		if !containsRoleName(roles, name) {
+			return nil, failure.NewNotFoundFailure(fmt.Sprintf("Role %s does not exist", name))
+		}
+	}
+
+	// 2. Replace assignments
+	roleIDs := make([]string, 0, len(roles))
+	for _, r := range roles {
+		roleIDs = append(roleIDs, r.ID.String())
+	}
+
+	if err := s.repository.ReplaceAccountRoles(ctx, accountID, roleIDs); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func containsRoleName(roles []*RoleEntity, name string) bool {
+	for _, r := range roles {
+		if r.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
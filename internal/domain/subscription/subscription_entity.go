@@ -0,0 +1,120 @@
+package subscription
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/values"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TargetType is what can be followed, new blogs are announced to the followers of their author
+// and of each of their categories
+type TargetType string
+
+const (
+	TargetCategory TargetType = "category"
+	TargetAuthor   TargetType = "author"
+)
+
+func TargetTypeFromString(value string) (TargetType, error) {
+	switch targetType := TargetType(value); targetType {
+	case TargetCategory, TargetAuthor:
+		return targetType, nil
+	default:
+		return "", failure.NewValidationFailure("follow target type must be one of category or author")
+	}
+}
+
+type Target struct {
+	Type TargetType
+	ID   uuid.UUID
+}
+
+// CategoryTopic is the FCM topic new blogs of the category are broadcast to, devices without a
+// signed-in account subscribe to it from the client SDK
+func CategoryTopic(categoryID uuid.UUID) string {
+	return "category_" + categoryID.String()
+}
+
+// FollowEntity is an account following a category or an author
+type FollowEntity struct {
+	ID         uuid.UUID  `json:"id"`
+	AccountID  uuid.UUID  `json:"accountId"`
+	TargetType TargetType `json:"targetType"`
+	TargetID   uuid.UUID  `json:"targetId"`
+	CreatedAt  int64      `json:"createdAt"`
+}
+
+func NewFollow(accountID uuid.UUID, target Target) (*FollowEntity, error) {
+	if target.Type == TargetAuthor && target.ID == accountID {
+		return nil, failure.NewValidationFailure("you cannot follow yourself")
+	}
+
+	return &FollowEntity{
+		ID:         uuid.New(),
+		AccountID:  accountID,
+		TargetType: target.Type,
+		TargetID:   target.ID,
+		CreatedAt:  time.Now().Unix(),
+	}, nil
+}
+
+// EmailSubscriptionEntity is an anonymous reader following a target by email. It only receives
+// mail once confirmed through the link sent to the address (double opt-in); the same token
+// unsubscribes it.
+type EmailSubscriptionEntity struct {
+	ID          uuid.UUID  `json:"id"`
+	Email       string     `json:"email"`
+	TargetType  TargetType `json:"targetType"`
+	TargetID    uuid.UUID  `json:"targetId"`
+	Token       string     `json:"-"`
+	ConfirmedAt *int64     `json:"confirmedAt,omitempty"`
+	CreatedAt   int64      `json:"createdAt"`
+}
+
+func NewEmailSubscription(email string, target Target) (*EmailSubscriptionEntity, error) {
+	address, err := values.NewEmail(strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return &EmailSubscriptionEntity{
+		ID:         uuid.New(),
+		Email:      address.Value(),
+		TargetType: target.Type,
+		TargetID:   target.ID,
+		Token:      token,
+		CreatedAt:  time.Now().Unix(),
+	}, nil
+}
+
+func (s *EmailSubscriptionEntity) IsConfirmed() bool {
+	return s.ConfirmedAt != nil
+}
+
+func (s *EmailSubscriptionEntity) Confirm() {
+	if s.ConfirmedAt != nil {
+		return
+	}
+
+	now := time.Now().Unix()
+	s.ConfirmedAt = &now
+}
+
+func newToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", failure.NewInternalFailure("failed to generate subscription token", err)
+	}
+
+	return hex.EncodeToString(token), nil
+}
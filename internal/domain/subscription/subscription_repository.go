@@ -0,0 +1,23 @@
+package subscription
+
+import (
+	"context"
+)
+
+type FollowRepository interface {
+	// Create keeps an existing follow of the same target and returns it
+	Create(ctx context.Context, follow *FollowEntity) (*FollowEntity, error)
+	FindByAccountID(ctx context.Context, accountID string) ([]*FollowEntity, error)
+	DeleteByID(ctx context.Context, accountID, id string) (int, error)
+	FindFollowerIDs(ctx context.Context, targets []Target) ([]string, error)
+}
+
+type EmailSubscriptionRepository interface {
+	// Upsert replaces the token of a pending subscription to the same target, confirmed ones are
+	// returned unchanged
+	Upsert(ctx context.Context, subscription *EmailSubscriptionEntity) (*EmailSubscriptionEntity, error)
+	FindByToken(ctx context.Context, token string) (*EmailSubscriptionEntity, error)
+	Update(ctx context.Context, subscription *EmailSubscriptionEntity) (int, error)
+	DeleteByEmail(ctx context.Context, email string) (int, error)
+	FindConfirmed(ctx context.Context, targets []Target) ([]*EmailSubscriptionEntity, error)
+}
@@ -0,0 +1,151 @@
+package subscription
+
+import (
+	"context"
+	"hinsun-backend/internal/core/failure"
+
+	"github.com/google/uuid"
+)
+
+type SubscriptionService interface {
+	Follow(ctx context.Context, accountID uuid.UUID, target Target) (*FollowEntity, error)
+	FindAccountFollows(ctx context.Context, accountID string) ([]*FollowEntity, error)
+	Unfollow(ctx context.Context, accountID, id string) error
+	SubscribeEmail(ctx context.Context, email string, target Target) (*EmailSubscriptionEntity, error)
+	ConfirmEmail(ctx context.Context, token string) (*EmailSubscriptionEntity, error)
+	UnsubscribeEmail(ctx context.Context, token string) error
+	FindSubscribers(ctx context.Context, targets []Target) (*Subscribers, error)
+}
+
+// Subscribers is who hears about new content of a set of targets
+type Subscribers struct {
+	AccountIDs []string
+	Emails     []*EmailSubscriptionEntity // confirmed, one per address
+}
+
+type subscriptionService struct {
+	followRepository            FollowRepository
+	emailSubscriptionRepository EmailSubscriptionRepository
+}
+
+func NewSubscriptionService(followRepository FollowRepository, emailSubscriptionRepository EmailSubscriptionRepository) SubscriptionService {
+	return &subscriptionService{
+		followRepository:            followRepository,
+		emailSubscriptionRepository: emailSubscriptionRepository,
+	}
+}
+
+// Follow makes the account follow the target, following it again is a no-op
+func (s *subscriptionService) Follow(ctx context.Context, accountID uuid.UUID, target Target) (*FollowEntity, error) {
+	follow, err := NewFollow(accountID, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.followRepository.Create(ctx, follow)
+}
+
+func (s *subscriptionService) FindAccountFollows(ctx context.Context, accountID string) ([]*FollowEntity, error) {
+	return s.followRepository.FindByAccountID(ctx, accountID)
+}
+
+// Unfollow removes a follow owned by the given account
+func (s *subscriptionService) Unfollow(ctx context.Context, accountID, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return failure.NewValidationFailure("invalid follow ID")
+	}
+
+	rowsAffected, err := s.followRepository.DeleteByID(ctx, accountID, id)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return failure.NewNotFoundFailure("Follow with the given ID does not exist")
+	}
+
+	return nil
+}
+
+// SubscribeEmail records a pending subscription, the caller sends its token to the address
+func (s *subscriptionService) SubscribeEmail(ctx context.Context, email string, target Target) (*EmailSubscriptionEntity, error) {
+	subscription, err := NewEmailSubscription(email, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.emailSubscriptionRepository.Upsert(ctx, subscription)
+}
+
+func (s *subscriptionService) ConfirmEmail(ctx context.Context, token string) (*EmailSubscriptionEntity, error) {
+	subscription, err := s.findByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription.IsConfirmed() {
+		return subscription, nil
+	}
+
+	subscription.Confirm()
+	if _, err := s.emailSubscriptionRepository.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// UnsubscribeEmail removes every subscription of the address the token belongs to, so the link
+// in any email stops them all
+func (s *subscriptionService) UnsubscribeEmail(ctx context.Context, token string) error {
+	subscription, err := s.findByToken(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.emailSubscriptionRepository.DeleteByEmail(ctx, subscription.Email)
+	return err
+}
+
+func (s *subscriptionService) FindSubscribers(ctx context.Context, targets []Target) (*Subscribers, error) {
+	subscribers := &Subscribers{}
+	if len(targets) == 0 {
+		return subscribers, nil
+	}
+
+	accountIDs, err := s.followRepository.FindFollowerIDs(ctx, targets)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := s.emailSubscriptionRepository.FindConfirmed(ctx, targets)
+	if err != nil {
+		return nil, err
+	}
+
+	subscribers.AccountIDs = accountIDs
+	seen := make(map[string]bool, len(subscriptions))
+	for _, subscription := range subscriptions {
+		if seen[subscription.Email] {
+			continue
+		}
+
+		seen[subscription.Email] = true
+		subscribers.Emails = append(subscribers.Emails, subscription)
+	}
+
+	return subscribers, nil
+}
+
+func (s *subscriptionService) findByToken(ctx context.Context, token string) (*EmailSubscriptionEntity, error) {
+	subscription, err := s.emailSubscriptionRepository.FindByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription == nil {
+		return nil, failure.NewNotFoundFailure("Subscription link is invalid or was already used to unsubscribe")
+	}
+
+	return subscription, nil
+}
@@ -0,0 +1,76 @@
+package tag
+
+import (
+	"fmt"
+	"hinsun-backend/internal/core/failure"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxTagNameLength = 50
+	MinTagNameLength = 2
+)
+
+// TagEntity is a word of the project tag vocabulary, projects reference tags by name
+type TagEntity struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	NumProjects int64     `json:"numProjects"` // counted on read from the live projects, not persisted
+	CreatedAt   int64     `json:"createdAt"`
+	UpdatedAt   int64     `json:"updatedAt"`
+	Version     int64     `json:"version"`
+	DeletedAt   *int64    `json:"deletedAt,omitempty"`
+}
+
+func NewTag(name string) (*TagEntity, error) {
+	name = NormalizeName(name)
+	if err := ValidateTagName(name); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &TagEntity{
+		ID:        uuid.New(),
+		Name:      name,
+		CreatedAt: now.Unix(),
+		UpdatedAt: now.Unix(),
+		Version:   1,
+		DeletedAt: nil,
+	}, nil
+}
+
+func (t *TagEntity) Update(name string) error {
+	name = NormalizeName(name)
+	if err := ValidateTagName(name); err != nil {
+		return err
+	}
+
+	t.Name = name
+	t.UpdatedAt = time.Now().Unix()
+	return nil
+}
+
+// NormalizeName gives the form tags are stored and matched in: trimmed, lower case and with
+// single spaces, so "Machine  Learning" and "machine learning" are the same tag
+func NormalizeName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+func ValidateTagName(name string) error {
+	if len(name) < MinTagNameLength {
+		return failure.NewValidationFailure(
+			fmt.Sprintf("tag name must be at least %d characters", MinTagNameLength),
+		)
+	}
+
+	if len(name) > MaxTagNameLength {
+		return failure.NewValidationFailure(
+			fmt.Sprintf("tag name exceeds maximum of %d characters", MaxTagNameLength),
+		)
+	}
+
+	return nil
+}
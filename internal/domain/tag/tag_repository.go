@@ -0,0 +1,18 @@
+package tag
+
+import (
+	"context"
+)
+
+// TagRepository stores the vocabulary, the tags it reads carry the number of live projects using them
+type TagRepository interface {
+	Create(ctx context.Context, tag *TagEntity) error
+	Update(ctx context.Context, tag *TagEntity) (int, error)
+	Delete(ctx context.Context, id string) (int, error)
+	DeleteMany(ctx context.Context, ids []string) (int, error)
+	FindByID(ctx context.Context, id string) (*TagEntity, error)
+	FindByName(ctx context.Context, name string) (*TagEntity, error)
+	FindByNames(ctx context.Context, names []string) ([]*TagEntity, error)
+	// FindAll returns the tags sorted by name, or by usage first when byUsage is set
+	FindAll(ctx context.Context, byUsage bool) ([]*TagEntity, error)
+}
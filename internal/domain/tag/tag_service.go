@@ -0,0 +1,146 @@
+package tag
+
+import (
+	"context"
+	"fmt"
+	"hinsun-backend/internal/core/failure"
+	"hinsun-backend/internal/domain/values"
+	"slices"
+	"strings"
+)
+
+type TagService interface {
+	FindAllTags(ctx context.Context, byUsage bool) ([]*TagEntity, error)
+	FindTagByID(ctx context.Context, id string) (*TagEntity, error)
+	CreateTag(ctx context.Context, name string) (*TagEntity, error)
+	// UpdateTag renames a tag and returns it along with the name it had before
+	UpdateTag(ctx context.Context, id string, name string, expectedVersion *int64) (*TagEntity, string, error)
+	DeleteTag(ctx context.Context, id string) (int, error)
+	DeleteMultipleTags(ctx context.Context, ids []string) (int, error)
+	// ResolveTags normalizes the tag names given for a project and drops the duplicates,
+	// names missing from the vocabulary fail with a validation failure
+	ResolveTags(ctx context.Context, names []string) ([]string, error)
+}
+
+type tagService struct {
+	repository TagRepository
+}
+
+func NewTagService(repository TagRepository) TagService {
+	return &tagService{
+		repository: repository,
+	}
+}
+
+func (s *tagService) FindAllTags(ctx context.Context, byUsage bool) ([]*TagEntity, error) {
+	return s.repository.FindAll(ctx, byUsage)
+}
+
+func (s *tagService) FindTagByID(ctx context.Context, id string) (*TagEntity, error) {
+	return s.repository.FindByID(ctx, id)
+}
+
+func (s *tagService) CreateTag(ctx context.Context, name string) (*TagEntity, error) {
+	newTag, err := NewTag(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if the normalized name is already in the vocabulary
+	existingTag, err := s.repository.FindByName(ctx, newTag.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingTag != nil {
+		return nil, failure.NewConflictFailure("Tag with the same name already exists")
+	}
+
+	err = s.repository.Create(ctx, newTag)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTag, nil
+}
+
+func (s *tagService) UpdateTag(ctx context.Context, id string, name string, expectedVersion *int64) (*TagEntity, string, error) {
+	// Find existing tag
+	existingTag, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if existingTag == nil {
+		return nil, "", failure.NewNotFoundFailure("Tag with the given ID does not exist")
+	}
+
+	// The update must be based on the version the client last read
+	if err := values.CheckVersion(existingTag.Version, expectedVersion); err != nil {
+		return nil, "", err
+	}
+
+	previousName := existingTag.Name
+	if err := existingTag.Update(name); err != nil {
+		return nil, "", err
+	}
+
+	// Check if another tag already has the new name
+	tagWithSameName, err := s.repository.FindByName(ctx, existingTag.Name)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if tagWithSameName != nil && tagWithSameName.ID != existingTag.ID {
+		return nil, "", failure.NewConflictFailure("Another tag with the same name already exists")
+	}
+
+	rowsAffected, err := s.repository.Update(ctx, existingTag)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if rowsAffected == 0 {
+		return nil, "", failure.NewNotFoundFailure("Tag with the given ID does not exist")
+	}
+
+	return existingTag, previousName, nil
+}
+
+func (s *tagService) DeleteTag(ctx context.Context, id string) (int, error) {
+	return s.repository.Delete(ctx, id)
+}
+
+func (s *tagService) DeleteMultipleTags(ctx context.Context, ids []string) (int, error) {
+	return s.repository.DeleteMany(ctx, ids)
+}
+
+func (s *tagService) ResolveTags(ctx context.Context, names []string) ([]string, error) {
+	resolved := make([]string, 0, len(names))
+	for _, name := range names {
+		name = NormalizeName(name)
+		if !slices.Contains(resolved, name) {
+			resolved = append(resolved, name)
+		}
+	}
+
+	knownTags, err := s.repository.FindByNames(ctx, resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	var unknown []string
+	for _, name := range resolved {
+		if !slices.ContainsFunc(knownTags, func(t *TagEntity) bool { return t.Name == name }) {
+			unknown = append(unknown, name)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return nil, failure.NewValidationFailure(
+			fmt.Sprintf("unknown project tags: %s", strings.Join(unknown, ", ")),
+		)
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,49 @@
+package trash
+
+import (
+	"fmt"
+	"hinsun-backend/internal/core/failure"
+	"slices"
+)
+
+// Resource names an aggregate that supports soft delete
+type Resource string
+
+const (
+	AccountResource    Resource = "accounts"
+	BlogResource       Resource = "blogs"
+	CommentResource    Resource = "comments"
+	CategoryResource   Resource = "categories"
+	ExperienceResource Resource = "experiences"
+	ProjectResource    Resource = "projects"
+	TagResource        Resource = "tags"
+)
+
+// AllResources returns every soft-deletable resource, dependents before the rows they reference
+func AllResources() []Resource {
+	return []Resource{
+		CommentResource,
+		BlogResource,
+		CategoryResource,
+		ExperienceResource,
+		ProjectResource,
+		TagResource,
+		AccountResource,
+	}
+}
+
+func ResourceFromString(resource string) (Resource, error) {
+	if !slices.Contains(AllResources(), Resource(resource)) {
+		return "", failure.NewValidationFailure(fmt.Sprintf("Invalid trash resource: %s", resource))
+	}
+
+	return Resource(resource), nil
+}
+
+// TrashItemEntity is a soft-deleted row, described just enough to decide whether to restore it
+type TrashItemEntity struct {
+	ID        string   `json:"id"`
+	Resource  Resource `json:"resource"`
+	Label     string   `json:"label"`
+	DeletedAt int64    `json:"deletedAt"`
+}
@@ -0,0 +1,11 @@
+package trash
+
+import (
+	"context"
+)
+
+type TrashRepository interface {
+	FindDeleted(ctx context.Context, resource Resource) ([]*TrashItemEntity, error)
+	Restore(ctx context.Context, resource Resource, id string) (int, error)
+	Purge(ctx context.Context, resource Resource, deletedBefore int64) (int, error)
+}
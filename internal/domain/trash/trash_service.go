@@ -0,0 +1,73 @@
+package trash
+
+import (
+	"context"
+	"hinsun-backend/internal/core/failure"
+	"sort"
+	"time"
+)
+
+type TrashService interface {
+	FindTrash(ctx context.Context, resources []Resource) ([]*TrashItemEntity, error)
+	RestoreItem(ctx context.Context, resource Resource, id string) error
+	PurgeExpired(ctx context.Context, retention time.Duration) (map[Resource]int, error)
+}
+
+type trashService struct {
+	repository TrashRepository
+}
+
+func NewTrashService(repository TrashRepository) TrashService {
+	return &trashService{
+		repository: repository,
+	}
+}
+
+// FindTrash lists soft-deleted items of the given resources, most recently deleted first
+func (s *trashService) FindTrash(ctx context.Context, resources []Resource) ([]*TrashItemEntity, error) {
+	items := []*TrashItemEntity{}
+	for _, resource := range resources {
+		deleted, err := s.repository.FindDeleted(ctx, resource)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, deleted...)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DeletedAt > items[j].DeletedAt
+	})
+
+	return items, nil
+}
+
+func (s *trashService) RestoreItem(ctx context.Context, resource Resource, id string) error {
+	rowsAffected, err := s.repository.Restore(ctx, resource, id)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return failure.NewNotFoundFailure("No deleted item with the given ID exists in the trash")
+	}
+
+	return nil
+}
+
+// PurgeExpired permanently deletes items that have been in the trash longer than the retention
+func (s *trashService) PurgeExpired(ctx context.Context, retention time.Duration) (map[Resource]int, error) {
+	deletedBefore := time.Now().Add(-retention).Unix()
+	purged := make(map[Resource]int)
+
+	for _, resource := range AllResources() {
+		rowsAffected, err := s.repository.Purge(ctx, resource, deletedBefore)
+		if err != nil {
+			return purged, err
+		}
+
+		purged[resource] = rowsAffected
+	}
+
+	return purged, nil
+}
@@ -4,17 +4,20 @@ import (
 	"context"
 	"hinsun-backend/internal/core/types"
 	"hinsun-backend/internal/domain/account"
+	"hinsun-backend/internal/domain/role"
+	"hinsun-backend/internal/domain/values"
 )
 
 type CreateAccountParams struct {
-	Name          string `json:"name" validate:"required,min=2,max=50"`
-	Email         string `json:"email" validate:"required,email"`
-	Password      string `json:"password" validate:"required,min=8"`
-	EmailVerified bool   `json:"emailVerified"`
-	Avatar        string `json:"avatar" validate:"omitempty,url"`
-	Bio           string `json:"bio" validate:"omitempty,max=300"`
-	IsActive      bool   `json:"isActive"`
-	Role          int    `json:"role"`
+	Name          string   `json:"name" validate:"required,min=2,max=50"`
+	Email         string   `json:"email" validate:"required,email"`
+	Password      string   `json:"password" validate:"required,min=8"`
+	EmailVerified bool     `json:"emailVerified"`
+	Avatar        string   `json:"avatar" validate:"omitempty,url"`
+	Bio           string   `json:"bio" validate:"omitempty,max=300"`
+	IsActive      bool     `json:"isActive"`
+	Role          int      `json:"role"`                                            // legacy role, used when Roles is empty
+	Roles         []string `json:"roles" validate:"omitempty,dive,required,max=50"` // role names, e.g. ["admin"]
 }
 
 type UpdateAccountParams struct {
@@ -26,6 +29,18 @@ type UpdateAccountParams struct {
 	IsActive      bool   `json:"isActive"`
 }
 
+// NewUpdateAccountParams returns the update params matching the account's current state, the base for a PATCH
+func NewUpdateAccountParams(entity *account.AccountEntity) *UpdateAccountParams {
+	return &UpdateAccountParams{
+		Name:          entity.Name,
+		Email:         entity.Email.Value(),
+		EmailVerified: entity.EmailVerified,
+		Avatar:        entity.Avatar,
+		Bio:           entity.Bio,
+		IsActive:      entity.IsActive,
+	}
+}
+
 type DeleteAccountsQuery struct {
 	IDs []string `query:"ids"`
 }
@@ -43,6 +58,24 @@ type ManageAccountUseCase interface {
 	DeleteMultipleAccounts(ctx context.Context, query *DeleteAccountsQuery) (*types.DeletedResult, error)
 
 	FindAccountByID(ctx context.Context, id string) (*account.AccountEntity, error)
-	UpdateAccount(ctx context.Context, id string, params *UpdateAccountParams) (*account.AccountEntity, error)
+	UpdateAccount(ctx context.Context, id string, expectedVersion *int64, params *UpdateAccountParams) (*account.AccountEntity, error)
 	DeleteAccount(ctx context.Context, id string) (*types.DeletedResult, error)
 }
+
+// ================================== AssignAccountRoleUseCase =================================
+
+type AssignAccountRolesParams struct {
+	Roles []string `json:"roles" validate:"required,min=1,dive,required,max=50" example:"admin,editor"`
+}
+
+type AccountRolesResponse struct {
+	Roles       []*role.RoleEntity  `json:"roles"`
+	Permissions []values.Permission `json:"permissions"`
+}
+
+type AssignAccountRoleUseCase interface {
+	FindAccountRoles(ctx context.Context, accountID string) (*AccountRolesResponse, error)
+	AssignAccountRoles(ctx context.Context, accountID string, params *AssignAccountRolesParams) (*AccountRolesResponse, error)
+}
+
+// ================================== AssignAccountRoleUseCase =================================
@@ -1,6 +1,11 @@
 package usecases
 
-import "context"
+import (
+	"context"
+	"hinsun-backend/internal/domain/accesstoken"
+	"hinsun-backend/internal/domain/values"
+	"hinsun-backend/pkg/jwt"
+)
 
 type AuthEmailParams struct {
 	Email    string `json:"email" validate:"required,email" example:"example@example.com"`
@@ -8,8 +13,10 @@ type AuthEmailParams struct {
 }
 
 type AuthResponse struct {
-	AccessToken  string `json:"accessToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
-	RefreshToken string `json:"refreshToken" example:"dGhpcy1pcz1hLXJlZnJlc2gtdG9rZW4tZXhhbXBsZQ..."`
+	AccessToken       string `json:"accessToken,omitempty" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken      string `json:"refreshToken,omitempty" example:"dGhpcy1pcz1hLXJlZnJlc2gtdG9rZW4tZXhhbXBsZQ..."`
+	TwoFactorRequired bool   `json:"twoFactorRequired,omitempty" example:"false"`
+	ChallengeToken    string `json:"challengeToken,omitempty" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
 }
 
 type RefreshTokensParams struct {
@@ -21,3 +28,70 @@ type ManageSessionAuthUseCase interface {
 	RefreshTokens(ctx context.Context, params *RefreshTokensParams) (*AuthResponse, error)
 	SignOut(ctx context.Context) error
 }
+
+// ================================== TwoFactorAuthUseCase =================================
+
+type VerifyTwoFactorParams struct {
+	ChallengeToken string `json:"challengeToken" validate:"required"`
+	Code           string `json:"code" validate:"required_without=RecoveryCode,omitempty,len=6,numeric" example:"123456"`
+	RecoveryCode   string `json:"recoveryCode" validate:"required_without=Code,omitempty,len=11" example:"abcde-fghij"`
+}
+
+type TwoFactorCodeParams struct {
+	Code string `json:"code" validate:"required,len=6,numeric" example:"123456"`
+}
+
+type EnrollTwoFactorResponse struct {
+	Secret     string `json:"secret" example:"JBSWY3DPEHPK3PXP"`
+	OtpauthURI string `json:"otpauthUri" example:"otpauth://totp/Hinsun:example@example.com?secret=JBSWY3DPEHPK3PXP&issuer=Hinsun"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type TwoFactorAuthUseCase interface {
+	VerifyTwoFactor(ctx context.Context, params *VerifyTwoFactorParams) (*AuthResponse, error)
+	EnrollTwoFactor(ctx context.Context, accountID string) (*EnrollTwoFactorResponse, error)
+	ActivateTwoFactor(ctx context.Context, accountID string, params *TwoFactorCodeParams) (*RecoveryCodesResponse, error)
+	DisableTwoFactor(ctx context.Context, accountID string, params *TwoFactorCodeParams) error
+	RegenerateRecoveryCodes(ctx context.Context, accountID string, params *TwoFactorCodeParams) (*RecoveryCodesResponse, error)
+}
+
+// ================================== TwoFactorAuthUseCase =================================
+
+// ================================== SigningKeyUseCase =================================
+
+type RotateSigningKeysResponse struct {
+	AccessKeyID  string `json:"accessKeyId" example:"0190b3c1-7a2e-7d4f-9c1a-2b3c4d5e6f70"`
+	RefreshKeyID string `json:"refreshKeyId" example:"0190b3c1-7a2e-7d4f-9c1a-2b3c4d5e6f71"`
+}
+
+type SigningKeyUseCase interface {
+	RotateSigningKeys(ctx context.Context) (*RotateSigningKeysResponse, error)
+	FindPublicKeySet(ctx context.Context) (*jwt.JWKSet, error)
+}
+
+// ================================== SigningKeyUseCase =================================
+
+// ================================== AccessTokenUseCase =================================
+
+type CreateAccessTokenParams struct {
+	Name          string   `json:"name" validate:"required,max=100" example:"ci-publisher"`
+	Scopes        []string `json:"scopes" validate:"dive,required" example:"blog:write,project:write"`
+	ExpiresInDays int      `json:"expiresInDays" validate:"omitempty,min=1,max=365" example:"90"` // 0 never expires
+}
+
+type CreateAccessTokenResponse struct {
+	Token       string                         `json:"token" example:"hs_pat_0123456789abcdef_..."` // shown only once
+	AccessToken *accesstoken.AccessTokenEntity `json:"accessToken"`
+}
+
+type AccessTokenUseCase interface {
+	FindAccessTokens(ctx context.Context, actor *values.Actor) ([]*accesstoken.AccessTokenEntity, error)
+	CreateAccessToken(ctx context.Context, actor *values.Actor, params *CreateAccessTokenParams) (*CreateAccessTokenResponse, error)
+	RevokeAccessToken(ctx context.Context, actor *values.Actor, id string) (*accesstoken.AccessTokenEntity, error)
+	AuthenticateAccessToken(ctx context.Context, token string) (*jwt.Claims, error)
+}
+
+// ================================== AccessTokenUseCase =================================
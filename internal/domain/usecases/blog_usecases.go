@@ -34,6 +34,25 @@ type UpdateBlogParams struct {
 	EstimatedReadTimeSeconds int64                `json:"estimatedReadTimeSeconds" validate:"min=0"`
 }
 
+// NewUpdateBlogParams returns the update params matching the blog's current state, the base for a PATCH
+func NewUpdateBlogParams(entity *blog.BlogEntity) *UpdateBlogParams {
+	languages := make([]string, 0, len(entity.Languages))
+	for _, language := range entity.Languages {
+		languages = append(languages, string(language))
+	}
+
+	return &UpdateBlogParams{
+		AuthorID:                 entity.AuthorID,
+		Names:                    entity.Names,
+		Descriptions:             entity.Descriptions,
+		Categories:               entity.Categories,
+		Languages:                languages,
+		Markdowns:                entity.Markdowns,
+		IsPublished:              entity.IsPublished,
+		EstimatedReadTimeSeconds: entity.EstimatedReadTimeSeconds,
+	}
+}
+
 type DeleteBlogsQuery struct {
 	IDs []string `query:"ids"`
 }
@@ -44,12 +63,14 @@ type FindBlogsQuery struct {
 
 type ManageBlogUseCase interface {
 	FindBlogs(ctx context.Context, query *FindBlogsQuery) ([]*blog.BlogEntity, error)
-	CreateBlog(ctx context.Context, params *CreateBlogParams) (*blog.BlogEntity, error)
+	CreateBlog(ctx context.Context, actor *values.Actor, params *CreateBlogParams) (*blog.BlogEntity, error)
 	DeleteMultipleBlogs(ctx context.Context, query *DeleteBlogsQuery) (*types.DeletedResult, error)
 
 	FindBlog(ctx context.Context, id string) (*blog.BlogEntity, error)
-	UpdateBlog(ctx context.Context, id string, params *UpdateBlogParams) (*blog.BlogEntity, error)
-	DeleteBlog(ctx context.Context, id string) (*types.DeletedResult, error)
+	// FindBlogForUpdate reads past the cache, the base of a merge patch must carry the current version
+	FindBlogForUpdate(ctx context.Context, id string) (*blog.BlogEntity, error)
+	UpdateBlog(ctx context.Context, actor *values.Actor, id string, expectedVersion *int64, params *UpdateBlogParams) (*blog.BlogEntity, error)
+	DeleteBlog(ctx context.Context, actor *values.Actor, id string) (*types.DeletedResult, error)
 }
 
 // ================================== ManageBlogUseCase =================================
@@ -69,8 +90,8 @@ type CommentBlogUseCase interface {
 	FindAllCommentsOnBlog(ctx context.Context, blogId string) ([]*comment.CommentEntity, error)
 	AddCommentToBlog(ctx context.Context, blogId string, accountId string, params *AddCommentToBlogParams) (*comment.CommentEntity, error)
 
-	UpdateCommentOnBlog(ctx context.Context, blogId string, accountId string, commentId string, params *UpdateCommentOnBlogParams) (*comment.CommentEntity, error)
-	DeleteCommentOnBlog(ctx context.Context, blogId string, accountId string, commentId string) (*types.DeletedResult, error)
+	UpdateCommentOnBlog(ctx context.Context, blogId string, accountId string, commentId string, expectedVersion *int64, params *UpdateCommentOnBlogParams) (*comment.CommentEntity, error)
+	DeleteCommentOnBlog(ctx context.Context, blogId string, actor *values.Actor, commentId string) (*types.DeletedResult, error)
 }
 
 // ================================== CommentBlogUseCase =================================
@@ -22,7 +22,7 @@ type ManageCategoryUseCase interface {
 	FindCategories(ctx context.Context) ([]*category.CategoryEntity, error)
 	FindCategory(ctx context.Context, id string) (*category.CategoryEntity, error)
 	CreateCategory(ctx context.Context, params *CreateCategoryParams) (*category.CategoryEntity, error)
-	UpdateCategory(ctx context.Context, id string, params *UpdateCategoryParams) (*category.CategoryEntity, error)
+	UpdateCategory(ctx context.Context, id string, expectedVersion *int64, params *UpdateCategoryParams) (*category.CategoryEntity, error)
 	DeleteCategory(ctx context.Context, id string) (*types.DeletedResult, error)
 	DeleteMultipleCategories(ctx context.Context, query *DeleteCategoriesQuery) (*types.DeletedResult, error)
 }
@@ -31,7 +31,7 @@ type ManageCommentUseCase interface {
 	FindComments(ctx context.Context) ([]*comment.CommentEntity, error)
 
 	DeleteComment(ctx context.Context, id string) (*types.DeletedResult, error)
-	UpdateComment(ctx context.Context, id string, params *UpdateCommentParams) (*comment.CommentEntity, error)
+	UpdateComment(ctx context.Context, id string, expectedVersion *int64, params *UpdateCommentParams) (*comment.CommentEntity, error)
 	FindComment(ctx context.Context, id string) (*comment.CommentEntity, error)
 }
 
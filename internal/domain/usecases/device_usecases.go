@@ -0,0 +1,18 @@
+package usecases
+
+import (
+	"context"
+	"hinsun-backend/internal/domain/devicetoken"
+	"hinsun-backend/internal/domain/values"
+)
+
+type RegisterDeviceTokenParams struct {
+	Token    string `json:"token" validate:"required,max=4096" example:"fcm-registration-token"`
+	Platform string `json:"platform" validate:"required,oneof=android ios web" example:"android"`
+}
+
+type ManageDeviceTokenUseCase interface {
+	FindDeviceTokens(ctx context.Context, actor *values.Actor) ([]*devicetoken.DeviceTokenEntity, error)
+	RegisterDeviceToken(ctx context.Context, actor *values.Actor, params *RegisterDeviceTokenParams) (*devicetoken.DeviceTokenEntity, error)
+	UnregisterDeviceToken(ctx context.Context, actor *values.Actor, id string) error
+}
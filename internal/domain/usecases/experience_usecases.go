@@ -4,26 +4,56 @@ import (
 	"context"
 	"hinsun-backend/internal/core/types"
 	"hinsun-backend/internal/domain/experience"
+	"hinsun-backend/internal/domain/values"
 )
 
 type CreateExperienceParams struct {
-	OrderIdx         int8     `json:"orderIdx" validate:"required,min=0,max=100"`
-	Position         string   `json:"position" validate:"required,min=2,max=100"`
-	Company          string   `json:"company" validate:"required,min=2,max=100"`
-	Location         string   `json:"location" validate:"required,min=2,max=100"`
-	Period           string   `json:"period" validate:"required,min=2,max=100"`
-	Technologies     []string `json:"technologies" validate:"required,dive,min=1,max=50"`
-	Responsibilities []string `json:"responsibilities" validate:"required,dive,min=5,max=500"`
+	OrderIdx         *int                 `json:"orderIdx,omitempty" validate:"omitempty,min=0"` // nil places the experience after the others
+	Languages        []string             `json:"languages" validate:"required,min=1,max=10,dive,min=2,max=3"`
+	Positions        values.MultiLangText `json:"positions" validate:"required,dive,min=2,max=100"`
+	Companies        values.MultiLangText `json:"companies" validate:"required,dive,min=2,max=100"`
+	Location         string               `json:"location" validate:"required,min=2,max=100"`
+	StartDate        string               `json:"startDate" validate:"required,datetime=2006-01-02"`
+	EndDate          *string              `json:"endDate" validate:"omitempty,datetime=2006-01-02"` // nil while the experience is current
+	Technologies     []string             `json:"technologies" validate:"required,dive,min=1,max=50"`
+	Responsibilities values.MultiLangList `json:"responsibilities" validate:"required,dive,dive,min=5,max=500"`
 }
 
 type UpdateExperienceParams struct {
-	OrderIdx         int8     `json:"orderIdx" validate:"required,min=0,max=100"`
-	Position         string   `json:"position" validate:"required,min=2,max=100"`
-	Company          string   `json:"company" validate:"required,min=2,max=100"`
-	Location         string   `json:"location" validate:"required,min=2,max=100"`
-	Period           string   `json:"period" validate:"required,min=2,max=100"`
-	Technologies     []string `json:"technologies" validate:"required,dive,min=1,max=50"`
-	Responsibilities []string `json:"responsibilities" validate:"required,dive,min=5,max=500"`
+	OrderIdx         int                  `json:"orderIdx" validate:"min=0"`
+	Languages        []string             `json:"languages" validate:"required,min=1,max=10,dive,min=2,max=3"`
+	Positions        values.MultiLangText `json:"positions" validate:"required,dive,min=2,max=100"`
+	Companies        values.MultiLangText `json:"companies" validate:"required,dive,min=2,max=100"`
+	Location         string               `json:"location" validate:"required,min=2,max=100"`
+	StartDate        string               `json:"startDate" validate:"required,datetime=2006-01-02"`
+	EndDate          *string              `json:"endDate" validate:"omitempty,datetime=2006-01-02"` // nil while the experience is current
+	Technologies     []string             `json:"technologies" validate:"required,dive,min=1,max=50"`
+	Responsibilities values.MultiLangList `json:"responsibilities" validate:"required,dive,dive,min=5,max=500"`
+}
+
+// NewUpdateExperienceParams returns the update params matching the experience's current state, the base for a PATCH
+func NewUpdateExperienceParams(entity *experience.ExperienceEntity) *UpdateExperienceParams {
+	var endDate *string
+	if entity.EndDate != nil {
+		value := entity.EndDate.String()
+		endDate = &value
+	}
+
+	return &UpdateExperienceParams{
+		OrderIdx:         entity.OrderIdx,
+		Languages:        values.ConvertMarkdownLanguageCodesToStringArray(entity.Languages),
+		Positions:        entity.Positions,
+		Companies:        entity.Companies,
+		Location:         entity.Location,
+		StartDate:        entity.StartDate.String(),
+		EndDate:          endDate,
+		Technologies:     entity.Technologies,
+		Responsibilities: entity.Responsibilities,
+	}
+}
+
+type ReorderExperiencesParams struct {
+	IDs []string `json:"ids" validate:"required,min=1,dive,uuid"` // every experience, in the new order
 }
 
 type DeleteExperiencesQuery struct {
@@ -32,9 +62,12 @@ type DeleteExperiencesQuery struct {
 
 type ManageExperienceUseCase interface {
 	FindExperience(ctx context.Context, id string) (*experience.ExperienceEntity, error)
+	// FindExperienceForUpdate reads past the cache, the base of a merge patch must carry the current version
+	FindExperienceForUpdate(ctx context.Context, id string) (*experience.ExperienceEntity, error)
 	FindExperiences(ctx context.Context) ([]*experience.ExperienceEntity, error)
 	CreateExperience(ctx context.Context, params *CreateExperienceParams) (*experience.ExperienceEntity, error)
-	UpdateExperience(ctx context.Context, id string, params *UpdateExperienceParams) (*experience.ExperienceEntity, error)
+	UpdateExperience(ctx context.Context, id string, expectedVersion *int64, params *UpdateExperienceParams) (*experience.ExperienceEntity, error)
+	ReorderExperiences(ctx context.Context, params *ReorderExperiencesParams) ([]*experience.ExperienceEntity, error)
 	DeleteExperience(ctx context.Context, id string) (*types.DeletedResult, error)
 	DeleteMultipleExperiences(ctx context.Context, query *DeleteExperiencesQuery) (*types.DeletedResult, error)
 }
@@ -0,0 +1,16 @@
+package usecases
+
+import (
+	"context"
+	"hinsun-backend/internal/domain/media"
+	"hinsun-backend/internal/domain/values"
+	"io"
+)
+
+type ManageMediaUseCase interface {
+	FindMedia(ctx context.Context, actor *values.Actor) ([]*media.MediaEntity, error)
+	// UploadMedia returns false when the caller had already uploaded the same content
+	UploadMedia(ctx context.Context, actor *values.Actor, content []byte) (*media.MediaEntity, bool, error)
+	DeleteMedia(ctx context.Context, actor *values.Actor, id string) error
+	OpenMediaFile(ctx context.Context, key string) (io.ReadCloser, error)
+}
@@ -1,7 +1,21 @@
 package usecases
 
-import "hinsun-backend/internal/domain/notification"
+import (
+	"context"
+	"hinsun-backend/internal/domain/notification"
+	"hinsun-backend/internal/domain/values"
+)
+
+type UpdateNotificationPreferenceParams struct {
+	Language   string                   `json:"language" validate:"required" example:"en"`
+	Channels   map[string][]string      `json:"channels" validate:"required" example:"new_blog:in_app,push"` // event type -> channels, missing types receive nothing
+	QuietHours *notification.QuietHours `json:"quietHours"`                                                  // null disables quiet hours
+	WebhookURL string                   `json:"webhookUrl" validate:"omitempty,url,max=2048" example:"https://example.com/hooks/hinsun"`
+}
 
 type ManageNotificationUseCase interface {
-	FindNotificationsByAccountID(accountID string) ([]*notification.NotificationEntity, error)
+	FindNotifications(ctx context.Context, actor *values.Actor) ([]*notification.NotificationEntity, error)
+	MarkNotificationRead(ctx context.Context, actor *values.Actor, id string) (*notification.NotificationEntity, error)
+	FindNotificationPreference(ctx context.Context, actor *values.Actor) (*notification.PreferenceEntity, error)
+	UpdateNotificationPreference(ctx context.Context, actor *values.Actor, params *UpdateNotificationPreferenceParams) (*notification.PreferenceEntity, error)
 }
@@ -4,35 +4,70 @@ import (
 	"context"
 	"hinsun-backend/internal/core/types"
 	"hinsun-backend/internal/domain/project"
+	"hinsun-backend/internal/domain/values"
 )
 
 type CreateProjectParams struct {
-	Name        string   `json:"name" validate:"required,min=2,max=100"`
-	Description string   `json:"description" validate:"required,min=10,max=500"`
-	Github      string   `json:"github" validate:"required,url"`
-	Cover       string   `json:"cover" validate:"omitempty,url"`
-	Tags        []string `json:"tags" validate:"required,min=1,max=5,dive,min=2,max=50"`
-	Markdown    string   `json:"markdown" validate:"required,min=10"`
+	Languages    []string             `json:"languages" validate:"required,min=1,max=10,dive,min=2,max=3"`
+	Names        values.MultiLangText `json:"names" validate:"required,dive,min=2,max=100"`
+	Descriptions values.MultiLangText `json:"descriptions" validate:"required,dive,min=10,max=500"`
+	Github       string               `json:"github" validate:"required,url"`
+	Cover        string               `json:"cover" validate:"omitempty,url"`
+	Tags         []string             `json:"tags" validate:"required,min=1,max=5,dive,min=2,max=50"`
+	Markdowns    values.MultiLangText `json:"markdowns" validate:"required,dive,min=10"`
 }
 
 type UpdateProjectParams struct {
-	Name        string   `json:"name" validate:"required,min=2,max=100"`
-	Description string   `json:"description" validate:"required,min=10,max=500"`
-	Github      string   `json:"github" validate:"required,url"`
-	Cover       string   `json:"cover" validate:"omitempty,url"`
-	Tags        []string `json:"tags" validate:"required,min=1,max=5,dive,min=2,max=50"`
-	Markdown    string   `json:"markdown" validate:"required,min=10"`
+	Languages    []string             `json:"languages" validate:"required,min=1,max=10,dive,min=2,max=3"`
+	Names        values.MultiLangText `json:"names" validate:"required,dive,min=2,max=100"`
+	Descriptions values.MultiLangText `json:"descriptions" validate:"required,dive,min=10,max=500"`
+	Github       string               `json:"github" validate:"required,url"`
+	Cover        string               `json:"cover" validate:"omitempty,url"`
+	Tags         []string             `json:"tags" validate:"required,min=1,max=5,dive,min=2,max=50"`
+	Markdowns    values.MultiLangText `json:"markdowns" validate:"required,dive,min=10"`
+}
+
+// NewUpdateProjectParams returns the update params matching the project's current state, the base for a PATCH
+func NewUpdateProjectParams(entity *project.ProjectEntity) *UpdateProjectParams {
+	return &UpdateProjectParams{
+		Languages:    values.ConvertMarkdownLanguageCodesToStringArray(entity.Languages),
+		Names:        entity.Names,
+		Descriptions: entity.Descriptions,
+		Github:       entity.Github,
+		Cover:        entity.Cover,
+		Tags:         entity.Tags,
+		Markdowns:    entity.Markdowns,
+	}
+}
+
+type FindProjectsQuery struct {
+	Tag      string `query:"tag"`                                         // only the projects with this tag
+	Featured bool   `query:"featured"`                                    // only the featured projects
+	Page     int    `query:"page" validate:"omitempty,min=1"`             // starts at 1
+	PageSize int    `query:"pageSize" validate:"omitempty,min=1,max=100"` // defaults to 20
+}
+
+type FeatureProjectsParams struct {
+	IDs []string `json:"ids" validate:"required,dive,uuid"` // the featured projects in their order, empty unfeatures all
 }
 
 type DeleteProjectsQuery struct {
 	IDs []string `query:"ids"`
 }
 
+type RefreshProjectRepositoryQuery struct {
+	Readme string `query:"readme" validate:"omitempty,min=2,max=3"` // language whose markdown is replaced with the README, none keeps the markdowns
+}
+
 type ManageProjectUseCase interface {
 	FindProject(ctx context.Context, id string) (*project.ProjectEntity, error)
-	FindProjects(ctx context.Context) ([]*project.ProjectEntity, error)
+	// FindProjectForUpdate reads past the cache, the base of a merge patch must carry the current version
+	FindProjectForUpdate(ctx context.Context, id string) (*project.ProjectEntity, error)
+	FindProjects(ctx context.Context, query *FindProjectsQuery) ([]*project.ProjectEntity, *types.PageMeta, error)
 	CreateProject(ctx context.Context, params *CreateProjectParams) (*project.ProjectEntity, error)
-	UpdateProject(ctx context.Context, id string, params *UpdateProjectParams) (*project.ProjectEntity, error)
+	UpdateProject(ctx context.Context, id string, expectedVersion *int64, params *UpdateProjectParams) (*project.ProjectEntity, error)
+	FeatureProjects(ctx context.Context, params *FeatureProjectsParams) ([]*project.ProjectEntity, error)
 	DeleteProject(ctx context.Context, id string) (*types.DeletedResult, error)
 	DeleteMultipleProjects(ctx context.Context, query *DeleteProjectsQuery) (*types.DeletedResult, error)
+	RefreshProjectRepository(ctx context.Context, id string, query *RefreshProjectRepositoryQuery) (*project.ProjectEntity, error)
 }
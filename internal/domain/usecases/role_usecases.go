@@ -0,0 +1,28 @@
+package usecases
+
+import (
+	"context"
+	"hinsun-backend/internal/core/types"
+	"hinsun-backend/internal/domain/role"
+	"hinsun-backend/internal/domain/values"
+)
+
+type CreateRoleParams struct {
+	Name        string   `json:"name" validate:"required,min=2,max=50" example:"editor"`
+	Description string   `json:"description" validate:"max=255" example:"Writes and publishes blogs"`
+	Permissions []string `json:"permissions" validate:"dive,required" example:"blog:write,blog:publish"`
+}
+
+type UpdateRoleParams struct {
+	Description string   `json:"description" validate:"max=255" example:"Writes and publishes blogs"`
+	Permissions []string `json:"permissions" validate:"dive,required" example:"blog:write,blog:publish"`
+}
+
+type ManageRoleUseCase interface {
+	FindRoles(ctx context.Context) ([]*role.RoleEntity, error)
+	FindPermissions(ctx context.Context) ([]values.Permission, error)
+	FindRole(ctx context.Context, id string) (*role.RoleEntity, error)
+	CreateRole(ctx context.Context, params *CreateRoleParams) (*role.RoleEntity, error)
+	UpdateRole(ctx context.Context, id string, params *UpdateRoleParams) (*role.RoleEntity, error)
+	DeleteRole(ctx context.Context, id string) (*types.DeletedResult, error)
+}
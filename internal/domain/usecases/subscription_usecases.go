@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"context"
+	"hinsun-backend/internal/domain/subscription"
+	"hinsun-backend/internal/domain/values"
+
+	"github.com/google/uuid"
+)
+
+type FollowParams struct {
+	TargetType string    `json:"targetType" validate:"required,oneof=category author" example:"category"`
+	TargetID   uuid.UUID `json:"targetId" validate:"required"`
+}
+
+type SubscribeByEmailParams struct {
+	Email      string    `json:"email" validate:"required,email,max=254" example:"reader@example.com"`
+	TargetType string    `json:"targetType" validate:"required,oneof=category author" example:"category"`
+	TargetID   uuid.UUID `json:"targetId" validate:"required"`
+}
+
+type SubscriptionTokenParams struct {
+	Token string `json:"token" validate:"required,len=64,hexadecimal"`
+}
+
+type ManageFollowUseCase interface {
+	FindFollows(ctx context.Context, actor *values.Actor) ([]*subscription.FollowEntity, error)
+	Follow(ctx context.Context, actor *values.Actor, params *FollowParams) (*subscription.FollowEntity, error)
+	Unfollow(ctx context.Context, actor *values.Actor, id string) error
+}
+
+type EmailSubscriptionUseCase interface {
+	SubscribeByEmail(ctx context.Context, params *SubscribeByEmailParams) error
+	ConfirmEmailSubscription(ctx context.Context, params *SubscriptionTokenParams) (*subscription.EmailSubscriptionEntity, error)
+	UnsubscribeEmail(ctx context.Context, params *SubscriptionTokenParams) error
+}
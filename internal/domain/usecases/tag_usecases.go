@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"context"
+	"hinsun-backend/internal/core/types"
+	"hinsun-backend/internal/domain/tag"
+)
+
+type CreateTagParams struct {
+	Name string `json:"name" validate:"required,min=2,max=50"`
+}
+
+type UpdateTagParams struct {
+	Name string `json:"name" validate:"required,min=2,max=50"`
+}
+
+type FindTagsQuery struct {
+	Sort string `query:"sort" validate:"omitempty,oneof=name usage"` // usage puts the most used tags first, for a tag cloud
+}
+
+type DeleteTagsQuery struct {
+	IDs []string `query:"ids"`
+}
+
+type ManageTagUseCase interface {
+	FindTags(ctx context.Context, query *FindTagsQuery) ([]*tag.TagEntity, error)
+	FindTag(ctx context.Context, id string) (*tag.TagEntity, error)
+	CreateTag(ctx context.Context, params *CreateTagParams) (*tag.TagEntity, error)
+	UpdateTag(ctx context.Context, id string, expectedVersion *int64, params *UpdateTagParams) (*tag.TagEntity, error)
+	DeleteTag(ctx context.Context, id string) (*types.DeletedResult, error)
+	DeleteMultipleTags(ctx context.Context, query *DeleteTagsQuery) (*types.DeletedResult, error)
+}
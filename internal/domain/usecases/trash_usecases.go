@@ -0,0 +1,20 @@
+package usecases
+
+import (
+	"context"
+	"hinsun-backend/internal/domain/trash"
+)
+
+type FindTrashQuery struct {
+	Resource string `query:"resource"` // empty lists every resource
+}
+
+type RestoredItemResponse struct {
+	ID       string         `json:"id" example:"0190b3c1-7a2e-7d4f-9c1a-2b3c4d5e6f70"`
+	Resource trash.Resource `json:"resource" example:"blogs"`
+}
+
+type ManageTrashUseCase interface {
+	FindTrash(ctx context.Context, query *FindTrashQuery) ([]*trash.TrashItemEntity, error)
+	RestoreItem(ctx context.Context, resource string, id string) (*RestoredItemResponse, error)
+}
@@ -0,0 +1,65 @@
+package values
+
+import (
+	"encoding/json"
+	"fmt"
+	"hinsun-backend/internal/core/failure"
+	"time"
+)
+
+// DateLayout is the format of a date in JSON and in request params
+const DateLayout = time.DateOnly
+
+// Date is a calendar day without time of day nor time zone, written as 2006-01-02
+type Date struct {
+	time.Time
+}
+
+// NewDate truncates a time to its calendar day
+func NewDate(t time.Time) Date {
+	return Date{Time: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+// Today returns the current day
+func Today() Date {
+	return NewDate(time.Now())
+}
+
+func ParseDate(value string) (Date, error) {
+	t, err := time.Parse(DateLayout, value)
+	if err != nil {
+		return Date{}, failure.NewValidationFailure(fmt.Sprintf("invalid date '%s': expected format %s", value, DateLayout))
+	}
+
+	return Date{Time: t}, nil
+}
+
+func (d Date) String() string {
+	return d.Format(DateLayout)
+}
+
+// MonthsUntil counts the calendar months from d to end, both included, so
+// January to March is 3 months. An end before d gives 0.
+func (d Date) MonthsUntil(end Date) int {
+	months := (end.Year()-d.Year())*12 + int(end.Month()-d.Month()) + 1
+	return max(months, 0)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	parsed, err := ParseDate(value)
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+	return nil
+}
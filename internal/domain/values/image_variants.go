@@ -0,0 +1,4 @@
+package values
+
+// ImageVariants lists the resized copies of an uploaded image, e.g. for an img srcset
+type ImageVariants map[string]string // key: variant name (thumbnail, card, full), value: URL
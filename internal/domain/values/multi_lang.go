@@ -1,4 +1,131 @@
 package values
 
+import (
+	"fmt"
+	"hinsun-backend/internal/core/failure"
+)
+
+const (
+	MaxLanguages = 10
+	MinLanguages = 1
+)
+
 // MultiLangText represents text content in multiple languages
 type MultiLangText map[MarkdownLanguageCode]string // key: language code (vi, en, etc.), value: content
+
+// MultiLangList represents a list of texts in multiple languages, e.g. bullet points
+type MultiLangList map[MarkdownLanguageCode][]string // key: language code (vi, en, etc.), value: items
+
+// Pick returns the text in the first of the languages it has, or "" when it has none of them
+func (t MultiLangText) Pick(languages ...MarkdownLanguageCode) string {
+	for _, language := range languages {
+		if text, ok := t[language]; ok {
+			return text
+		}
+	}
+
+	return ""
+}
+
+// Only keeps the text of a single language
+func (t MultiLangText) Only(language MarkdownLanguageCode) MultiLangText {
+	if text, ok := t[language]; ok {
+		return MultiLangText{language: text}
+	}
+
+	return MultiLangText{}
+}
+
+// Only keeps the items of a single language
+func (l MultiLangList) Only(language MarkdownLanguageCode) MultiLangList {
+	if items, ok := l[language]; ok {
+		return MultiLangList{language: items}
+	}
+
+	return MultiLangList{}
+}
+
+// NegotiateLanguage returns the first preferred language the content is available in, falling
+// back to the first available one, which is the main language of the content
+func NegotiateLanguage(preferred, available []MarkdownLanguageCode) MarkdownLanguageCode {
+	for _, language := range preferred {
+		for _, candidate := range available {
+			if language == candidate {
+				return candidate
+			}
+		}
+	}
+
+	if len(available) == 0 {
+		return ""
+	}
+
+	return available[0]
+}
+
+func ValidateLanguages(languages []MarkdownLanguageCode) error {
+	if len(languages) < MinLanguages {
+		return failure.NewValidationFailure(
+			fmt.Sprintf("at least %d language is required", MinLanguages),
+		)
+	}
+
+	if len(languages) > MaxLanguages {
+		return failure.NewValidationFailure(
+			fmt.Sprintf("number of languages exceeds maximum of %d", MaxLanguages),
+		)
+	}
+
+	// Check for duplicates
+	seen := make(map[MarkdownLanguageCode]bool)
+	for _, lang := range languages {
+		if seen[lang] {
+			return failure.NewValidationFailure(fmt.Sprintf("duplicate language code: %s", lang))
+		}
+
+		seen[lang] = true
+
+		// Validate language code format (should be 2-3 lowercase letters)
+		if len(lang) < 2 || len(lang) > 3 {
+			return failure.NewValidationFailure(
+				fmt.Sprintf("invalid language code '%s': must be 2-3 characters", lang),
+			)
+		}
+	}
+
+	return nil
+}
+
+func ValidateMultiLangContent(languages []MarkdownLanguageCode, content MultiLangText, fieldName string) error {
+	if content == nil {
+		return failure.NewValidationFailure(fmt.Sprintf("%s cannot be nil", fieldName))
+	}
+
+	// Check that all languages have content
+	for _, lang := range languages {
+		if text, exists := content[lang]; !exists || text == "" {
+			return failure.NewValidationFailure(
+				fmt.Sprintf("%s is missing content for language: %s", fieldName, lang),
+			)
+		}
+	}
+
+	return nil
+}
+
+// ValidateMultiLangList checks that every language has its list, a list may be empty
+func ValidateMultiLangList(languages []MarkdownLanguageCode, content MultiLangList, fieldName string) error {
+	if content == nil {
+		return failure.NewValidationFailure(fmt.Sprintf("%s cannot be nil", fieldName))
+	}
+
+	for _, lang := range languages {
+		if _, exists := content[lang]; !exists {
+			return failure.NewValidationFailure(
+				fmt.Sprintf("%s is missing content for language: %s", fieldName, lang),
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package values
+
+import (
+	"fmt"
+	"hinsun-backend/internal/core/failure"
+	"slices"
+)
+
+// Permission is a named capability in the form <resource>:<action>
+type Permission string
+
+const (
+	BlogWritePermission       Permission = "blog:write"       // create blogs and edit own blogs
+	BlogPublishPermission     Permission = "blog:publish"     // publish or unpublish blogs
+	BlogManagePermission      Permission = "blog:manage"      // edit and delete blogs of any author
+	CommentModeratePermission Permission = "comment:moderate" // moderate and delete comments of any author
+	CategoryWritePermission   Permission = "category:write"
+	ProjectWritePermission    Permission = "project:write"
+	ExperienceWritePermission Permission = "experience:write"
+	AccountManagePermission   Permission = "account:manage"
+	RoleManagePermission      Permission = "role:manage"
+	SystemKeysPermission      Permission = "system:keys"  // rotate token signing keys
+	TrashManagePermission     Permission = "trash:manage" // list and restore soft-deleted items
+	MediaWritePermission      Permission = "media:write"  // upload files to the storage
+)
+
+// AllPermissions returns every permission known to the system
+func AllPermissions() []Permission {
+	return []Permission{
+		BlogWritePermission,
+		BlogPublishPermission,
+		BlogManagePermission,
+		CommentModeratePermission,
+		CategoryWritePermission,
+		ProjectWritePermission,
+		ExperienceWritePermission,
+		AccountManagePermission,
+		RoleManagePermission,
+		SystemKeysPermission,
+		TrashManagePermission,
+		MediaWritePermission,
+	}
+}
+
+func PermissionFromString(permission string) (Permission, error) {
+	if !slices.Contains(AllPermissions(), Permission(permission)) {
+		return "", failure.NewValidationFailure(fmt.Sprintf("Invalid permission: %s", permission))
+	}
+
+	return Permission(permission), nil
+}
+
+// ConvertStringArrayToPermissions validates and de-duplicates the given permissions
+func ConvertStringArrayToPermissions(permissions []string) ([]Permission, error) {
+	result := make([]Permission, 0, len(permissions))
+	for _, p := range permissions {
+		permission, err := PermissionFromString(p)
+		if err != nil {
+			return nil, err
+		}
+
+		if !slices.Contains(result, permission) {
+			result = append(result, permission)
+		}
+	}
+
+	return result, nil
+}
+
+// Actor is the authenticated caller of a use case, used for resource-level authorization
+type Actor struct {
+	AccountID     string
+	Permissions   []Permission
+	TwoFactor     bool   // the session passed a second factor
+	AccessTokenID string // set when the caller authenticated with a personal access token
+}
+
+func NewActor(accountID string, permissions []string) *Actor {
+	actor := &Actor{AccountID: accountID}
+	for _, p := range permissions {
+		actor.Permissions = append(actor.Permissions, Permission(p))
+	}
+
+	return actor
+}
+
+// Can reports whether the actor holds the permission
+func (a *Actor) Can(permission Permission) bool {
+	return a != nil && slices.Contains(a.Permissions, permission)
+}
+
+// Owns reports whether the actor is the given account
+func (a *Actor) Owns(accountID string) bool {
+	return a != nil && a.AccountID == accountID
+}
@@ -0,0 +1,13 @@
+package values
+
+import "hinsun-backend/internal/core/failure"
+
+// CheckVersion rejects a write based on a version other than the current one.
+// A nil expected version, i.e. no If-Match from the client, skips the check.
+func CheckVersion(currentVersion int64, expectedVersion *int64) error {
+	if expectedVersion != nil && *expectedVersion != currentVersion {
+		return failure.NewVersionConflictFailure(currentVersion)
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package firebase
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// SentPush is a notification recorded by FakeFCMService, Token or Topic is set depending on
+// how it was sent
+type SentPush struct {
+	Token        string
+	Topic        string
+	Notification PushNotification
+}
+
+// FakeFCMService records pushes in memory instead of sending them so tests can inspect them, it
+// never forgets a push and is not meant for a running server (see NewNoopFCMService)
+type FakeFCMService struct {
+	mu            sync.Mutex
+	sent          []SentPush
+	invalidTokens map[string]bool
+	// unavailable tokens fail without being reported invalid, like FCM outages or quota errors
+	unavailableTokens map[string]bool
+}
+
+func NewFakeFCMService() *FakeFCMService {
+	return &FakeFCMService{
+		invalidTokens:     make(map[string]bool),
+		unavailableTokens: make(map[string]bool),
+	}
+}
+
+// MarkInvalid makes the fake reject the given tokens the way FCM rejects unregistered ones
+func (s *FakeFCMService) MarkInvalid(tokens ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, token := range tokens {
+		s.invalidTokens[token] = true
+	}
+}
+
+// MarkUnavailable makes sends to the given tokens fail with a transient error, the tokens stay valid
+func (s *FakeFCMService) MarkUnavailable(tokens ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, token := range tokens {
+		s.unavailableTokens[token] = true
+	}
+}
+
+// Sent returns a copy of every push delivered so far
+func (s *FakeFCMService) Sent() []SentPush {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]SentPush(nil), s.sent...)
+}
+
+// Reset forgets the recorded pushes and the invalid and unavailable tokens
+func (s *FakeFCMService) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sent = nil
+	s.invalidTokens = make(map[string]bool)
+	s.unavailableTokens = make(map[string]bool)
+}
+
+func (s *FakeFCMService) SendNotification(ctx context.Context, token string, notification *PushNotification) error {
+	result, err := s.SendMulticast(ctx, []string{token}, notification)
+	if err != nil {
+		return err
+	}
+
+	if len(result.InvalidTokens) > 0 {
+		return ErrFakeTokenNotRegistered
+	}
+
+	if result.FailureCount > 0 {
+		return ErrFakeUnavailable
+	}
+
+	return nil
+}
+
+func (s *FakeFCMService) SendMulticast(ctx context.Context, tokens []string, notification *PushNotification) (*MulticastResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := &MulticastResult{}
+	for _, token := range tokens {
+		if s.invalidTokens[token] {
+			result.FailureCount++
+			result.InvalidTokens = append(result.InvalidTokens, token)
+			continue
+		}
+
+		if s.unavailableTokens[token] {
+			result.FailureCount++
+			continue
+		}
+
+		result.SuccessCount++
+		s.sent = append(s.sent, SentPush{Token: token, Notification: *notification})
+	}
+
+	return result, nil
+}
+
+func (s *FakeFCMService) SendToTopic(ctx context.Context, topic string, notification *PushNotification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sent = append(s.sent, SentPush{Topic: topic, Notification: *notification})
+	return nil
+}
+
+// ErrFakeTokenNotRegistered is returned by FakeFCMService.SendNotification for tokens marked invalid
+var ErrFakeTokenNotRegistered = errors.New("registration token is not registered")
+
+// ErrFakeUnavailable is returned by FakeFCMService.SendNotification for tokens marked unavailable
+var ErrFakeUnavailable = errors.New("the service is currently unavailable")
@@ -17,9 +17,20 @@ type PushNotification struct {
 	Priority string
 }
 
+// MulticastResult reports the outcome of a multicast send
+type MulticastResult struct {
+	SuccessCount int
+	FailureCount int
+	// InvalidTokens were rejected by FCM as unregistered or malformed and should not be used again
+	InvalidTokens []string
+}
+
+// maxMulticastTokens is the number of tokens FCM accepts in one multicast message
+const maxMulticastTokens = 500
+
 type FCMService interface {
 	SendNotification(ctx context.Context, token string, notification *PushNotification) error
-	SendMulticast(ctx context.Context, tokens []string, notification *PushNotification) error
+	SendMulticast(ctx context.Context, tokens []string, notification *PushNotification) (*MulticastResult, error)
 	SendToTopic(ctx context.Context, topic string, notification *PushNotification) error
 }
 
@@ -59,34 +70,57 @@ func (s *fcmService) SendNotification(ctx context.Context, token string, notific
 	return nil
 }
 
-func (s *fcmService) SendMulticast(ctx context.Context, tokens []string, notification *PushNotification) error {
+func (s *fcmService) SendMulticast(ctx context.Context, tokens []string, notification *PushNotification) (*MulticastResult, error) {
+	result := &MulticastResult{}
 	if len(tokens) == 0 {
 		s.logger.Warn("No tokens provided for multicast")
-		return nil
+		return result, nil
 	}
 
-	message := s.buildMulticastMessage(tokens, notification)
-	response, err := s.client.SendMulticast(ctx, message)
-	if err != nil {
-		s.logger.Error("Failed to send multicast notification", zap.Error(err))
-		return fmt.Errorf("failed to send multicast: %w", err)
-	}
+	for start := 0; start < len(tokens); start += maxMulticastTokens {
+		batch := tokens[start:min(start+maxMulticastTokens, len(tokens))]
+
+		message := s.buildMulticastMessage(batch, notification)
+		response, err := s.client.SendMulticast(ctx, message)
+		if err != nil {
+			s.logger.Error("Failed to send multicast notification", zap.Error(err))
+			return result, fmt.Errorf("failed to send multicast: %w", err)
+		}
+
+		s.logger.Info("Multicast notification sent",
+			zap.Int("success_count", response.SuccessCount),
+			zap.Int("failure_count", response.FailureCount))
 
-	s.logger.Info("Multicast notification sent",
-		zap.Int("success_count", response.SuccessCount),
-		zap.Int("failure_count", response.FailureCount))
+		result.SuccessCount += response.SuccessCount
+		result.FailureCount += response.FailureCount
 
-	if response.FailureCount > 0 {
+		// Responses are in the order of the tokens in the message
 		for idx, resp := range response.Responses {
-			if !resp.Success {
-				s.logger.Warn("Failed to send to token",
-					zap.String("token", tokens[idx]),
-					zap.String("error", resp.Error.Error()))
+			if resp.Success {
+				continue
 			}
+
+			if messaging.IsRegistrationTokenNotRegistered(resp.Error) || messaging.IsInvalidArgument(resp.Error) {
+				result.InvalidTokens = append(result.InvalidTokens, batch[idx])
+			}
+
+			s.logger.Warn("Failed to send to token",
+				zap.String("token", batch[idx]),
+				zap.String("error", resp.Error.Error()))
 		}
 	}
 
-	return nil
+	return result, nil
+}
+
+// readinessTopic receives the dry-run message of Ping, nothing is delivered to it
+const readinessTopic = "readiness"
+
+// Ping validates a message against FCM without delivering it, which checks the credentials and
+// that FCM is reachable
+func (s *fcmService) Ping(ctx context.Context) error {
+	_, err := s.client.SendDryRun(ctx, &messaging.Message{Topic: readinessTopic})
+	return err
 }
 
 func (s *fcmService) SendToTopic(ctx context.Context, topic string, notification *PushNotification) error {
@@ -5,21 +5,28 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 
 	googleFirebase "firebase.google.com/go"
 
 	"google.golang.org/api/option"
 )
 
-func NewFirebase(ctx context.Context) (*googleFirebase.App, error) {
-	rootPath, err := os.Getwd()
-	if err != nil {
-		log.Fatal("Failed to get working directory:", err)
+// NewFirebase initializes the Firebase app from a service account file, relative paths are
+// resolved from the working directory
+func NewFirebase(ctx context.Context, credentialsFile string) (*googleFirebase.App, error) {
+	servicePath := credentialsFile
+	if !filepath.IsAbs(servicePath) {
+		rootPath, err := os.Getwd()
+		if err != nil {
+			log.Fatal("Failed to get working directory:", err)
+		}
+
+		servicePath = filepath.Join(rootPath, credentialsFile)
 	}
 
-	servicePath := fmt.Sprintf("%s/%s", rootPath, "firebase-adminsdk.json")
 	opt := option.WithCredentialsFile(servicePath)
-	app, err := googleFirebase.NewApp(context.Background(), &googleFirebase.Config{}, opt)
+	app, err := googleFirebase.NewApp(ctx, &googleFirebase.Config{}, opt)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing app: %v", err)
 	}
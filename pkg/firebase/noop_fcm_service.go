@@ -0,0 +1,22 @@
+package firebase
+
+import "context"
+
+type noopFCMService struct{}
+
+// NewNoopFCMService drops every push, it stands in for FCM when Firebase is disabled
+func NewNoopFCMService() FCMService {
+	return noopFCMService{}
+}
+
+func (noopFCMService) SendNotification(ctx context.Context, token string, notification *PushNotification) error {
+	return nil
+}
+
+func (noopFCMService) SendMulticast(ctx context.Context, tokens []string, notification *PushNotification) (*MulticastResult, error) {
+	return &MulticastResult{}, nil
+}
+
+func (noopFCMService) SendToTopic(ctx context.Context, topic string, notification *PushNotification) error {
+	return nil
+}
@@ -0,0 +1,97 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// MaxPixels bounds the decoded size, a small file can declare huge dimensions
+const MaxPixels = 50_000_000
+
+var ErrTooLarge = errors.New("image dimensions are too large")
+
+// Format is an output encoding
+type Format struct {
+	ContentType string
+	Extension   string
+}
+
+var (
+	JPEG = Format{ContentType: "image/jpeg", Extension: ".jpg"}
+	PNG  = Format{ContentType: "image/png", Extension: ".png"} // lossless, used to keep transparency
+)
+
+// Decode decodes a JPEG, PNG, GIF or WebP image, only the first frame of animations is kept.
+// JPEGs are turned upright following their EXIF orientation, since re-encoding drops it.
+func Decode(content []byte) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image header: %w", err)
+	}
+
+	if config.Width*config.Height > MaxPixels {
+		return nil, ErrTooLarge
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	if format == "jpeg" {
+		img = applyOrientation(img, readOrientation(content))
+	}
+
+	return img, nil
+}
+
+// Fit scales the image down to maxWidth, keeping its aspect ratio. Narrower images keep their size.
+func Fit(img image.Image, maxWidth int) *image.RGBA {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > maxWidth {
+		height = max(1, height*maxWidth/width)
+		width = maxWidth
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	if width == bounds.Dx() {
+		draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Src)
+		return dst
+	}
+
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+// Encode writes opaque images as JPEG at the given quality and the others as PNG.
+// Only pixels are written, no metadata of the source survives.
+func Encode(w io.Writer, img *image.RGBA, quality int) (Format, error) {
+	if img.Opaque() {
+		return JPEG, jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+	}
+
+	// Variants are encoded once in the background, the smaller file is worth the time
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	return PNG, encoder.Encode(w, img)
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Bounds().Min == (image.Point{}) {
+		return rgba
+	}
+
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+	return rgba
+}
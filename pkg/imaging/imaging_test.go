@@ -0,0 +1,92 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name   string
+		alpha  func(x, y int) uint8
+		format Format
+	}{
+		{name: "opaque", alpha: func(int, int) uint8 { return 0xff }, format: JPEG},
+		{name: "translucent", alpha: func(x, y int) uint8 { return uint8(x * 8) }, format: PNG},
+		{
+			name: "transparent pixels",
+			alpha: func(x, y int) uint8 {
+				if (x/4+y/4)%2 == 0 {
+					return 0
+				}
+
+				return 0xff
+			},
+			format: PNG,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := image.NewRGBA(image.Rect(0, 0, 30, 20))
+			for y := range 20 {
+				for x := range 30 {
+					img.Set(x, y, color.NRGBA{R: uint8(x * 7), G: uint8(y * 11), B: 90, A: tt.alpha(x, y)})
+				}
+			}
+
+			var buf bytes.Buffer
+			format, err := Encode(&buf, img, 85)
+			if err != nil {
+				t.Fatalf("encode: %v", err)
+			}
+
+			if format != tt.format {
+				t.Fatalf("format = %s, want %s", format.ContentType, tt.format.ContentType)
+			}
+
+			decoded, err := Decode(buf.Bytes())
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+
+			if decoded.Bounds().Size() != img.Bounds().Size() {
+				t.Fatalf("decoded size %v, want %v", decoded.Bounds().Size(), img.Bounds().Size())
+			}
+
+			// JPEG is lossy, the transparent formats must keep every pixel
+			if format == JPEG {
+				return
+			}
+
+			for y := range 20 {
+				for x := range 30 {
+					want := color.NRGBAModel.Convert(img.At(x, y))
+					if got := color.NRGBAModel.Convert(decoded.At(x, y)); got != want {
+						t.Fatalf("pixel (%d, %d) = %v, want %v", x, y, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeTooLarge(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := Encode(&buf, image.NewRGBA(image.Rect(0, 0, 10, 10)), 85); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	// Declare 65536x65536 in the IHDR chunk and fix its checksum, the pixels are never read
+	content := buf.Bytes()
+	binary.BigEndian.PutUint32(content[16:20], 1<<16)
+	binary.BigEndian.PutUint32(content[20:24], 1<<16)
+	binary.BigEndian.PutUint32(content[29:33], crc32.ChecksumIEEE(content[12:29]))
+	if _, err := Decode(content); err != ErrTooLarge {
+		t.Errorf("error = %v, want %v", err, ErrTooLarge)
+	}
+}
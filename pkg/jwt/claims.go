@@ -1,13 +1,35 @@
 package jwt
 
 import (
+	"slices"
+
 	jwtV4 "github.com/golang-jwt/jwt/v4"
 )
 
+// TokenPurpose distinguishes special-purpose tokens from regular access tokens
+type TokenPurpose string
+
+const (
+	// TwoFactorChallengePurpose marks a token issued after a correct password
+	// that can only be exchanged for a token pair with a valid second factor
+	TwoFactorChallengePurpose TokenPurpose = "2fa_challenge"
+
+	// AccessTokenPurpose marks claims resolved from a personal access token rather than a JWT
+	AccessTokenPurpose TokenPurpose = "access_token"
+)
+
 type Claims struct {
-	AccountID string `json:"accountId"`
-	Email     string `json:"email"`
-	JTI       string `json:"jti"`
-	Role      string `json:"role"`
+	AccountID   string       `json:"accountId"`
+	Email       string       `json:"email"`
+	JTI         string       `json:"jti"`
+	Roles       []string     `json:"roles,omitempty"`
+	Permissions []string     `json:"perms,omitempty"`   // union of the permissions granted by Roles
+	TwoFactor   bool         `json:"tfa,omitempty"`     // true when the session passed a second factor
+	Purpose     TokenPurpose `json:"purpose,omitempty"` // empty for access and refresh tokens
 	jwtV4.RegisteredClaims
 }
+
+// HasPermission reports whether the token grants the permission
+func (c *Claims) HasPermission(permission string) bool {
+	return slices.Contains(c.Permissions, permission)
+}
@@ -0,0 +1,99 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"hinsun-backend/pkg/security"
+	"math/big"
+)
+
+// JWK is a public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+// JWKSet is the document served at /.well-known/jwks.json
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// NewJWKSet converts the public part of RSA, EC and Ed25519 keys into a JWK set.
+// Symmetric keys are skipped since publishing them would disclose the secret.
+func NewJWKSet(keys []*SigningKey) *JWKSet {
+	set := &JWKSet{Keys: make([]JWK, 0, len(keys))}
+
+	for _, key := range keys {
+		if jwk, ok := toJWK(key); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+
+	return set
+}
+
+func toJWK(key *SigningKey) (JWK, bool) {
+	signingMethod, err := retrieveSigningMethod(key.Algorithm)
+	if err != nil {
+		return JWK{}, false
+	}
+
+	jwk := JWK{
+		KeyID:     key.ID,
+		Use:       "sig",
+		Algorithm: signingMethod.Alg(),
+	}
+
+	switch publicKey := key.KeyPair.VerificationKey.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = encodeBase64URL(publicKey.N.Bytes())
+		jwk.E = encodeBase64URL(big.NewInt(int64(publicKey.E)).Bytes())
+	case *ecdsa.PublicKey:
+		curve, ok := curveName(key.Algorithm)
+		if !ok {
+			return JWK{}, false
+		}
+
+		size := (publicKey.Curve.Params().BitSize + 7) / 8
+		jwk.KeyType = "EC"
+		jwk.Curve = curve
+		jwk.X = encodeBase64URL(publicKey.X.FillBytes(make([]byte, size)))
+		jwk.Y = encodeBase64URL(publicKey.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		// Octet key pair (RFC 8037)
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = encodeBase64URL(publicKey)
+	default:
+		return JWK{}, false
+	}
+
+	return jwk, true
+}
+
+func curveName(algorithm security.Algorithm) (string, bool) {
+	switch algorithm {
+	case security.ES256:
+		return "P-256", true
+	case security.ES384:
+		return "P-384", true
+	case security.ES521:
+		return "P-521", true
+	default:
+		return "", false
+	}
+}
+
+func encodeBase64URL(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
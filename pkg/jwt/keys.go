@@ -1,52 +1,461 @@
 package jwt
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"hinsun-backend/pkg/security"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	activeKeyFile   = "ACTIVE"
+	metadataFile    = "metadata.json"
+	privateKeyFile  = "private_key.pem"
+	publicKeyFile   = "public_key.pem"
+	secretFile      = "secret.key"
+	accessRingName  = "access"
+	refreshRingName = "refresh"
+
+	// defaultReloadInterval bounds how often unknown key IDs make the rings reload from disk,
+	// since any client can send one
+	defaultReloadInterval = 10 * time.Second
+)
+
+var (
+	ErrUnknownKeyID   = errors.New("unknown key id")
+	ErrStaticKeys     = errors.New("keys configured from secrets cannot be rotated")
+	ErrSecretTooShort = errors.New("secret is shorter than the algorithm requires")
 )
 
+// KeyManagerParams configures where keys are stored and how they are generated
+type KeyManagerParams struct {
+	KeysPath       string
+	Algorithm      string
+	KeySize        int
+	MaxRetiredKeys int
+	// AccessSecret and RefreshSecret pin HMAC keys instead of generating them under KeysPath
+	AccessSecret  string
+	RefreshSecret string
+}
+
+// SigningKey is a key pair identified by the kid written into JWT headers
+type SigningKey struct {
+	ID        string             `json:"kid"`
+	Algorithm security.Algorithm `json:"algorithm"`
+	CreatedAt int64              `json:"createdAt"`
+	RetiredAt *int64             `json:"retiredAt,omitempty"`
+	KeyPair   *security.KeyPair  `json:"-"`
+}
+
+// keyRing holds the active signing key and the retired keys still accepted for verification.
+// On disk it is laid out as <path>/ACTIVE and one <path>/<kid>/ directory per key.
+// A static ring holds a single configured secret and is never written to disk.
+type keyRing struct {
+	path    string
+	static  bool
+	active  *SigningKey
+	retired []*SigningKey
+}
+
 type KeyManager struct {
-	algorithm  security.Algorithm
-	accessKey  *security.KeyPair
-	refreshKey *security.KeyPair
+	mutex          sync.RWMutex
+	cryptography   security.Cryptography
+	algorithm      security.Algorithm
+	keySize        int
+	maxRetiredKeys int
+	accessRing     *keyRing
+	refreshRing    *keyRing
+	reloadInterval time.Duration
+	reloadedAt     time.Time
 }
 
-func NewKeyManager(alg string, keySize int) *KeyManager {
-	cryptography := security.NewCryptography()
-	rootPath, err := os.Getwd()
+// NewKeyManager loads (or creates) the access and refresh key rings under params.KeysPath
+func NewKeyManager(params *KeyManagerParams) (*KeyManager, error) {
+	algorithm, err := security.AlgorithmFromString(params.Algorithm)
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+
+	if !algorithm.IsSymmetric() && (params.AccessSecret != "" || params.RefreshSecret != "") {
+		return nil, fmt.Errorf("%w: secrets are only supported by HMAC algorithms", security.ErrUnsupportedAlgorithm)
+	}
+
+	keyPath := params.KeysPath
+	if !filepath.IsAbs(keyPath) {
+		rootPath, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get working directory: %w", err)
+		}
+
+		keyPath = filepath.Join(rootPath, keyPath)
+	}
+
+	if err := os.MkdirAll(keyPath, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create key directory %s: %w", keyPath, err)
 	}
 
-	algorithm, err := security.AlgorithmFromString(alg)
+	manager := &KeyManager{
+		cryptography:   security.NewCryptography(),
+		algorithm:      algorithm,
+		keySize:        params.KeySize,
+		maxRetiredKeys: params.MaxRetiredKeys,
+		reloadInterval: defaultReloadInterval,
+		reloadedAt:     time.Now(),
+	}
+
+	manager.accessRing, err = manager.loadOrPinKeyRing(filepath.Join(keyPath, accessRingName), params.AccessSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load access keys: %w", err)
+	}
+
+	manager.refreshRing, err = manager.loadOrPinKeyRing(filepath.Join(keyPath, refreshRingName), params.RefreshSecret)
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("failed to load refresh keys: %w", err)
 	}
 
-	keyPath := rootPath + "/keys"
-	if _, err := os.Stat(keyPath); os.IsNotExist(err) {
-		err := os.Mkdir(keyPath, 0700)
+	return manager, nil
+}
+
+// ActiveAccessKey returns the key currently used to sign access tokens
+func (m *KeyManager) ActiveAccessKey() *SigningKey {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.accessRing.active
+}
+
+// ActiveRefreshKey returns the key currently used to sign refresh tokens
+func (m *KeyManager) ActiveRefreshKey() *SigningKey {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.refreshRing.active
+}
+
+// AccessVerificationKeys returns the active and retired access keys
+func (m *KeyManager) AccessVerificationKeys() []*SigningKey {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.accessRing.verificationKeys()
+}
+
+// RefreshVerificationKeys returns the active and retired refresh keys
+func (m *KeyManager) RefreshVerificationKeys() []*SigningKey {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.refreshRing.verificationKeys()
+}
+
+// Rotate generates new access and refresh keys, retires the previous ones and
+// prunes retired keys beyond maxRetiredKeys. Retired keys keep verifying tokens
+// they signed, so maxRetiredKeys should cover at least one refresh token lifetime.
+func (m *KeyManager) Rotate() (*SigningKey, *SigningKey, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.accessRing.static || m.refreshRing.static {
+		return nil, nil, ErrStaticKeys
+	}
+
+	accessKey, err := m.rotateKeyRing(m.accessRing)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to rotate access key: %w", err)
+	}
+
+	refreshKey, err := m.rotateKeyRing(m.refreshRing)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to rotate refresh key: %w", err)
+	}
+
+	return accessKey, refreshKey, nil
+}
+
+// Reload re-reads the key rings from the key directory, so keys rotated by another instance
+// sharing it are picked up. It runs at most once per reload interval and reports whether it did;
+// static rings are kept as they are.
+func (m *KeyManager) Reload() (bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if time.Since(m.reloadedAt) < m.reloadInterval {
+		return false, nil
+	}
+	m.reloadedAt = time.Now()
+
+	accessRing, err := m.reloadKeyRing(m.accessRing)
+	if err != nil {
+		return false, fmt.Errorf("failed to reload access keys: %w", err)
+	}
+
+	refreshRing, err := m.reloadKeyRing(m.refreshRing)
+	if err != nil {
+		return false, fmt.Errorf("failed to reload refresh keys: %w", err)
+	}
+
+	m.accessRing, m.refreshRing = accessRing, refreshRing
+	return true, nil
+}
+
+func (m *KeyManager) reloadKeyRing(ring *keyRing) (*keyRing, error) {
+	if ring.static {
+		return ring, nil
+	}
+
+	return m.loadKeyRing(ring.path)
+}
+
+func (r *keyRing) verificationKeys() []*SigningKey {
+	keys := make([]*SigningKey, 0, len(r.retired)+1)
+	keys = append(keys, r.active)
+	keys = append(keys, r.retired...)
+	return keys
+}
+
+func (m *KeyManager) loadOrPinKeyRing(path, secret string) (*keyRing, error) {
+	if secret == "" {
+		return m.loadKeyRing(path)
+	}
+
+	if len(secret) < m.algorithm.MinSecretSize() {
+		return nil, fmt.Errorf("%w: %s needs at least %d bytes", ErrSecretTooShort, m.algorithm, m.algorithm.MinSecretSize())
+	}
+
+	// The kid is derived from the secret so every instance sharing it agrees on the ID
+	digest := sha256.Sum256([]byte(secret))
+	key := &SigningKey{
+		ID:        "static-" + hex.EncodeToString(digest[:8]),
+		Algorithm: m.algorithm,
+		KeyPair: &security.KeyPair{
+			SigningKey:      []byte(secret),
+			VerificationKey: []byte(secret),
+		},
+	}
+
+	return &keyRing{static: true, active: key}, nil
+}
+
+func (m *KeyManager) loadKeyRing(path string) (*keyRing, error) {
+	if err := os.MkdirAll(path, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", path, err)
+	}
+
+	// Keys created before rotation support live directly in the ring directory
+	if err := m.migrateLegacyKey(path); err != nil {
+		return nil, err
+	}
+
+	ring := &keyRing{path: path}
+	activeID, err := os.ReadFile(filepath.Join(path, activeKeyFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read active key id: %w", err)
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key directory %s: %w", path, err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		key, err := m.loadSigningKey(filepath.Join(path, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		if key.ID == strings.TrimSpace(string(activeID)) {
+			ring.active = key
+		} else {
+			ring.retired = append(ring.retired, key)
+		}
+	}
+
+	sortNewestFirst(ring.retired)
+
+	if ring.active == nil {
+		key, err := m.generateSigningKey(path)
+		if err != nil {
+			return nil, err
+		}
+
+		ring.active = key
+	}
+
+	return ring, nil
+}
+
+func (m *KeyManager) migrateLegacyKey(path string) error {
+	legacyPrivate := filepath.Join(path, privateKeyFile)
+	if _, err := os.Stat(legacyPrivate); os.IsNotExist(err) {
+		return nil
+	}
+
+	kid := uuid.Must(uuid.NewV7()).String()
+	keyDir := filepath.Join(path, kid)
+	if err := os.Mkdir(keyDir, 0700); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", keyDir, err)
+	}
+
+	for _, name := range []string{privateKeyFile, publicKeyFile} {
+		if err := os.Rename(filepath.Join(path, name), filepath.Join(keyDir, name)); err != nil {
+			return fmt.Errorf("failed to migrate legacy key %s: %w", name, err)
+		}
+	}
+
+	key := &SigningKey{ID: kid, Algorithm: m.algorithm, CreatedAt: time.Now().Unix()}
+	if err := writeMetadata(keyDir, key); err != nil {
+		return err
+	}
+
+	return writeActiveKeyID(path, kid)
+}
+
+func (m *KeyManager) loadSigningKey(keyDir string) (*SigningKey, error) {
+	data, err := os.ReadFile(filepath.Join(keyDir, metadataFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key metadata in %s: %w", keyDir, err)
+	}
+
+	var key SigningKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("failed to parse key metadata in %s: %w", keyDir, err)
+	}
+
+	if _, err := security.AlgorithmFromString(string(key.Algorithm)); err != nil {
+		return nil, fmt.Errorf("key %s: %w", key.ID, err)
+	}
+
+	if key.Algorithm.IsSymmetric() {
+		secret, err := m.cryptography.LoadSecret(filepath.Join(keyDir, secretFile))
 		if err != nil {
-			panic(err)
+			return nil, fmt.Errorf("failed to load secret: %w", err)
+		}
+
+		key.KeyPair = &security.KeyPair{
+			SigningKey:      secret,
+			VerificationKey: secret,
 		}
+
+		return &key, nil
+	}
+
+	privateKey, err := m.cryptography.LoadPrivateKey(filepath.Join(keyDir, privateKeyFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load private key: %w", err)
+	}
+
+	publicKey, err := m.cryptography.LoadPublicKey(filepath.Join(keyDir, publicKeyFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load public key: %w", err)
+	}
+
+	key.KeyPair = &security.KeyPair{
+		SigningKey:      privateKey,
+		VerificationKey: publicKey,
+	}
+
+	return &key, nil
+}
+
+func (m *KeyManager) generateSigningKey(ringPath string) (*SigningKey, error) {
+	kid := uuid.Must(uuid.NewV7()).String()
+	keyDir := filepath.Join(ringPath, kid)
+
+	keyPair, err := m.cryptography.InitializeKeyPair(keyDir, m.algorithm, m.keySize)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &SigningKey{
+		ID:        kid,
+		Algorithm: m.algorithm,
+		CreatedAt: time.Now().Unix(),
+		KeyPair:   keyPair,
+	}
+
+	if err := writeMetadata(keyDir, key); err != nil {
+		return nil, err
+	}
+
+	if err := writeActiveKeyID(ringPath, kid); err != nil {
+		return nil, err
 	}
 
-	accessPath := keyPath + "/access"
-	refreshPath := keyPath + "/refresh"
+	return key, nil
+}
 
-	accessKey, err := cryptography.InitializeKeyPair(accessPath, algorithm, keySize)
+func (m *KeyManager) rotateKeyRing(ring *keyRing) (*SigningKey, error) {
+	key, err := m.generateSigningKey(ring.path)
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+
+	previous := ring.active
+	retiredAt := time.Now().Unix()
+	previous.RetiredAt = &retiredAt
+	if err := writeMetadata(filepath.Join(ring.path, previous.ID), previous); err != nil {
+		return nil, err
+	}
+
+	ring.active = key
+	ring.retired = append([]*SigningKey{previous}, ring.retired...)
+
+	// Prune the oldest retired keys
+	for len(ring.retired) > m.maxRetiredKeys {
+		oldest := ring.retired[len(ring.retired)-1]
+		if err := os.RemoveAll(filepath.Join(ring.path, oldest.ID)); err != nil {
+			return nil, fmt.Errorf("failed to remove retired key %s: %w", oldest.ID, err)
+		}
+
+		ring.retired = ring.retired[:len(ring.retired)-1]
 	}
 
-	refreshKey, err := cryptography.InitializeKeyPair(refreshPath, algorithm, keySize)
+	return key, nil
+}
+
+func writeMetadata(keyDir string, key *SigningKey) error {
+	data, err := json.MarshalIndent(key, "", "  ")
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("failed to marshal key metadata: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(keyDir, metadataFile), data, 0600); err != nil {
+		return fmt.Errorf("failed to write key metadata: %w", err)
+	}
+
+	return nil
+}
+
+func writeActiveKeyID(ringPath, kid string) error {
+	// Write to a temporary file first so a crash never leaves a truncated ACTIVE file
+	tmpPath := filepath.Join(ringPath, activeKeyFile+".tmp")
+	if err := os.WriteFile(tmpPath, []byte(kid), 0600); err != nil {
+		return fmt.Errorf("failed to write active key id: %w", err)
 	}
 
-	return &KeyManager{
-		algorithm:  algorithm,
-		accessKey:  accessKey,
-		refreshKey: refreshKey,
+	if err := os.Rename(tmpPath, filepath.Join(ringPath, activeKeyFile)); err != nil {
+		return fmt.Errorf("failed to activate key %s: %w", kid, err)
 	}
+
+	return nil
+}
+
+func sortNewestFirst(keys []*SigningKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt > keys[j].CreatedAt
+	})
 }
@@ -11,8 +11,9 @@ import (
 )
 
 var (
-	ErrInvalidToken = errors.New("invalid token")
-	ErrExpiredToken = errors.New("token has expired")
+	ErrInvalidToken        = errors.New("invalid token")
+	ErrExpiredToken        = errors.New("token has expired")
+	ErrInvalidTokenPurpose = errors.New("invalid token purpose")
 )
 
 type TokenPair struct {
@@ -21,62 +22,89 @@ type TokenPair struct {
 }
 
 type JwtService interface {
-	GenerateTokenPair(accountID, email string, role int) (*TokenPair, error)
+	GenerateTokenPair(subject *TokenSubject, twoFactor bool) (*TokenPair, error)
+	GenerateChallengeToken(accountID, email string) (string, error)
 	ValidateAccessToken(tokenString string) (*Claims, error)
 	ValidateRefreshToken(tokenString string) (*Claims, error)
+	ValidateChallengeToken(tokenString string) (*Claims, error)
+	RotateKeys() (*RotatedKeys, error)
+	PublicKeySet() *JWKSet
+}
+
+// TokenSubject describes the account a token pair is issued for
+type TokenSubject struct {
+	AccountID   string
+	Email       string
+	Roles       []string
+	Permissions []string
+}
+
+// RotatedKeys holds the key IDs activated by a rotation
+type RotatedKeys struct {
+	AccessKeyID  string
+	RefreshKeyID string
 }
 
 type jwtService struct {
-	algorithm          security.Algorithm
-	keyManager         *KeyManager
-	accessTokenExpiry  time.Duration
-	refreshTokenExpiry time.Duration
+	keyManager           *KeyManager
+	accessTokenExpiry    time.Duration
+	refreshTokenExpiry   time.Duration
+	challengeTokenExpiry time.Duration
 }
 
-func NewJwtService(keyManager *KeyManager, accessTokenExpiry, refreshTokenExpiry time.Duration) JwtService {
+func NewJwtService(keyManager *KeyManager, accessTokenExpiry, refreshTokenExpiry, challengeTokenExpiry time.Duration) JwtService {
 	return &jwtService{
-		keyManager:         keyManager,
-		algorithm:          keyManager.algorithm,
-		accessTokenExpiry:  accessTokenExpiry,
-		refreshTokenExpiry: refreshTokenExpiry,
+		keyManager:           keyManager,
+		accessTokenExpiry:    accessTokenExpiry,
+		refreshTokenExpiry:   refreshTokenExpiry,
+		challengeTokenExpiry: challengeTokenExpiry,
 	}
 }
 
-func (j *jwtService) retrieveSigningMethod() jwtv4.SigningMethod {
-	switch j.algorithm {
+func retrieveSigningMethod(algorithm security.Algorithm) (jwtv4.SigningMethod, error) {
+	switch algorithm {
 	case security.RS256:
-		return jwtv4.SigningMethodRS256
+		return jwtv4.SigningMethodRS256, nil
 	case security.RS384:
-		return jwtv4.SigningMethodRS384
+		return jwtv4.SigningMethodRS384, nil
 	case security.RS512:
-		return jwtv4.SigningMethodRS512
+		return jwtv4.SigningMethodRS512, nil
 	case security.ES256:
-		return jwtv4.SigningMethodES256
+		return jwtv4.SigningMethodES256, nil
 	case security.ES384:
-		return jwtv4.SigningMethodES384
+		return jwtv4.SigningMethodES384, nil
 	case security.ES521:
-		return jwtv4.SigningMethodES512
+		return jwtv4.SigningMethodES512, nil
 	case security.HS256:
-		return jwtv4.SigningMethodHS256
+		return jwtv4.SigningMethodHS256, nil
+	case security.HS384:
+		return jwtv4.SigningMethodHS384, nil
+	case security.HS512:
+		return jwtv4.SigningMethodHS512, nil
+	case security.EdDSA:
+		return jwtv4.SigningMethodEdDSA, nil
 	default:
-		return jwtv4.SigningMethodHS256
+		return nil, fmt.Errorf("%w: %s", security.ErrUnsupportedAlgorithm, algorithm)
 	}
 }
 
-func (j *jwtService) GenerateTokenPair(accountID, email string, role int) (*TokenPair, error) {
+func (j *jwtService) GenerateTokenPair(subject *TokenSubject, twoFactor bool) (*TokenPair, error) {
 	jti := uuid.New().String()
 	now := time.Now()
 
 	accessExpiresAt := now.Add(j.accessTokenExpiry)
 	refreshExpiresAt := now.Add(j.refreshTokenExpiry)
 
-	signingMethod := j.retrieveSigningMethod()
+	accessKey := j.keyManager.ActiveAccessKey()
+	refreshKey := j.keyManager.ActiveRefreshKey()
 
 	accessClaims := Claims{
-		AccountID: accountID,
-		Email:     email,
-		JTI:       jti,
-		Role:      fmt.Sprintf("%d", role),
+		AccountID:   subject.AccountID,
+		Email:       subject.Email,
+		JTI:         jti,
+		Roles:       subject.Roles,
+		Permissions: subject.Permissions,
+		TwoFactor:   twoFactor,
 		RegisteredClaims: jwtv4.RegisteredClaims{
 			ExpiresAt: jwtv4.NewNumericDate(accessExpiresAt),
 			IssuedAt:  jwtv4.NewNumericDate(now),
@@ -84,18 +112,19 @@ func (j *jwtService) GenerateTokenPair(accountID, email string, role int) (*Toke
 		},
 	}
 
-	accessToken := jwtv4.NewWithClaims(signingMethod, accessClaims)
-	accessTokenString, err := accessToken.SignedString(j.keyManager.accessKey.SigningKey)
+	accessTokenString, err := signToken(accessKey, accessClaims)
 	if err != nil {
 		return nil, err
 	}
 
 	// Generate refresh token
 	refreshClaims := Claims{
-		AccountID: accountID,
-		Email:     email,
-		JTI:       jti,
-		Role:      fmt.Sprintf("%d", role),
+		AccountID:   subject.AccountID,
+		Email:       subject.Email,
+		JTI:         jti,
+		Roles:       subject.Roles,
+		Permissions: subject.Permissions,
+		TwoFactor:   twoFactor,
 		RegisteredClaims: jwtv4.RegisteredClaims{
 			ExpiresAt: jwtv4.NewNumericDate(refreshExpiresAt),
 			IssuedAt:  jwtv4.NewNumericDate(now),
@@ -103,8 +132,7 @@ func (j *jwtService) GenerateTokenPair(accountID, email string, role int) (*Toke
 		},
 	}
 
-	refreshToken := jwtv4.NewWithClaims(signingMethod, refreshClaims)
-	refreshTokenString, err := refreshToken.SignedString(j.keyManager.refreshKey.SigningKey)
+	refreshTokenString, err := signToken(refreshKey, refreshClaims)
 	if err != nil {
 		return nil, err
 	}
@@ -115,23 +143,111 @@ func (j *jwtService) GenerateTokenPair(accountID, email string, role int) (*Toke
 	}, nil
 }
 
+// GenerateChallengeToken issues a short-lived token proving that the first factor succeeded.
+// It is signed with the access key but carries a purpose, so it is rejected as an access token.
+func (j *jwtService) GenerateChallengeToken(accountID, email string) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		AccountID: accountID,
+		Email:     email,
+		JTI:       uuid.New().String(),
+		Purpose:   TwoFactorChallengePurpose,
+		RegisteredClaims: jwtv4.RegisteredClaims{
+			ExpiresAt: jwtv4.NewNumericDate(now.Add(j.challengeTokenExpiry)),
+			IssuedAt:  jwtv4.NewNumericDate(now),
+			NotBefore: jwtv4.NewNumericDate(now),
+		},
+	}
+
+	return signToken(j.keyManager.ActiveAccessKey(), claims)
+}
+
 func (j *jwtService) ValidateAccessToken(token string) (*Claims, error) {
-	return j.validateToken(token, j.keyManager.accessKey.VerificationKey)
+	return j.validateTokenWithPurpose(token, j.keyManager.AccessVerificationKeys, "")
 }
 
 func (j *jwtService) ValidateRefreshToken(token string) (*Claims, error) {
-	return j.validateToken(token, j.keyManager.refreshKey.VerificationKey)
+	return j.validateTokenWithPurpose(token, j.keyManager.RefreshVerificationKeys, "")
+}
+
+func (j *jwtService) ValidateChallengeToken(token string) (*Claims, error) {
+	return j.validateTokenWithPurpose(token, j.keyManager.AccessVerificationKeys, TwoFactorChallengePurpose)
+}
+
+// RotateKeys activates new signing keys; tokens signed with the previous keys stay valid
+func (j *jwtService) RotateKeys() (*RotatedKeys, error) {
+	accessKey, refreshKey, err := j.keyManager.Rotate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &RotatedKeys{
+		AccessKeyID:  accessKey.ID,
+		RefreshKeyID: refreshKey.ID,
+	}, nil
+}
+
+// PublicKeySet returns the access token verification keys as a JWK set.
+// Refresh keys are never published since only this service consumes refresh tokens.
+func (j *jwtService) PublicKeySet() *JWKSet {
+	return NewJWKSet(j.keyManager.AccessVerificationKeys())
 }
 
-func (j *jwtService) validateToken(token string, verificationKey any) (*Claims, error) {
+func signToken(key *SigningKey, claims Claims) (string, error) {
+	signingMethod, err := retrieveSigningMethod(key.Algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	token := jwtv4.NewWithClaims(signingMethod, claims)
+	token.Header["kid"] = key.ID
+
+	return token.SignedString(key.KeyPair.SigningKey)
+}
+
+func (j *jwtService) validateTokenWithPurpose(token string, keys func() []*SigningKey, purpose TokenPurpose) (*Claims, error) {
+	claims, err := j.validateToken(token, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Purpose != purpose {
+		return nil, ErrInvalidTokenPurpose
+	}
+
+	return claims, nil
+}
+
+func (j *jwtService) validateToken(token string, keys func() []*SigningKey) (*Claims, error) {
 	parsedToken, err := jwtv4.ParseWithClaims(token, &Claims{}, func(t *jwtv4.Token) (any, error) {
+		key, err := findVerificationKey(t, keys())
+		if errors.Is(err, ErrUnknownKeyID) {
+			// Another instance may have rotated the keys in the shared key directory
+			reloaded, reloadErr := j.keyManager.Reload()
+			if reloadErr != nil {
+				return nil, fmt.Errorf("%w: %v", err, reloadErr)
+			}
+
+			if reloaded {
+				key, err = findVerificationKey(t, keys())
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
 		// Validate signing method
-		expectedMethod := j.retrieveSigningMethod()
+		expectedMethod, err := retrieveSigningMethod(key.Algorithm)
+		if err != nil {
+			return nil, err
+		}
+
 		if t.Method.Alg() != expectedMethod.Alg() {
 			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
 		}
 
-		return verificationKey, nil
+		return key.KeyPair.VerificationKey, nil
 	})
 
 	if err != nil {
@@ -149,3 +265,20 @@ func (j *jwtService) validateToken(token string, verificationKey any) (*Claims,
 
 	return claims, nil
 }
+
+// findVerificationKey selects the key named by the kid header.
+// Tokens issued before key IDs were introduced have no kid and are checked against the active key.
+func findVerificationKey(token *jwtv4.Token, keys []*SigningKey) (*SigningKey, error) {
+	kid, ok := token.Header["kid"].(string)
+	if !ok || kid == "" {
+		return keys[0], nil
+	}
+
+	for _, key := range keys {
+		if key.ID == kid {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownKeyID, kid)
+}
@@ -0,0 +1,217 @@
+package jwt
+
+import (
+	"errors"
+	"hinsun-backend/pkg/security"
+	"testing"
+	"time"
+
+	jwtv4 "github.com/golang-jwt/jwt/v4"
+)
+
+func newTestService(t *testing.T, algorithm security.Algorithm) (JwtService, *KeyManager) {
+	t.Helper()
+	keyManager, err := NewKeyManager(&KeyManagerParams{
+		KeysPath:       t.TempDir(),
+		Algorithm:      string(algorithm),
+		KeySize:        2048,
+		MaxRetiredKeys: 2,
+	})
+	if err != nil {
+		t.Fatalf("new key manager: %v", err)
+	}
+
+	return NewJwtService(keyManager, time.Minute, time.Hour, time.Minute), keyManager
+}
+
+var testSubject = &TokenSubject{
+	AccountID:   "0198c1a0-0000-7000-8000-000000000001",
+	Email:       "jwt@example.com",
+	Roles:       []string{"admin"},
+	Permissions: []string{"blogs:write"},
+}
+
+func TestSignAndVerify(t *testing.T) {
+	tests := []struct {
+		algorithm security.Algorithm
+		alg       string
+	}{
+		{security.RS256, "RS256"},
+		{security.RS384, "RS384"},
+		{security.RS512, "RS512"},
+		{security.ES256, "ES256"},
+		{security.ES384, "ES384"},
+		{security.ES521, "ES512"},
+		{security.HS256, "HS256"},
+		{security.HS384, "HS384"},
+		{security.HS512, "HS512"},
+		{security.EdDSA, "EdDSA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.algorithm), func(t *testing.T) {
+			service, keyManager := newTestService(t, tt.algorithm)
+			pair, err := service.GenerateTokenPair(testSubject, true)
+			if err != nil {
+				t.Fatalf("generate token pair: %v", err)
+			}
+
+			token, _, err := jwtv4.NewParser().ParseUnverified(pair.AccessToken, &Claims{})
+			if err != nil {
+				t.Fatalf("parse header: %v", err)
+			}
+
+			if token.Header["alg"] != tt.alg {
+				t.Errorf("alg = %v, want %s", token.Header["alg"], tt.alg)
+			}
+
+			if token.Header["kid"] != keyManager.ActiveAccessKey().ID {
+				t.Errorf("kid = %v, want %s", token.Header["kid"], keyManager.ActiveAccessKey().ID)
+			}
+
+			claims, err := service.ValidateAccessToken(pair.AccessToken)
+			if err != nil {
+				t.Fatalf("validate access token: %v", err)
+			}
+
+			if claims.AccountID != testSubject.AccountID || !claims.TwoFactor || !claims.HasPermission("blogs:write") {
+				t.Errorf("unexpected claims %+v", claims)
+			}
+
+			if _, err := service.ValidateRefreshToken(pair.RefreshToken); err != nil {
+				t.Errorf("validate refresh token: %v", err)
+			}
+
+			// Access and refresh tokens are signed by different rings
+			if _, err := service.ValidateRefreshToken(pair.AccessToken); !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("access token accepted as refresh token: %v", err)
+			}
+		})
+	}
+}
+
+func TestUnknownAlgorithm(t *testing.T) {
+	_, err := NewKeyManager(&KeyManagerParams{KeysPath: t.TempDir(), Algorithm: "none"})
+	if !errors.Is(err, security.ErrUnsupportedAlgorithm) {
+		t.Errorf("NewKeyManager error = %v, want %v", err, security.ErrUnsupportedAlgorithm)
+	}
+
+	if _, err := retrieveSigningMethod("PS256"); !errors.Is(err, security.ErrUnsupportedAlgorithm) {
+		t.Errorf("retrieveSigningMethod error = %v, want %v", err, security.ErrUnsupportedAlgorithm)
+	}
+
+	_, keyManager := newTestService(t, security.HS256)
+	key := *keyManager.ActiveAccessKey()
+	key.Algorithm = "PS256"
+	if _, err := signToken(&key, Claims{}); !errors.Is(err, security.ErrUnsupportedAlgorithm) {
+		t.Errorf("signToken error = %v, want %v", err, security.ErrUnsupportedAlgorithm)
+	}
+}
+
+// Tokens issued before key IDs were introduced carry no kid and resolve to the active key
+func TestTokenWithoutKeyID(t *testing.T) {
+	service, keyManager := newTestService(t, security.ES256)
+	key := keyManager.ActiveAccessKey()
+
+	now := time.Now()
+	claims := Claims{
+		AccountID: testSubject.AccountID,
+		Email:     testSubject.Email,
+		RegisteredClaims: jwtv4.RegisteredClaims{
+			ExpiresAt: jwtv4.NewNumericDate(now.Add(time.Minute)),
+			IssuedAt:  jwtv4.NewNumericDate(now),
+		},
+	}
+
+	legacyToken, err := jwtv4.NewWithClaims(jwtv4.SigningMethodES256, claims).SignedString(key.KeyPair.SigningKey)
+	if err != nil {
+		t.Fatalf("sign legacy token: %v", err)
+	}
+
+	parsed, err := service.ValidateAccessToken(legacyToken)
+	if err != nil {
+		t.Fatalf("validate legacy token: %v", err)
+	}
+
+	if parsed.AccountID != testSubject.AccountID {
+		t.Errorf("account id = %s, want %s", parsed.AccountID, testSubject.AccountID)
+	}
+
+	// After a rotation the kid-less token is checked against the new active key only
+	if _, err := service.RotateKeys(); err != nil {
+		t.Fatalf("rotate keys: %v", err)
+	}
+
+	if _, err := service.ValidateAccessToken(legacyToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("legacy token after rotation error = %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestUnknownKeyID(t *testing.T) {
+	service, keyManager := newTestService(t, security.HS256)
+	key := *keyManager.ActiveAccessKey()
+	key.ID = "missing"
+
+	token, err := signToken(&key, Claims{AccountID: testSubject.AccountID})
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+
+	if _, err := service.ValidateAccessToken(token); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("error = %v, want %v", err, ErrInvalidToken)
+	}
+
+	parsed, _, err := jwtv4.NewParser().ParseUnverified(token, &Claims{})
+	if err != nil {
+		t.Fatalf("parse header: %v", err)
+	}
+
+	if _, err := findVerificationKey(parsed, keyManager.AccessVerificationKeys()); !errors.Is(err, ErrUnknownKeyID) {
+		t.Errorf("findVerificationKey error = %v, want %v", err, ErrUnknownKeyID)
+	}
+}
+
+// Instances sharing a key directory accept tokens signed with a key another instance rotated in
+func TestRotationOnAnotherInstance(t *testing.T) {
+	keysPath := t.TempDir()
+	newInstance := func() (JwtService, *KeyManager) {
+		keyManager, err := NewKeyManager(&KeyManagerParams{KeysPath: keysPath, Algorithm: "ES256", MaxRetiredKeys: 2})
+		if err != nil {
+			t.Fatalf("new key manager: %v", err)
+		}
+
+		return NewJwtService(keyManager, time.Minute, time.Hour, time.Minute), keyManager
+	}
+
+	rotating, rotatingKeys := newInstance()
+	other, otherKeys := newInstance()
+	otherKeys.reloadInterval = time.Hour
+
+	if _, err := rotating.RotateKeys(); err != nil {
+		t.Fatalf("rotate keys: %v", err)
+	}
+
+	pair, err := rotating.GenerateTokenPair(testSubject, false)
+	if err != nil {
+		t.Fatalf("generate token pair: %v", err)
+	}
+
+	// Reloads are rate limited, the new kid stays unknown until the interval has passed
+	if _, err := other.ValidateAccessToken(pair.AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("error before the reload interval = %v, want %v", err, ErrInvalidToken)
+	}
+
+	otherKeys.reloadInterval = 0
+	if _, err := other.ValidateAccessToken(pair.AccessToken); err != nil {
+		t.Fatalf("validate access token signed after rotation: %v", err)
+	}
+
+	if _, err := other.ValidateRefreshToken(pair.RefreshToken); err != nil {
+		t.Errorf("validate refresh token signed after rotation: %v", err)
+	}
+
+	// The reloaded instance signs with the new keys as well
+	if got, want := otherKeys.ActiveAccessKey().ID, rotatingKeys.ActiveAccessKey().ID; got != want {
+		t.Errorf("active access key = %s after reload, want %s", got, want)
+	}
+}
@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"context"
+	"sync"
+)
+
+// FakeMailer records emails in memory instead of sending them, so notifications run without an
+// SMTP server (local development, tests)
+type FakeMailer struct {
+	mu   sync.Mutex
+	sent []Message
+}
+
+func NewFakeMailer() *FakeMailer {
+	return &FakeMailer{}
+}
+
+// Sent returns a copy of every email delivered so far
+func (m *FakeMailer) Sent() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Message(nil), m.sent...)
+}
+
+// Reset forgets the recorded emails
+func (m *FakeMailer) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sent = nil
+}
+
+func (m *FakeMailer) Send(ctx context.Context, message *Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sent = append(m.sent, *message)
+	return nil
+}
@@ -0,0 +1,101 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string // plain text
+}
+
+type Mailer interface {
+	Send(ctx context.Context, message *Message) error
+}
+
+type SMTPParams struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+type smtpMailer struct {
+	params SMTPParams
+	from   *mail.Address
+}
+
+// NewSMTPMailer creates a mailer sending through an SMTP server, with STARTTLS when the server
+// offers it and PLAIN authentication when a username is set
+func NewSMTPMailer(params SMTPParams) (Mailer, error) {
+	from, err := mail.ParseAddress(params.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address %q: %w", params.From, err)
+	}
+
+	return &smtpMailer{params: params, from: from}, nil
+}
+
+func (m *smtpMailer) Send(ctx context.Context, message *Message) error {
+	to, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address %q: %w", message.To, err)
+	}
+
+	var auth smtp.Auth
+	if m.params.Username != "" {
+		auth = smtp.PlainAuth("", m.params.Username, m.params.Password, m.params.Host)
+	}
+
+	address := net.JoinHostPort(m.params.Host, strconv.Itoa(m.params.Port))
+	body := m.build(to, message)
+
+	// net/smtp has no context support, the send runs aside so a cancelled context returns early
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(address, auth, m.from.Address, []string{to.Address}, body)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *smtpMailer) build(to *mail.Address, message *Message) []byte {
+	var buffer bytes.Buffer
+	headers := [][2]string{
+		{"From", m.from.String()},
+		{"To", to.String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", message.Subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=utf-8"},
+		{"Content-Transfer-Encoding", "8bit"},
+	}
+
+	for _, header := range headers {
+		fmt.Fprintf(&buffer, "%s: %s\r\n", header[0], header[1])
+	}
+
+	buffer.WriteString("\r\n")
+	buffer.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+	return buffer.Bytes()
+}
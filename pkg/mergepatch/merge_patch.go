@@ -0,0 +1,79 @@
+package mergepatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+var (
+	ErrInvalidPatch = errors.New("merge patch must be a JSON object")
+)
+
+// Apply applies a JSON merge patch (RFC 7396) to a JSON document. Objects are merged
+// member by member, null removes a member and any other value replaces it as a whole.
+func Apply(document, patch []byte) ([]byte, error) {
+	var patchValue any
+	if err := json.Unmarshal(patch, &patchValue); err != nil {
+		return nil, err
+	}
+
+	if _, ok := patchValue.(map[string]any); !ok {
+		return nil, ErrInvalidPatch
+	}
+
+	var documentValue any
+	if len(document) > 0 {
+		if err := json.Unmarshal(document, &documentValue); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(merge(documentValue, patchValue))
+}
+
+// ApplyTo patches the value target points to through its JSON representation.
+// Members removed by the patch end up as zero values.
+func ApplyTo(target any, patch []byte) error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return errors.New("merge patch target must be a non-nil pointer")
+	}
+
+	document, err := json.Marshal(target)
+	if err != nil {
+		return err
+	}
+
+	patched, err := Apply(document, patch)
+	if err != nil {
+		return err
+	}
+
+	// Decoding into a fresh value keeps removed members and map keys from surviving
+	value.Elem().SetZero()
+	return json.Unmarshal(patched, target)
+}
+
+func merge(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = make(map[string]any)
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+
+		targetObject[key] = merge(targetObject[key], value)
+	}
+
+	return targetObject
+}
@@ -2,16 +2,25 @@ package security
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/pem"
 	"errors"
 	"fmt"
 	"hinsun-backend/internal/core/log"
 	"os"
 	"path/filepath"
+	"strings"
+)
+
+const (
+	privateKeyFileName = "private_key.pem"
+	publicKeyFileName  = "public_key.pem"
+	secretFileName     = "secret.key"
 )
 
 var (
@@ -28,6 +37,7 @@ type Cryptography interface {
 	) (*KeyPair, error)
 	LoadPrivateKey(path string) (any, error)
 	LoadPublicKey(path string) (any, error)
+	LoadSecret(path string) ([]byte, error)
 }
 
 type cryptography struct{}
@@ -46,12 +56,25 @@ func (c *cryptography) InitializeKeyPair(
 		return nil, err
 	}
 
-	privateKey, err := c.LoadPrivateKey(filepath.Join(rootPath, "private_key.pem"))
+	// HMAC uses the same shared secret for signing and verification
+	if algorithm.IsSymmetric() {
+		secret, err := c.LoadSecret(filepath.Join(rootPath, secretFileName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load secret: %w", err)
+		}
+
+		return &KeyPair{
+			SigningKey:      secret,
+			VerificationKey: secret,
+		}, nil
+	}
+
+	privateKey, err := c.LoadPrivateKey(filepath.Join(rootPath, privateKeyFileName))
 	if err != nil {
 		return nil, fmt.Errorf("failed to load private key: %w", err)
 	}
 
-	publicKey, err := c.LoadPublicKey(filepath.Join(rootPath, "public_key.pem"))
+	publicKey, err := c.LoadPublicKey(filepath.Join(rootPath, publicKeyFileName))
 	if err != nil {
 		return nil, fmt.Errorf("failed to load public key: %w", err)
 	}
@@ -64,11 +87,15 @@ func (c *cryptography) InitializeKeyPair(
 
 func (c *cryptography) checkKeypairExists(path string) bool {
 	exists := false
-	if _, err := os.Stat(filepath.Join(path, "private_key.pem")); err == nil {
+	if _, err := os.Stat(filepath.Join(path, privateKeyFileName)); err == nil {
 		exists = true
 	}
 
-	if _, err := os.Stat(filepath.Join(path, "public_key.pem")); err == nil {
+	if _, err := os.Stat(filepath.Join(path, publicKeyFileName)); err == nil {
+		exists = true
+	}
+
+	if _, err := os.Stat(filepath.Join(path, secretFileName)); err == nil {
 		exists = true
 	}
 
@@ -94,8 +121,12 @@ func (c *cryptography) generateKeyPair(path string, algorithm Algorithm, keySize
 		err = c.generateRSAKeyPair(path, keySize)
 	case ES256, ES384, ES521:
 		err = c.generateECKeyPair(algorithm, path)
+	case EdDSA:
+		err = c.generateEdDSAKeyPair(path)
+	case HS256, HS384, HS512:
+		err = c.generateHMACSecret(algorithm, path)
 	default:
-		return errors.New("unsupported algorithm: " + string(algorithm))
+		return fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, algorithm)
 	}
 
 	return err
@@ -109,7 +140,7 @@ func (c *cryptography) generateRSAKeyPair(outputDir string, keySize int) error {
 	}
 
 	// Save private key
-	privateKeyPath := filepath.Join(outputDir, "private_key.pem")
+	privateKeyPath := filepath.Join(outputDir, privateKeyFileName)
 	privateKeyFile, err := os.Create(privateKeyPath)
 	if err != nil {
 		return errors.New("failed to create private key file: " + err.Error())
@@ -128,7 +159,7 @@ func (c *cryptography) generateRSAKeyPair(outputDir string, keySize int) error {
 	}
 
 	// Save public key
-	publicKeyPath := filepath.Join(outputDir, "public_key.pem")
+	publicKeyPath := filepath.Join(outputDir, publicKeyFileName)
 	publicKeyFile, err := os.Create(publicKeyPath)
 	if err != nil {
 		return errors.New("failed to create public key file: " + err.Error())
@@ -164,7 +195,7 @@ func (c *cryptography) generateECKeyPair(algorithm Algorithm, outputDir string)
 	case ES521:
 		curve = elliptic.P521() // Note: P521, not P512
 	default:
-		log.Logger.Fatal("Unsupported algorithm: " + string(algorithm))
+		return fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, algorithm)
 	}
 
 	// Generate EC private key
@@ -174,7 +205,7 @@ func (c *cryptography) generateECKeyPair(algorithm Algorithm, outputDir string)
 	}
 
 	// Save private key
-	privateKeyPath := filepath.Join(outputDir, "private_key.pem")
+	privateKeyPath := filepath.Join(outputDir, privateKeyFileName)
 	privateKeyFile, err := os.Create(privateKeyPath)
 	if err != nil {
 		return errors.New("failed to create private key file: " + err.Error())
@@ -197,7 +228,7 @@ func (c *cryptography) generateECKeyPair(algorithm Algorithm, outputDir string)
 	}
 
 	// Save public key
-	publicKeyPath := filepath.Join(outputDir, "public_key.pem")
+	publicKeyPath := filepath.Join(outputDir, publicKeyFileName)
 	publicKeyFile, err := os.Create(publicKeyPath)
 	if err != nil {
 		return errors.New("failed to create public key file: " + err.Error())
@@ -222,7 +253,87 @@ func (c *cryptography) generateECKeyPair(algorithm Algorithm, outputDir string)
 	return nil
 }
 
-// LoadPublicKey loads a public key from a file (supports RSA and ECDSA)
+func (c *cryptography) generateEdDSAKeyPair(outputDir string) error {
+	// Generate Ed25519 key pair
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return errors.New("failed to generate Ed25519 key pair: " + err.Error())
+	}
+
+	// Save private key (Ed25519 keys are only representable as PKCS8)
+	privateKeyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return errors.New("failed to marshal private key: " + err.Error())
+	}
+
+	privateKeyPEM := &pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: privateKeyBytes,
+	}
+
+	if err := writePEMFile(filepath.Join(outputDir, privateKeyFileName), privateKeyPEM); err != nil {
+		return err
+	}
+
+	// Save public key
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return errors.New("failed to marshal public key: " + err.Error())
+	}
+
+	publicKeyPEM := &pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: publicKeyBytes,
+	}
+
+	return writePEMFile(filepath.Join(outputDir, publicKeyFileName), publicKeyPEM)
+}
+
+func (c *cryptography) generateHMACSecret(algorithm Algorithm, outputDir string) error {
+	secret := make([]byte, algorithm.MinSecretSize())
+	if _, err := rand.Read(secret); err != nil {
+		return errors.New("failed to generate secret: " + err.Error())
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(secret)
+	if err := os.WriteFile(filepath.Join(outputDir, secretFileName), []byte(encoded), 0600); err != nil {
+		return errors.New("failed to write secret to file: " + err.Error())
+	}
+
+	return nil
+}
+
+func writePEMFile(path string, block *pem.Block) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.New("failed to create key file: " + err.Error())
+	}
+
+	defer file.Close()
+
+	if err := pem.Encode(file, block); err != nil {
+		return errors.New("failed to write key to file: " + err.Error())
+	}
+
+	return nil
+}
+
+// LoadSecret loads a base64 encoded HMAC secret from a file
+func (c *cryptography) LoadSecret(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret file: %w", err)
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(secret) == 0 {
+		return nil, ErrInvalidKeyFormat
+	}
+
+	return secret, nil
+}
+
+// LoadPublicKey loads a public key from a file (supports RSA, ECDSA and Ed25519)
 func (c *cryptography) LoadPublicKey(path string) (any, error) {
 	keyData, err := os.ReadFile(path)
 	if err != nil {
@@ -251,7 +362,7 @@ func (c *cryptography) parsePrivateKey(keyData []byte) (any, error) {
 	// Try parsing as PKCS8 (universal format)
 	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
 		switch k := key.(type) {
-		case *rsa.PrivateKey, *ecdsa.PrivateKey:
+		case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey:
 			return k, nil
 		default:
 			return nil, ErrUnsupportedKey
@@ -281,7 +392,7 @@ func (c *cryptography) parsePublicKey(keyData []byte) (any, error) {
 	// Try parsing as PKIX (universal format)
 	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
 		switch k := key.(type) {
-		case *rsa.PublicKey, *ecdsa.PublicKey:
+		case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
 			return k, nil
 		default:
 			return nil, ErrUnsupportedKey
@@ -0,0 +1,153 @@
+package security
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// SecretBoxKeySize is the key size of AES-256
+	SecretBoxKeySize = 32
+
+	// sealedPrefix marks sealed values and the format version, plain base32 TOTP secrets never contain ':'
+	sealedPrefix = "v1:"
+)
+
+var (
+	ErrInvalidSecretBoxKey = errors.New("secret box key must be 32 bytes")
+	ErrNotSealed           = errors.New("value is not sealed")
+	ErrUnsealFailed        = errors.New("sealed value is corrupted or was sealed with another key")
+)
+
+// SecretBox encrypts small secrets stored in the database, such as TOTP secrets, with AES-GCM
+type SecretBox interface {
+	Seal(plaintext string) (string, error)
+	Open(sealed string) (string, error)
+}
+
+type secretBox struct {
+	aead cipher.AEAD
+}
+
+func NewSecretBox(key []byte) (SecretBox, error) {
+	if len(key) != SecretBoxKeySize {
+		return nil, ErrInvalidSecretBoxKey
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &secretBox{aead: aead}, nil
+}
+
+// Seal encrypts the plaintext under a random nonce, sealing the same value twice gives different results
+func (b *secretBox) Seal(plaintext string) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := b.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return sealedPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+func (b *secretBox) Open(sealed string) (string, error) {
+	encoded, ok := strings.CutPrefix(sealed, sealedPrefix)
+	if !ok {
+		return "", ErrNotSealed
+	}
+
+	data, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil || len(data) < b.aead.NonceSize() {
+		return "", ErrUnsealFailed
+	}
+
+	nonceSize := b.aead.NonceSize()
+	plaintext, err := b.aead.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return "", ErrUnsealFailed
+	}
+
+	return string(plaintext), nil
+}
+
+// IsSealed reports whether the value was produced by a SecretBox
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, sealedPrefix)
+}
+
+// ParseSecretBoxKey decodes a base64 encoded key
+func ParseSecretBoxKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("secret box key is not valid base64: %w", err)
+	}
+
+	if len(key) != SecretBoxKeySize {
+		return nil, ErrInvalidSecretBoxKey
+	}
+
+	return key, nil
+}
+
+// LoadOrCreateSecretBoxKey reads the base64 key stored at path, generating it on first use
+func LoadOrCreateSecretBoxKey(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		return ParseSecretBoxKey(string(data))
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read secret box key: %w", err)
+	}
+
+	key := make([]byte, SecretBoxKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate secret box key: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create secret box key directory: %w", err)
+	}
+
+	// Written aside then linked, so another instance never reads a partial key and the first
+	// instance to link wins
+	temp, err := os.CreateTemp(filepath.Dir(path), ".secret-box-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to write secret box key: %w", err)
+	}
+
+	defer os.Remove(temp.Name())
+	_, err = temp.WriteString(base64.StdEncoding.EncodeToString(key))
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to write secret box key: %w", err)
+	}
+
+	if err := os.Link(temp.Name(), path); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return LoadOrCreateSecretBoxKey(path)
+		}
+
+		return nil, fmt.Errorf("failed to write secret box key: %w", err)
+	}
+
+	return key, nil
+}
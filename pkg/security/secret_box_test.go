@@ -0,0 +1,98 @@
+package security
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestSecretBox(t *testing.T, fill byte) SecretBox {
+	t.Helper()
+	box, err := NewSecretBox(bytes.Repeat([]byte{fill}, SecretBoxKeySize))
+	if err != nil {
+		t.Fatalf("new secret box: %v", err)
+	}
+
+	return box
+}
+
+func TestSecretBox(t *testing.T) {
+	box := newTestSecretBox(t, 1)
+	secret := "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+
+	sealed, err := box.Seal(secret)
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+
+	if !IsSealed(sealed) || IsSealed(secret) || strings.Contains(sealed, secret) {
+		t.Fatalf("sealed = %q", sealed)
+	}
+
+	// The column holding sealed TOTP secrets is varchar(255)
+	if len(sealed) > 255 {
+		t.Errorf("sealed secret takes %d characters", len(sealed))
+	}
+
+	opened, err := box.Open(sealed)
+	if err != nil || opened != secret {
+		t.Fatalf("open = %q, %v; want %q", opened, err, secret)
+	}
+
+	if again, _ := box.Seal(secret); again == sealed {
+		t.Error("sealing twice gave the same value")
+	}
+
+	tampered := []byte(sealed)
+	tampered[len(tampered)-1] ^= 1
+	if _, err := box.Open(string(tampered)); !errors.Is(err, ErrUnsealFailed) {
+		t.Errorf("open tampered value error = %v, want %v", err, ErrUnsealFailed)
+	}
+
+	if _, err := newTestSecretBox(t, 2).Open(sealed); !errors.Is(err, ErrUnsealFailed) {
+		t.Errorf("open with another key error = %v, want %v", err, ErrUnsealFailed)
+	}
+
+	if _, err := box.Open(secret); !errors.Is(err, ErrNotSealed) {
+		t.Errorf("open plain secret error = %v, want %v", err, ErrNotSealed)
+	}
+
+	if _, err := box.Open(sealedPrefix + "AA"); !errors.Is(err, ErrUnsealFailed) {
+		t.Errorf("open truncated value error = %v, want %v", err, ErrUnsealFailed)
+	}
+}
+
+func TestSecretBoxKey(t *testing.T) {
+	if _, err := NewSecretBox(make([]byte, 16)); !errors.Is(err, ErrInvalidSecretBoxKey) {
+		t.Errorf("16 byte key error = %v, want %v", err, ErrInvalidSecretBoxKey)
+	}
+
+	if _, err := ParseSecretBoxKey("c2hvcnQ="); !errors.Is(err, ErrInvalidSecretBoxKey) {
+		t.Errorf("short key error = %v, want %v", err, ErrInvalidSecretBoxKey)
+	}
+
+	path := filepath.Join(t.TempDir(), "keys", "two_factor.key")
+	key, err := LoadOrCreateSecretBoxKey(path)
+	if err != nil {
+		t.Fatalf("create key: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("key file %v, %v; want mode 0600", info, err)
+	}
+
+	// Later starts, or other instances sharing the directory, read the same key
+	loaded, err := LoadOrCreateSecretBoxKey(path)
+	if err != nil || !bytes.Equal(loaded, key) {
+		t.Errorf("reloaded key differs: %v", err)
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("%d files next to the key, want the key only", len(entries))
+	}
+}
@@ -0,0 +1,180 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidTOTPSecret = errors.New("invalid totp secret")
+)
+
+// TOTP defines the interface for time-based one-time passwords (RFC 6238)
+type TOTP interface {
+	GenerateSecret() (string, error)
+	ProvisioningURI(secret, accountName string) string
+	Generate(secret string, at time.Time) (string, error)
+	Validate(secret, code string, at time.Time, lastStep uint64) (uint64, bool, error)
+}
+
+// TOTPParams holds the parameters for TOTP generation
+type TOTPParams struct {
+	Issuer      string
+	SecretBytes int
+	Digits      int
+	Period      uint64
+	Skew        uint64 // number of periods accepted before and after the current one
+}
+
+// DefaultTOTPParams returns parameters compatible with common authenticator apps
+func DefaultTOTPParams(issuer string) *TOTPParams {
+	return &TOTPParams{
+		Issuer:      issuer,
+		SecretBytes: 20, // 160 bits, as recommended by RFC 4226
+		Digits:      6,
+		Period:      30,
+		Skew:        1,
+	}
+}
+
+type totp struct {
+	params *TOTPParams
+}
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewTOTP creates a new TOTP generator
+func NewTOTP(params *TOTPParams) TOTP {
+	if params == nil {
+		params = DefaultTOTPParams("")
+	}
+	return &totp{params: params}
+}
+
+// GenerateSecret generates a random base32 encoded secret
+func (t *totp) GenerateSecret() (string, error) {
+	secret := make([]byte, t.params.SecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// ProvisioningURI builds an otpauth:// URI which can be rendered as a QR code
+func (t *totp) ProvisioningURI(secret, accountName string) string {
+	label := accountName
+	if t.params.Issuer != "" {
+		label = t.params.Issuer + ":" + accountName
+	}
+
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprintf("%d", t.params.Digits))
+	query.Set("period", fmt.Sprintf("%d", t.params.Period))
+	if t.params.Issuer != "" {
+		query.Set("issuer", t.params.Issuer)
+	}
+
+	uri := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + label,
+		RawQuery: query.Encode(),
+	}
+
+	return uri.String()
+}
+
+// Generate returns the code for the period containing the given time
+func (t *totp) Generate(secret string, at time.Time) (string, error) {
+	key, err := decodeTOTPSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return t.hotp(key, uint64(at.Unix())/t.params.Period), nil
+}
+
+// Validate checks the code against the current period and the allowed skew and returns the time
+// step it matched. Steps at or before lastStep were already used, so a code cannot be replayed
+// while it is still inside the window.
+func (t *totp) Validate(secret, code string, at time.Time, lastStep uint64) (uint64, bool, error) {
+	key, err := decodeTOTPSecret(secret)
+	if err != nil {
+		return 0, false, err
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) != t.params.Digits {
+		return 0, false, nil
+	}
+
+	counter := uint64(at.Unix()) / t.params.Period
+	for step := counter - min(counter, t.params.Skew); step <= counter+t.params.Skew; step++ {
+		if step > lastStep && hmac.Equal([]byte(t.hotp(key, step)), []byte(code)) {
+			return step, true, nil
+		}
+	}
+
+	return 0, false, nil
+}
+
+// hotp computes an HOTP value (RFC 4226) for the given counter
+func (t *totp) hotp(key []byte, counter uint64) string {
+	message := make([]byte, 8)
+	binary.BigEndian.PutUint64(message, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(message)
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < t.params.Digits; i++ {
+		modulo *= 10
+	}
+
+	return fmt.Sprintf("%0*d", t.params.Digits, value%modulo)
+}
+
+func decodeTOTPSecret(secret string) ([]byte, error) {
+	normalized := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(secret), " ", ""))
+	normalized = strings.TrimRight(normalized, "=")
+
+	key, err := totpEncoding.DecodeString(normalized)
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidTOTPSecret
+	}
+
+	return key, nil
+}
+
+// GenerateRecoveryCodes generates one-time recovery codes formatted as xxxxx-xxxxx
+func GenerateRecoveryCodes(count int) ([]string, error) {
+	codes := make([]string, 0, count)
+
+	for i := 0; i < count; i++ {
+		raw := make([]byte, 7)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, err
+		}
+
+		encoded := strings.ToLower(totpEncoding.EncodeToString(raw))[:10]
+		codes = append(codes, encoded[:5]+"-"+encoded[5:])
+	}
+
+	return codes, nil
+}
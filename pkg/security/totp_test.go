@@ -0,0 +1,48 @@
+package security
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTOTPRejectsUsedSteps(t *testing.T) {
+	totp := NewTOTP(DefaultTOTPParams("Hinsun"))
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("generate secret: %v", err)
+	}
+
+	now := time.Unix(1_700_000_000, 0)
+	code, err := totp.Generate(secret, now)
+	if err != nil {
+		t.Fatalf("generate code: %v", err)
+	}
+
+	step, ok, err := totp.Validate(secret, code, now, 0)
+	if err != nil || !ok {
+		t.Fatalf("fresh code rejected: %v", err)
+	}
+
+	if want := uint64(now.Unix()) / 30; step != want {
+		t.Errorf("step = %d, want %d", step, want)
+	}
+
+	// Replaying the code, even from the next period where the skew still covers it
+	if _, ok, _ := totp.Validate(secret, code, now, step); ok {
+		t.Error("code accepted again in the same period")
+	}
+
+	if _, ok, _ := totp.Validate(secret, code, now.Add(30*time.Second), step); ok {
+		t.Error("code accepted again in the next period")
+	}
+
+	// The code of the next period is still accepted
+	next, err := totp.Generate(secret, now.Add(30*time.Second))
+	if err != nil {
+		t.Fatalf("generate next code: %v", err)
+	}
+
+	if nextStep, ok, _ := totp.Validate(secret, next, now.Add(30*time.Second), step); !ok || nextStep != step+1 {
+		t.Errorf("next code: step %d, accepted %v", nextStep, ok)
+	}
+}
@@ -1,6 +1,9 @@
 package security
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 type Algorithm string
 
@@ -12,11 +15,18 @@ const (
 	ES384 Algorithm = "ES384"
 	ES521 Algorithm = "ES521"
 	HS256 Algorithm = "HS256"
+	HS384 Algorithm = "HS384"
+	HS512 Algorithm = "HS512"
+	EdDSA Algorithm = "EdDSA"
+)
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported algorithm")
 )
 
 type KeyPair struct {
-	SigningKey      any // private key for signing (RSA/ECDSA) or secret (HMAC)
-	VerificationKey any // public key for verification (RSA/ECDSA) or secret (HMAC)
+	SigningKey      any // private key for signing (RSA/ECDSA/Ed25519) or secret (HMAC)
+	VerificationKey any // public key for verification (RSA/ECDSA/Ed25519) or secret (HMAC)
 }
 
 func AlgorithmFromString(alg string) (Algorithm, error) {
@@ -35,7 +45,32 @@ func AlgorithmFromString(alg string) (Algorithm, error) {
 		return ES521, nil
 	case "HS256":
 		return HS256, nil
+	case "HS384":
+		return HS384, nil
+	case "HS512":
+		return HS512, nil
+	case "EdDSA":
+		return EdDSA, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, alg)
+	}
+}
+
+// IsSymmetric reports whether the algorithm signs and verifies with the same shared secret
+func (a Algorithm) IsSymmetric() bool {
+	return a == HS256 || a == HS384 || a == HS512
+}
+
+// MinSecretSize returns the minimum HMAC secret length in bytes, which matches the hash output size (RFC 7518 section 3.2)
+func (a Algorithm) MinSecretSize() int {
+	switch a {
+	case HS256:
+		return 32
+	case HS384:
+		return 48
+	case HS512:
+		return 64
 	default:
-		return "", errors.New("unsupported algorithm: " + alg)
+		return 0
 	}
 }
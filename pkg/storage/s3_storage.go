@@ -0,0 +1,278 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+type S3Params struct {
+	Endpoint     string // e.g. https://s3.eu-central-1.amazonaws.com or http://localhost:9000 for MinIO
+	Region       string
+	Bucket       string
+	AccessKey    string
+	SecretKey    string
+	UsePathStyle bool   // bucket in the path instead of the host name, required by MinIO
+	PublicURL    string // base download URL, defaults to the bucket URL
+}
+
+type s3Storage struct {
+	params   S3Params
+	endpoint *url.URL
+	client   *http.Client
+}
+
+// NewS3Storage stores blobs in an S3 compatible bucket, requests are signed with AWS Signature
+// Version 4 so it works against AWS S3 as well as MinIO
+func NewS3Storage(params S3Params) (BlobStorage, error) {
+	endpoint, err := url.Parse(strings.TrimRight(params.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", params.Endpoint)
+	}
+
+	if params.Bucket == "" {
+		return nil, fmt.Errorf("S3 bucket is required")
+	}
+
+	if params.Region == "" {
+		params.Region = "us-east-1"
+	}
+
+	storage := &s3Storage{
+		params:   params,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 60 * time.Second},
+	}
+
+	if storage.params.PublicURL == "" {
+		storage.params.PublicURL = storage.bucketURL().String()
+	}
+
+	return storage, nil
+}
+
+func (s *s3Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	request, err := s.newRequest(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+
+	request.ContentLength = size
+	request.Header.Set("Content-Type", contentType)
+
+	response, err := s.do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return responseError("put", key, response)
+	}
+
+	return nil
+}
+
+func (s *s3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	request, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return response.Body, nil
+	case http.StatusNotFound:
+		response.Body.Close()
+		return nil, ErrNotFound
+	default:
+		defer response.Body.Close()
+		return nil, responseError("get", key, response)
+	}
+}
+
+func (s *s3Storage) Delete(ctx context.Context, key string) error {
+	request, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := s.do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNotFound {
+		return responseError("delete", key, response)
+	}
+
+	return nil
+}
+
+func (s *s3Storage) URL(key string) string {
+	return joinURL(s.params.PublicURL, key)
+}
+
+func (s *s3Storage) bucketURL() *url.URL {
+	bucketURL := *s.endpoint
+	if s.params.UsePathStyle {
+		bucketURL.Path = strings.TrimRight(bucketURL.Path, "/") + "/" + s.params.Bucket
+	} else {
+		bucketURL.Host = s.params.Bucket + "." + bucketURL.Host
+	}
+
+	return &bucketURL
+}
+
+func (s *s3Storage) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	objectURL := s.bucketURL()
+	objectURL.Path = objectURL.Path + "/" + strings.TrimLeft(key, "/")
+	// Send the path encoded the way it is signed
+	objectURL.RawPath = uriEncode(objectURL.Path, false)
+
+	request, err := http.NewRequestWithContext(ctx, method, objectURL.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build S3 request: %w", err)
+	}
+
+	return request, nil
+}
+
+func (s *s3Storage) do(request *http.Request) (*http.Response, error) {
+	s.sign(request, time.Now().UTC())
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("S3 request failed: %w", err)
+	}
+
+	return response, nil
+}
+
+// sign adds the AWS Signature Version 4 headers. Host and every header already set are signed.
+// The payload is left unsigned so bodies stream, unless X-Amz-Content-Sha256 carries its hash.
+func (s *s3Storage) sign(request *http.Request, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	scope := date + "/" + s.params.Region + "/s3/aws4_request"
+
+	request.Header.Set("X-Amz-Date", amzDate)
+	payloadHash := request.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash == "" {
+		payloadHash = unsignedPayload
+		request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	}
+
+	signedHeaders := []string{"host"}
+	for name := range request.Header {
+		if name = strings.ToLower(name); name != "authorization" {
+			signedHeaders = append(signedHeaders, name)
+		}
+	}
+	sort.Strings(signedHeaders)
+
+	signature := s.signature(canonicalRequest(request, signedHeaders, payloadHash), amzDate, date)
+	request.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.params.AccessKey, scope, strings.Join(signedHeaders, ";"), signature,
+	))
+}
+
+func (s *s3Storage) signature(canonicalRequest, amzDate, date string) string {
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		date + "/" + s.params.Region + "/s3/aws4_request",
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.params.SecretKey), date)
+	signingKey = hmacSHA256(signingKey, s.params.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+}
+
+// canonicalRequest lays out the request as signed: the URI-encoded path, the sorted query and
+// the named headers (lowercase and sorted) with their trimmed values
+func canonicalRequest(request *http.Request, signedHeaders []string, payloadHash string) string {
+	query := request.URL.Query()
+	pairs := make([]string, 0, len(query))
+	for key, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(key, true)+"="+uriEncode(value, true))
+		}
+	}
+	sort.Strings(pairs)
+
+	var headers strings.Builder
+	for _, name := range signedHeaders {
+		value := request.Host
+		if name != "host" {
+			value = strings.Join(request.Header.Values(name), ",")
+		} else if value == "" {
+			value = request.URL.Host
+		}
+
+		headers.WriteString(name + ":" + strings.Join(strings.Fields(value), " ") + "\n")
+	}
+
+	return strings.Join([]string{
+		request.Method,
+		uriEncode(request.URL.Path, false),
+		strings.Join(pairs, "&"),
+		headers.String(),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+}
+
+// uriEncode percent-encodes everything but the unreserved characters of RFC 3986, which is
+// stricter than net/url; slashes are kept in paths
+func uriEncode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9', b == '-', b == '_', b == '.', b == '~':
+			encoded.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+
+	return encoded.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func responseError(operation, key string, response *http.Response) error {
+	message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+	return fmt.Errorf("S3 %s %s failed with status %d: %s", operation, key, response.StatusCode, strings.TrimSpace(string(message)))
+}
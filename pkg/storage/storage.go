@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ErrNotFound is returned by Get for keys that were never stored or were deleted
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStorage stores opaque files under slash separated keys
+type BlobStorage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete succeeds for keys that do not exist
+	Delete(ctx context.Context, key string) error
+	// URL is where clients download the blob from
+	URL(key string) string
+}
+
+func joinURL(baseURL, key string) string {
+	return strings.TrimRight(baseURL, "/") + "/" + strings.TrimLeft(key, "/")
+}